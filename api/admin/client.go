@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
@@ -76,3 +77,81 @@ func (c *Client) Stacktrace() (bool, error) {
 	err := c.requester.SendRequest("stacktrace", struct{}{}, res)
 	return res.Success, err
 }
+
+// Connect ...
+func (c *Client) Connect(ip string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("connect", &ConnectArgs{
+		IP: ip,
+	}, res)
+	return res.Success, err
+}
+
+// Disconnect ...
+func (c *Client) Disconnect(nodeID string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("disconnect", &DisconnectArgs{
+		NodeID: nodeID,
+	}, res)
+	return res.Success, err
+}
+
+// BanNodeID ...
+func (c *Client) BanNodeID(nodeID string, duration time.Duration) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("ban", &BanArgs{
+		NodeID:   nodeID,
+		Duration: duration.String(),
+	}, res)
+	return res.Success, err
+}
+
+// BanIP ...
+func (c *Client) BanIP(ip string, duration time.Duration) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("ban", &BanArgs{
+		IP:       ip,
+		Duration: duration.String(),
+	}, res)
+	return res.Success, err
+}
+
+// StorageUsage ...
+func (c *Client) StorageUsage() (*StorageUsageReply, error) {
+	res := &StorageUsageReply{}
+	err := c.requester.SendRequest("storageUsage", struct{}{}, res)
+	return res, err
+}
+
+// InspectConsensus ...
+func (c *Client) InspectConsensus(chain string) (*InspectConsensusReply, error) {
+	res := &InspectConsensusReply{}
+	err := c.requester.SendRequest("inspectConsensus", &InspectConsensusArgs{
+		Chain: chain,
+	}, res)
+	return res, err
+}
+
+// GetBenched ...
+func (c *Client) GetBenched() ([]BenchEvent, error) {
+	res := &GetBenchedReply{}
+	err := c.requester.SendRequest("getBenched", struct{}{}, res)
+	return res.Benched, err
+}
+
+// Unbench ...
+func (c *Client) Unbench(nodeID, chain string) ([]ids.ID, error) {
+	res := &UnbenchReply{}
+	err := c.requester.SendRequest("unbench", &UnbenchArgs{
+		NodeID: nodeID,
+		Chain:  chain,
+	}, res)
+	return res.ChainIDs, err
+}
+
+// GetBenchHistory ...
+func (c *Client) GetBenchHistory() ([]BenchEvent, error) {
+	res := &GetBenchHistoryReply{}
+	err := c.requester.SendRequest("getBenchHistory", struct{}{}, res)
+	return res.Events, err
+}
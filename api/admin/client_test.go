@@ -6,6 +6,7 @@ package admin
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/utils/rpc"
@@ -194,3 +195,79 @@ func TestStacktrace(t *testing.T) {
 		}
 	}
 }
+
+func TestConnect(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.Connect("127.0.0.1:9651")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.Disconnect("NodeID-111111111111111111116DBWJs")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestBanNodeID(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.BanNodeID("NodeID-111111111111111111116DBWJs", time.Hour)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestBanIP(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.BanIP("127.0.0.1", time.Hour)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
@@ -5,14 +5,22 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
@@ -26,7 +34,10 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
+	errAliasTooLong        = errors.New("alias length is too long")
+	errNonPositiveDuration = errors.New("ban duration must be positive")
+	errNoBanTarget         = errors.New("argument 'nodeID' or 'ip' must be given")
+	errTwoBanTargets       = errors.New("only one of 'nodeID' and 'ip' may be given")
 )
 
 // Admin is the API service for node admin management
@@ -34,11 +45,13 @@ type Admin struct {
 	log          logging.Logger
 	performance  Performance
 	chainManager chains.Manager
+	networking   network.Network
+	benchlist    benchlist.Manager
 	httpServer   *api.Server
 }
 
 // NewService returns a new admin API service
-func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api.Server) (*common.HTTPHandler, error) {
+func NewService(log logging.Logger, chainManager chains.Manager, peers network.Network, benchlist benchlist.Manager, httpServer *api.Server) (*common.HTTPHandler, error) {
 	newServer := rpc.NewServer()
 	codec := cjson.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
@@ -46,6 +59,8 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api
 	if err := newServer.RegisterService(&Admin{
 		log:          log,
 		chainManager: chainManager,
+		networking:   peers,
+		benchlist:    benchlist,
 		httpServer:   httpServer,
 	}, "admin"); err != nil {
 		return nil, err
@@ -136,3 +151,294 @@ func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.Succes
 	stacktrace := []byte(logging.Stacktrace{Global: true}.String())
 	return ioutil.WriteFile(stacktraceFile, stacktrace, 0600)
 }
+
+// ConnectArgs are the arguments for calling Connect
+type ConnectArgs struct {
+	// IP:port of the node to connect to
+	IP string `json:"ip"`
+}
+
+// Connect attempts to connect to the node at the provided IP. Connection
+// attempts to the IP are retried until they succeed. Fails if the node only
+// connects to static peers and the IP isn't one of them.
+func (service *Admin) Connect(_ *http.Request, args *ConnectArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: Connect called with IP: %s", args.IP)
+
+	ip, err := utils.ToIPDesc(args.IP)
+	if err != nil {
+		return fmt.Errorf("couldn't parse IP %q: %w", args.IP, err)
+	}
+
+	if err := service.networking.Track(ip); err != nil {
+		return fmt.Errorf("couldn't connect to %s: %w", ip, err)
+	}
+	reply.Success = true
+	return nil
+}
+
+// DisconnectArgs are the arguments for calling Disconnect
+type DisconnectArgs struct {
+	NodeID string `json:"nodeID"`
+}
+
+// Disconnect closes the connection to the provided node
+func (service *Admin) Disconnect(_ *http.Request, args *DisconnectArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: Disconnect called with NodeID: %s", args.NodeID)
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return fmt.Errorf("couldn't parse nodeID %q: %w", args.NodeID, err)
+	}
+
+	if err := service.networking.Disconnect(nodeID); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// BanArgs are the arguments for calling Ban
+type BanArgs struct {
+	// Exactly one of [NodeID] and [IP] should be provided. If [IP] is
+	// provided, it should not include a port.
+	NodeID string `json:"nodeID"`
+	IP     string `json:"ip"`
+	// How long the ban lasts, formatted as a duration string, e.g. "1h30m"
+	Duration string `json:"duration"`
+}
+
+// Ban disconnects from the provided node or IP and prevents it from
+// connecting for the provided duration. Bans are kept across restarts.
+func (service *Admin) Ban(_ *http.Request, args *BanArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: Ban called with NodeID: %s, IP: %s, Duration: %s", args.NodeID, args.IP, args.Duration)
+
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return fmt.Errorf("couldn't parse duration %q: %w", args.Duration, err)
+	}
+	if duration <= 0 {
+		return errNonPositiveDuration
+	}
+
+	switch {
+	case args.NodeID != "" && args.IP != "":
+		return errTwoBanTargets
+	case args.NodeID != "":
+		nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("couldn't parse nodeID %q: %w", args.NodeID, err)
+		}
+		err = service.networking.Ban(nodeID, duration)
+		reply.Success = err == nil
+		return err
+	case args.IP != "":
+		ip := net.ParseIP(args.IP)
+		if ip == nil {
+			return fmt.Errorf("couldn't parse IP %q", args.IP)
+		}
+		err = service.networking.BanIP(ip, duration)
+		reply.Success = err == nil
+		return err
+	default:
+		return errNoBanTarget
+	}
+}
+
+// ChainStorageUsage is the approximate number of bytes of storage used by a
+// chain
+type ChainStorageUsage struct {
+	ChainID ids.ID   `json:"chainID"`
+	Aliases []string `json:"aliases"`
+	VMID    ids.ID   `json:"vmID"`
+	// Sum of the sizes of the chain's databases
+	Total cjson.Uint64 `json:"total"`
+	// Size of each of the chain's databases, such as "vm" and the
+	// bootstrapping queues
+	Databases map[string]cjson.Uint64 `json:"databases"`
+	// Size of each well-known prefix in the VM's database. These sizes are
+	// included in the size of the "vm" database.
+	VMPrefixes map[string]cjson.Uint64 `json:"vmPrefixes"`
+}
+
+// StorageUsageReply are the results from calling StorageUsage
+type StorageUsageReply struct {
+	// Size of the node's entire database
+	Total  cjson.Uint64        `json:"total"`
+	Chains []ChainStorageUsage `json:"chains"`
+}
+
+// StorageUsage returns the approximate number of bytes of storage used by the
+// node's database, by each chain and by each well-known prefix of the chains'
+// databases. Data that hasn't been flushed to disk yet isn't included.
+func (service *Admin) StorageUsage(_ *http.Request, _ *struct{}, reply *StorageUsageReply) error {
+	service.log.Info("Admin: StorageUsage called")
+
+	usage, err := service.chainManager.StorageUsage()
+	if err != nil {
+		return fmt.Errorf("couldn't estimate storage usage: %w", err)
+	}
+
+	reply.Total = cjson.Uint64(usage.Total)
+	reply.Chains = make([]ChainStorageUsage, len(usage.Chains))
+	for i, chainUsage := range usage.Chains {
+		chain := ChainStorageUsage{
+			ChainID:    chainUsage.ChainID,
+			Aliases:    service.chainManager.Aliases(chainUsage.ChainID),
+			VMID:       chainUsage.VMID,
+			Total:      cjson.Uint64(chainUsage.Total),
+			Databases:  make(map[string]cjson.Uint64, len(chainUsage.Databases)),
+			VMPrefixes: make(map[string]cjson.Uint64, len(chainUsage.VMPrefixes)),
+		}
+		for name, size := range chainUsage.Databases {
+			chain.Databases[name] = cjson.Uint64(size)
+		}
+		for name, size := range chainUsage.VMPrefixes {
+			chain.VMPrefixes[name] = cjson.Uint64(size)
+		}
+		reply.Chains[i] = chain
+	}
+	return nil
+}
+
+// InspectConsensusArgs are the arguments for calling InspectConsensus
+type InspectConsensusArgs struct {
+	// ID or alias of the chain
+	Chain string `json:"chain"`
+}
+
+// InspectConsensusReply are the results from calling InspectConsensus
+type InspectConsensusReply struct {
+	ChainID ids.ID `json:"chainID"`
+	// Description of the chain's consensus state, such as the processing
+	// blocks or vertices, the outstanding polls and the validators they're
+	// waiting on, and the operations that are blocked on missing containers
+	State interface{} `json:"state"`
+}
+
+// InspectConsensus returns a description of the consensus state of a chain,
+// which can be used to debug chains that aren't making progress
+func (service *Admin) InspectConsensus(_ *http.Request, args *InspectConsensusArgs, reply *InspectConsensusReply) error {
+	service.log.Info("Admin: InspectConsensus called with Chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	state, err := service.chainManager.Inspect(chainID)
+	if err != nil {
+		return fmt.Errorf("couldn't inspect chain %s: %w", chainID, err)
+	}
+
+	reply.ChainID = chainID
+	reply.State = state
+	return nil
+}
+
+// BenchEvent describes a validator being benched on a chain
+type BenchEvent struct {
+	NodeID  string `json:"nodeID"`
+	ChainID ids.ID `json:"chainID"`
+	// Why the validator was benched
+	Reason string `json:"reason"`
+	// Number of consecutive queries to the validator that failed
+	Failures     cjson.Uint32 `json:"failures"`
+	FirstFailure time.Time    `json:"firstFailure"`
+	LastFailure  time.Time    `json:"lastFailure"`
+	// Times the validator was benched and the bench expires
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func newBenchEvent(e benchlist.Event) BenchEvent {
+	return BenchEvent{
+		NodeID:  e.ValidatorID.PrefixedString(constants.NodeIDPrefix),
+		ChainID: e.ChainID,
+		Reason: fmt.Sprintf(
+			"%d consecutive queries failed between %s and %s",
+			e.Failures,
+			e.FirstFailure.UTC().Format(time.RFC3339),
+			e.LastFailure.UTC().Format(time.RFC3339),
+		),
+		Failures:     cjson.Uint32(e.Failures),
+		FirstFailure: e.FirstFailure,
+		LastFailure:  e.LastFailure,
+		Start:        e.Start,
+		End:          e.End,
+	}
+}
+
+func newBenchEvents(events []benchlist.Event) []BenchEvent {
+	benchEvents := make([]BenchEvent, len(events))
+	for i, e := range events {
+		benchEvents[i] = newBenchEvent(e)
+	}
+	return benchEvents
+}
+
+// GetBenchedReply are the results from calling GetBenched
+type GetBenchedReply struct {
+	Benched []BenchEvent `json:"benched"`
+}
+
+// GetBenched returns the validators that are currently benched on each chain,
+// ordered by the time their benches expire
+func (service *Admin) GetBenched(_ *http.Request, _ *struct{}, reply *GetBenchedReply) error {
+	service.log.Info("Admin: GetBenched called")
+
+	reply.Benched = newBenchEvents(service.benchlist.Benched())
+	return nil
+}
+
+// UnbenchArgs are the arguments for calling Unbench
+type UnbenchArgs struct {
+	NodeID string `json:"nodeID"`
+	// ID or alias of the chain to unbench the node on. If empty, the node is
+	// unbenched on every chain.
+	Chain string `json:"chain"`
+}
+
+// UnbenchReply are the results from calling Unbench
+type UnbenchReply struct {
+	// Chains that the node was benched on
+	ChainIDs []ids.ID `json:"chainIDs"`
+}
+
+// Unbench removes a node from the benchlist of a chain, or of every chain
+func (service *Admin) Unbench(_ *http.Request, args *UnbenchArgs, reply *UnbenchReply) error {
+	service.log.Info("Admin: Unbench called with NodeID: %s, Chain: %s", args.NodeID, args.Chain)
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return fmt.Errorf("couldn't parse nodeID %q: %w", args.NodeID, err)
+	}
+	if args.Chain == "" {
+		reply.ChainIDs = service.benchlist.Unbench(nodeID)
+		return nil
+	}
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	reply.ChainIDs = service.benchlist.Unbench(nodeID, chainID)
+	return nil
+}
+
+// GetBenchHistoryReply are the results from calling GetBenchHistory
+type GetBenchHistoryReply struct {
+	// Most recent bench events, newest first
+	Events []BenchEvent `json:"events"`
+}
+
+// GetBenchHistory returns the most recent bench events, including the events
+// from before the node restarted
+func (service *Admin) GetBenchHistory(_ *http.Request, _ *struct{}, reply *GetBenchHistoryReply) error {
+	service.log.Info("Admin: GetBenchHistory called")
+
+	events, err := service.benchlist.History()
+	if err != nil {
+		return fmt.Errorf("couldn't read the bench history: %w", err)
+	}
+	reply.Events = newBenchEvents(events)
+	return nil
+}
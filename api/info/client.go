@@ -54,6 +54,17 @@ func (c *Client) GetBlockchainID(alias string) (string, error) {
 
 // Peers ...
 func (c *Client) Peers() ([]network.PeerID, error) {
+	peers, err := c.DetailedPeers()
+	peerIDs := make([]network.PeerID, len(peers))
+	for i, peer := range peers {
+		peerIDs[i] = peer.PeerID
+	}
+	return peerIDs, err
+}
+
+// DetailedPeers returns the connected peers along with the chains they are
+// benched on and their observed latency
+func (c *Client) DetailedPeers() ([]Peer, error) {
 	res := &PeersReply{}
 	err := c.requester.SendRequest("peers", struct{}{}, res)
 	return res.Peers, err
@@ -81,3 +92,10 @@ func (c *Client) GetNodeIP() (string, error) {
 	err := c.requester.SendRequest("getNodeIP", struct{}{}, res)
 	return res.IP, err
 }
+
+// Uptime ...
+func (c *Client) Uptime() (*UptimeResponse, error) {
+	res := &UptimeResponse{}
+	err := c.requester.SendRequest("uptime", struct{}{}, res)
+	return res, err
+}
@@ -4,6 +4,7 @@
 package info
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -13,12 +14,16 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
 )
 
+var errNotValidator = errors.New("this node isn't a primary network validator")
+
 // Info is the API service for unprivileged info on a node
 type Info struct {
 	version       version.Version
@@ -27,6 +32,8 @@ type Info struct {
 	log           logging.Logger
 	networking    network.Network
 	chainManager  chains.Manager
+	benchlist     benchlist.Manager
+	timeouts      *timeout.Manager
 	creationTxFee uint64
 	txFee         uint64
 }
@@ -39,6 +46,8 @@ func NewService(
 	networkID uint32,
 	chainManager chains.Manager,
 	peers network.Network,
+	benchlist benchlist.Manager,
+	timeouts *timeout.Manager,
 	creationTxFee uint64,
 	txFee uint64,
 ) (*common.HTTPHandler, error) {
@@ -53,6 +62,8 @@ func NewService(
 		log:           log,
 		chainManager:  chainManager,
 		networking:    peers,
+		benchlist:     benchlist,
+		timeouts:      timeouts,
 		creationTxFee: creationTxFee,
 		txFee:         txFee,
 	}, "info"); err != nil {
@@ -132,19 +143,64 @@ func (service *Info) GetBlockchainID(_ *http.Request, args *GetBlockchainIDArgs,
 	return err
 }
 
+// Peer is the description of a peer returned by Peers
+type Peer struct {
+	network.PeerID
+
+	// IDs of the chains that the peer is currently benched on
+	Benched []string `json:"benched"`
+	// Moving average of the time, in nanoseconds, that the peer has taken to
+	// respond to consensus queries. 0 if the peer hasn't responded to a query.
+	ObservedLatency json.Uint64 `json:"observedLatency"`
+}
+
 // PeersReply are the results from calling Peers
 type PeersReply struct {
 	// Number of elements in [Peers]
 	NumPeers json.Uint64 `json:"numPeers"`
+	// Number of elements in [Peers] that connected to this node
+	NumInbound json.Uint64 `json:"numInbound"`
+	// Number of elements in [Peers] that this node connected to
+	NumOutbound json.Uint64 `json:"numOutbound"`
+	// Number of elements in [Peers] that are validators
+	NumValidators json.Uint64 `json:"numValidators"`
 	// Each element is a peer
-	Peers []network.PeerID `json:"peers"`
+	Peers []Peer `json:"peers"`
 }
 
 // Peers returns the list of current validators
 func (service *Info) Peers(_ *http.Request, _ *struct{}, reply *PeersReply) error {
 	service.log.Info("Info: Peers called")
 
-	reply.Peers = service.networking.Peers()
+	peers := service.networking.Peers()
+	reply.Peers = make([]Peer, len(peers))
+	for i, peerID := range peers {
+		peer := Peer{
+			PeerID:  peerID,
+			Benched: []string{},
+		}
+
+		nodeID, err := ids.ShortFromPrefixedString(peerID.ID, constants.NodeIDPrefix)
+		if err != nil {
+			return fmt.Errorf("couldn't parse peer's ID %q: %w", peerID.ID, err)
+		}
+		for _, chainID := range service.benchlist.GetBenched(nodeID) {
+			peer.Benched = append(peer.Benched, chainID.String())
+		}
+		if latency, ok := service.timeouts.Latency(nodeID); ok {
+			peer.ObservedLatency = json.Uint64(latency)
+		}
+		reply.Peers[i] = peer
+
+		if peerID.Inbound {
+			reply.NumInbound++
+		} else {
+			reply.NumOutbound++
+		}
+		if peerID.Validator {
+			reply.NumValidators++
+		}
+	}
 	reply.NumPeers = json.Uint64(len(reply.Peers))
 	return nil
 }
@@ -202,3 +258,27 @@ func (service *Info) GetNodeIP(_ *http.Request, _ *struct{}, reply *GetNodeIPRep
 	reply.IP = service.networking.IP().String()
 	return nil
 }
+
+// UptimeResponse are the results from calling Uptime
+type UptimeResponse struct {
+	// RewardingStakePercentage is the percentage of stake, in [0, 100], that
+	// observes this node's uptime as meeting the uptime requirement
+	RewardingStakePercentage json.Float32 `json:"rewardingStakePercentage"`
+
+	// WeightedAveragePercentage is the stake weighted average of the uptimes,
+	// in [0, 100], that the connected validators observe for this node
+	WeightedAveragePercentage json.Float32 `json:"weightedAveragePercentage"`
+}
+
+// Uptime returns how the connected validators perceive the uptime of this node
+func (service *Info) Uptime(_ *http.Request, _ *struct{}, reply *UptimeResponse) error {
+	service.log.Info("Info: Uptime called")
+
+	result, isValidator := service.networking.NodeUptime()
+	if !isValidator {
+		return errNotValidator
+	}
+	reply.RewardingStakePercentage = json.Float32(result.RewardingStakePercentage)
+	reply.WeightedAveragePercentage = json.Float32(result.WeightedAveragePercentage)
+	return nil
+}
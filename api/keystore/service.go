@@ -12,7 +12,6 @@ import (
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/api"
-	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/encdb"
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -64,6 +63,11 @@ type Keystore struct {
 	// Value: The user with that name
 	users map[string]*password.Hash
 
+	// Key: username
+	// Value: The keys derived from the user's password, so that opening the
+	// user's databases doesn't derive them again
+	keys map[string]*encdb.KeyCache
+
 	// Used to persist users and their data
 	userDB database.Database
 	bcDB   database.Database
@@ -87,6 +91,7 @@ func (ks *Keystore) Initialize(log logging.Logger, db database.Database) error {
 	ks.log = log
 	ks.codec = manager
 	ks.users = make(map[string]*password.Hash)
+	ks.keys = make(map[string]*encdb.KeyCache)
 	ks.userDB = prefixdb.New([]byte("users"), db)
 	ks.bcDB = prefixdb.New([]byte("bcs"), db)
 	return nil
@@ -279,7 +284,7 @@ func (ks *Keystore) ImportUser(r *http.Request, args *ImportUserArgs, reply *api
 		}
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	if err := database.WriteAll(dataBatch, userBatch); err != nil {
 		return err
 	}
 
@@ -331,12 +336,13 @@ func (ks *Keystore) DeleteUser(_ *http.Request, args *api.UserPass, reply *api.S
 		return err
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	if err := database.WriteAll(dataBatch, userBatch); err != nil {
 		return err
 	}
 
 	// delete from users map.
 	delete(ks.users, args.Username)
+	delete(ks.keys, args.Username)
 
 	reply.Success = true
 	return nil
@@ -365,9 +371,17 @@ func (ks *Keystore) GetDatabase(bID ids.ID, username, password string) (database
 		return nil, fmt.Errorf("incorrect password for user %q", username)
 	}
 
+	keys, ok := ks.keys[username]
+	if !ok {
+		keys = &encdb.KeyCache{}
+		ks.keys[username] = keys
+	}
+
 	userDB := prefixdb.New([]byte(username), ks.bcDB)
 	bcDB := prefixdb.NewNested(bID[:], userDB)
-	return encdb.New([]byte(password), bcDB)
+	// Keystore databases are small and hold private keys, so their keys are
+	// hashed as well. Databases written by older versions are upgraded here.
+	return encdb.NewWithKeyCache([]byte(password), bcDB, encdb.Config{HashKeys: true}, keys)
 }
 
 // AddUser attempts to register this username and password as a new user of the
@@ -102,7 +102,7 @@ func (sm *sharedMemory) Put(peerChainID ids.ID, elems []*Element, batches ...dat
 	if err != nil {
 		return err
 	}
-	return WriteAll(myBatch, batches...)
+	return database.WriteAll(myBatch, batches...)
 }
 
 func (sm *sharedMemory) Get(peerChainID ids.ID, keys [][]byte) ([][]byte, error) {
@@ -194,7 +194,7 @@ func (sm *sharedMemory) Remove(peerChainID ids.ID, keys [][]byte, batches ...dat
 	if err != nil {
 		return err
 	}
-	return WriteAll(myBatch, batches...)
+	return database.WriteAll(myBatch, batches...)
 }
 
 type state struct {
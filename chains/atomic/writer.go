@@ -9,13 +9,8 @@ import (
 
 // WriteAll assumes all batches have the same underlying database. Batches
 // should not be modified after being passed to this function.
+//
+// Deprecated: use database.WriteAll.
 func WriteAll(baseBatch database.Batch, batches ...database.Batch) error {
-	baseBatch = baseBatch.Inner()
-	for _, batch := range batches {
-		batch = batch.Inner()
-		if err := batch.Replay(baseBatch); err != nil {
-			return err
-		}
-	}
-	return baseBatch.Write()
+	return database.WriteAll(baseBatch, batches...)
 }
@@ -0,0 +1,37 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// VMDatabaseName is the name of the database that a chain's VM stores its data
+// in
+const VMDatabaseName = vmDBName
+
+// DatabaseNames are the names of the databases that a chain may store its
+// data in. Avalanche chains use the "vm", "vertex", "vertex_bs" and "tx_bs"
+// databases, while snowman chains use the "vm" and "bs" databases.
+var DatabaseNames = []string{
+	vmDBName,
+	vertexDBName,
+	vertexBootstrappingDBName,
+	txBootstrappingDBName,
+	bootstrappingDBName,
+}
+
+// Databases returns the view of each of the databases in [DatabaseNames] of
+// the chain [chainID], within the node's database [db]. This allows a chain's
+// data to be found without running the chain.
+func Databases(db database.Database, chainID ids.ID) map[string]database.Database {
+	chainDB := prefixdb.New(chainID[:], db)
+	dbs := make(map[string]database.Database, len(DatabaseNames))
+	for _, name := range DatabaseNames {
+		dbs[name] = prefixdb.New([]byte(name), chainDB)
+	}
+	return dbs
+}
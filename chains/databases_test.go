@@ -0,0 +1,47 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestDatabases(t *testing.T) {
+	db := memdb.New()
+	chainID := ids.GenerateTestID()
+
+	// Matches the databases created when a chain is built
+	chainDB := prefixdb.New(chainID[:], db)
+	vmDB := prefixdb.New([]byte(vmDBName), chainDB)
+	bootstrappingDB := prefixdb.New([]byte(bootstrappingDBName), chainDB)
+	assert.NoError(t, vmDB.Put([]byte("vm key"), []byte("vm value")))
+	assert.NoError(t, bootstrappingDB.Put([]byte("bs key"), []byte("bs value")))
+
+	dbs := Databases(db, chainID)
+	assert.Len(t, dbs, len(DatabaseNames))
+
+	value, err := dbs[vmDBName].Get([]byte("vm key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("vm value"), value)
+
+	value, err = dbs[bootstrappingDBName].Get([]byte("bs key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("bs value"), value)
+
+	has, err := dbs[vertexDBName].Has([]byte("vm key"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	// Another chain's databases don't overlap
+	otherDBs := Databases(db, ids.GenerateTestID())
+	has, err = otherDBs[vmDBName].Has([]byte("vm key"))
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
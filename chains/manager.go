@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/keystore"
@@ -23,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/networking/faults"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
@@ -43,6 +46,13 @@ import (
 
 const (
 	defaultChannelSize = 1024
+
+	// Names of the databases that chains store their data in
+	vmDBName                  = "vm"
+	vertexDBName              = "vertex"
+	vertexBootstrappingDBName = "vertex_bs"
+	txBootstrappingDBName     = "tx_bs"
+	bootstrappingDBName       = "bs"
 )
 
 // Manager manages the chains running on this node.
@@ -84,6 +94,14 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns the approximate storage used by the node's database and by each
+	// chain
+	StorageUsage() (StorageUsage, error)
+
+	// Returns a description of the consensus state of the chain with the
+	// given ID
+	Inspect(chainID ids.ID) (interface{}, error)
+
 	Shutdown()
 }
 
@@ -104,7 +122,10 @@ type chain struct {
 	Handler *router.Handler
 	Ctx     *snow.Context
 	VM      interface{}
+	VMID    ids.ID
 	Beacons validators.Set
+	// The databases the chain stores its data in, by name
+	DBs map[string]database.Database
 }
 
 // ManagerConfig ...
@@ -134,6 +155,14 @@ type ManagerConfig struct {
 	WhitelistedSubnets      ids.Set          // Subnets to validate
 	TimeoutManager          *timeout.Manager // Manages request timeouts when sending messages to other validators
 	HealthService           *health.Health
+	// If true, queries skip validators that aren't connected or are benched
+	SkipUnavailableValidators bool
+	// Directory of the subnet configs, which may override the consensus
+	// parameters of a subnet's chains
+	SubnetConfigDir string
+	// If non-nil, faults are injected into the messages that chains send.
+	// Only for local test networks.
+	FaultInjection *faults.Config
 }
 
 type manager struct {
@@ -151,6 +180,9 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]*router.Handler
+	// Key: Chain's ID
+	// Value: Where the chain stores its data
+	storage map[ids.ID]*chainStorage
 }
 
 // New returns a new Manager
@@ -158,6 +190,7 @@ func New(config *ManagerConfig) Manager {
 	m := &manager{
 		ManagerConfig: *config,
 		chains:        make(map[ids.ID]*router.Handler),
+		storage:       make(map[ids.ID]*chainStorage),
 	}
 	m.Initialize()
 	return m
@@ -207,8 +240,17 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 		return
 	}
 
+	storage := &chainStorage{
+		vmID: chain.VMID,
+		dbs:  chain.DBs,
+	}
+	if prefixer, ok := chain.VM.(StoragePrefixer); ok {
+		storage.vmPrefixes = prefixer.StoragePrefixes()
+	}
+
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	m.storage[chainParams.ID] = storage
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -314,6 +356,15 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 
 	bootstrapWeight := beacons.Weight()
 
+	if m.SkipUnavailableValidators {
+		vdrs = validators.NewFilteredSet(vdrs, &availableValidators{
+			nodeID:   m.NodeID,
+			chainID:  ctx.ChainID,
+			net:      m.Net,
+			timeouts: m.TimeoutManager,
+		})
+	}
+
 	var chain *chain
 	switch vm := vm.(type) {
 	case vertex.DAGVM:
@@ -347,6 +398,7 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 	default:
 		return nil, fmt.Errorf("the vm should have type avalanche.DAGVM or snowman.ChainVM. Chain not created")
 	}
+	chain.VMID = vmID
 
 	// Register the chain with the timeout manager
 	if err := m.TimeoutManager.RegisterChain(ctx, consensusParams.Namespace); err != nil {
@@ -379,6 +431,29 @@ func (m *manager) unblockChains() {
 	}
 }
 
+// externalSender returns the sender that the chain's messages to other nodes
+// are sent through
+func (m *manager) externalSender(ctx *snow.Context, namespace string, registerer prometheus.Registerer) (sender.ExternalSender, error) {
+	if m.FaultInjection == nil || !m.FaultInjection.Applies(ctx.ChainID) {
+		return m.Net, nil
+	}
+	if m.NetworkID != constants.LocalID && m.NetworkID != constants.UnitTestID {
+		return nil, fmt.Errorf("fault injection can't be enabled on network %s", constants.NetworkName(m.NetworkID))
+	}
+	ctx.Log.Warn("injecting faults into the messages sent by chain %s", ctx.ChainID)
+	faultySender, err := faults.New(
+		*m.FaultInjection,
+		m.Net,
+		ctx.Log,
+		fmt.Sprintf("%s_faults", namespace),
+		registerer,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize fault injection: %w", err)
+	}
+	return faultySender, nil
+}
+
 // Create a DAG-based blockchain that uses Avalanche
 func (m *manager) createAvalancheChain(
 	ctx *snow.Context,
@@ -390,14 +465,19 @@ func (m *manager) createAvalancheChain(
 	consensusParams avcon.Parameters,
 	bootstrapWeight uint64,
 ) (*chain, error) {
+	consensusParams, err := m.consensusParams(ctx, consensusParams, validators, avcon.Parameters.Valid)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
 	db := prefixdb.New(ctx.ChainID[:], m.DB)
-	vmDB := prefixdb.New([]byte("vm"), db)
-	vertexDB := prefixdb.New([]byte("vertex"), db)
-	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db)
-	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db)
+	vmDB := prefixdb.New([]byte(vmDBName), db)
+	vertexDB := prefixdb.New([]byte(vertexDBName), db)
+	vertexBootstrappingDB := prefixdb.New([]byte(vertexBootstrappingDBName), db)
+	txBootstrappingDB := prefixdb.New([]byte(txBootstrappingDBName), db)
 
 	vtxBlocker, err := queue.New(vertexBootstrappingDB)
 	if err != nil {
@@ -412,6 +492,20 @@ func (m *manager) createAvalancheChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
+	externalSender, err := m.externalSender(ctx, consensusParams.Namespace, consensusParams.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	// Passes messages from the consensus engine to the network
+	sender := sender.Sender{}
+	sender.Initialize(ctx, externalSender, m.ManagerConfig.Router, m.TimeoutManager)
+
+	// If the VM sends application level messages, it uses the same sender
+	if appVM, ok := vm.(common.AppVM); ok {
+		appVM.SetAppSender(&sender)
+	}
+
 	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
 		return nil, fmt.Errorf("error during vm's Initialize: %w", err)
 	}
@@ -421,10 +515,6 @@ func (m *manager) createAvalancheChain(
 	vtxManager := &state.Serializer{}
 	vtxManager.Initialize(ctx, vm, vertexDB)
 
-	// Passes messages from the consensus engine to the network
-	sender := sender.Sender{}
-	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
-
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
 		sampleK = int(bootstrapWeight)
@@ -488,6 +578,12 @@ func (m *manager) createAvalancheChain(
 		Handler: handler,
 		VM:      vm,
 		Ctx:     ctx,
+		DBs: map[string]database.Database{
+			vmDBName:                  vmDB,
+			vertexDBName:              vertexDB,
+			vertexBootstrappingDBName: vertexBootstrappingDB,
+			txBootstrappingDBName:     txBootstrappingDB,
+		},
 	}, nil
 }
 
@@ -502,12 +598,20 @@ func (m *manager) createSnowmanChain(
 	consensusParams snowball.Parameters,
 	bootstrapWeight uint64,
 ) (*chain, error) {
+	params, err := m.consensusParams(ctx, avcon.Parameters{Parameters: consensusParams}, validators, func(params avcon.Parameters) error {
+		return params.Parameters.Valid()
+	})
+	if err != nil {
+		return nil, err
+	}
+	consensusParams = params.Parameters
+
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
 	db := prefixdb.New(ctx.ChainID[:], m.DB)
-	vmDB := prefixdb.New([]byte("vm"), db)
-	bootstrappingDB := prefixdb.New([]byte("bs"), db)
+	vmDB := prefixdb.New([]byte(vmDBName), db)
+	bootstrappingDB := prefixdb.New([]byte(bootstrappingDBName), db)
 
 	blocked, err := queue.New(bootstrappingDB)
 	if err != nil {
@@ -518,14 +622,24 @@ func (m *manager) createSnowmanChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
-	// Initialize the VM
-	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
+	externalSender, err := m.externalSender(ctx, consensusParams.Namespace, consensusParams.Metrics)
+	if err != nil {
 		return nil, err
 	}
 
 	// Passes messages from the consensus engine to the network
 	sender := sender.Sender{}
-	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
+	sender.Initialize(ctx, externalSender, m.ManagerConfig.Router, m.TimeoutManager)
+
+	// If the VM sends application level messages, it uses the same sender
+	if appVM, ok := vm.(common.AppVM); ok {
+		appVM.SetAppSender(&sender)
+	}
+
+	// Initialize the VM
+	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
+		return nil, err
+	}
 
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
@@ -590,6 +704,10 @@ func (m *manager) createSnowmanChain(
 		Handler: handler,
 		VM:      vm,
 		Ctx:     ctx,
+		DBs: map[string]database.Database{
+			vmDBName:            vmDB,
+			bootstrappingDBName: bootstrappingDB,
+		},
 	}, nil
 }
 
@@ -615,6 +733,24 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Engine().IsBootstrapped()
 }
 
+// Inspect returns the engine's description of the consensus state of the
+// chain [chainID]
+func (m *manager) Inspect(chainID ids.ID) (interface{}, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("unknown chain ID %s", chainID)
+	}
+
+	engine := chain.Engine()
+	ctx := engine.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	return engine.Inspect()
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
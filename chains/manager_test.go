@@ -0,0 +1,32 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/faults"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestExternalSenderRejectsFaultsOnPublicNetworks(t *testing.T) {
+	m := &manager{
+		ManagerConfig: ManagerConfig{
+			NetworkID:      constants.MainnetID,
+			FaultInjection: &faults.Config{},
+		},
+	}
+	_, err := m.externalSender(snow.DefaultContextTest(), "chain", prometheus.NewRegistry())
+	assert.Error(t, err)
+
+	// Without fault injection, the chain sends through the network
+	m.FaultInjection = nil
+	externalSender, err := m.externalSender(snow.DefaultContextTest(), "chain", prometheus.NewRegistry())
+	assert.NoError(t, err)
+	assert.Equal(t, m.Net, externalSender)
+}
@@ -53,3 +53,9 @@ func (mm MockManager) SubnetID(ids.ID) (ids.ID, error) { return ids.ID{}, nil }
 
 // IsBootstrapped ...
 func (mm MockManager) IsBootstrapped(ids.ID) bool { return false }
+
+// StorageUsage ...
+func (mm MockManager) StorageUsage() (StorageUsage, error) { return StorageUsage{}, nil }
+
+// Inspect ...
+func (mm MockManager) Inspect(ids.ID) (interface{}, error) { return nil, nil }
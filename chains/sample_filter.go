@@ -0,0 +1,29 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+)
+
+// availableValidators only allows sampling validators that are able to respond
+// to queries on a chain. That is, validators that this node is connected to
+// and that aren't benched on the chain.
+type availableValidators struct {
+	nodeID   ids.ShortID
+	chainID  ids.ID
+	net      network.Network
+	timeouts *timeout.Manager
+}
+
+// ShouldSample implements the validators.SampleFilter interface
+func (a *availableValidators) ShouldSample(validatorID ids.ShortID) bool {
+	// Queries to this node are handled locally
+	if validatorID.Equals(a.nodeID) {
+		return true
+	}
+	return a.net.IsConnected(validatorID) && !a.timeouts.IsBenched(validatorID, a.chainID)
+}
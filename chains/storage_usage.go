@@ -0,0 +1,128 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// StoragePrefixer is implemented by VMs that group data under well-known
+// prefixes of their database, using prefixdb.NewNested. Data stored under keys
+// derived by hashing, such as those of vms/components/state, isn't contiguous
+// and can only be attributed to the VM's database as a whole.
+type StoragePrefixer interface {
+	// StoragePrefixes maps a human readable name of each well-known prefix to
+	// the prefix. An empty prefix refers to the VM's entire database. It is
+	// called once, after the VM has been initialized.
+	StoragePrefixes() map[string][]byte
+}
+
+// StorageUsage is the approximate number of bytes of storage used by this node
+type StorageUsage struct {
+	// Total is the size of the node's entire database
+	Total uint64
+	// Chains is the usage of each chain, sorted by chain ID
+	Chains []ChainStorageUsage
+}
+
+// ChainStorageUsage is the approximate number of bytes of storage used by a
+// chain
+type ChainStorageUsage struct {
+	ChainID ids.ID
+	VMID    ids.ID
+	// Total is the sum of the sizes of the chain's databases
+	Total uint64
+	// Databases maps the name of each of the chain's databases, such as "vm"
+	// or the bootstrapping queue "bs", to its size
+	Databases map[string]uint64
+	// VMPrefixes maps the name of each well-known prefix in the VM's database
+	// to its size. These sizes are included in the size of the "vm" database.
+	VMPrefixes map[string]uint64
+}
+
+// chainStorage is where a chain stores its data
+type chainStorage struct {
+	vmID ids.ID
+	dbs  map[string]database.Database
+	// Well-known prefixes of the "vm" database
+	vmPrefixes map[string][]byte
+}
+
+// usage returns the approximate size of each of the chain's databases and VM
+// prefixes
+func (s *chainStorage) usage(chainID ids.ID) (ChainStorageUsage, error) {
+	usage := ChainStorageUsage{
+		ChainID:    chainID,
+		VMID:       s.vmID,
+		Databases:  make(map[string]uint64, len(s.dbs)),
+		VMPrefixes: make(map[string]uint64, len(s.vmPrefixes)),
+	}
+	for name, db := range s.dbs {
+		size, err := sizeOf(db)
+		if err != nil {
+			return ChainStorageUsage{}, err
+		}
+		usage.Databases[name] = size
+		usage.Total += size
+	}
+	if vmDB, ok := s.dbs[vmDBName]; ok {
+		for name, prefix := range s.vmPrefixes {
+			if len(prefix) == 0 {
+				usage.VMPrefixes[name] = usage.Databases[vmDBName]
+				continue
+			}
+			size, err := sizeOf(prefixdb.NewNested(prefix, vmDB))
+			if err != nil {
+				return ChainStorageUsage{}, err
+			}
+			usage.VMPrefixes[name] = size
+		}
+	}
+	return usage, nil
+}
+
+// StorageUsage returns the approximate storage used by the node's database and
+// by each chain
+func (m *manager) StorageUsage() (StorageUsage, error) {
+	usage := StorageUsage{}
+	total, err := sizeOf(m.DB)
+	if err != nil {
+		return StorageUsage{}, err
+	}
+	usage.Total = total
+
+	m.chainsLock.Lock()
+	storage := make(map[ids.ID]*chainStorage, len(m.storage))
+	for chainID, chainStorage := range m.storage {
+		storage[chainID] = chainStorage
+	}
+	m.chainsLock.Unlock()
+
+	usage.Chains = make([]ChainStorageUsage, 0, len(storage))
+	for chainID, chainStorage := range storage {
+		chainUsage, err := chainStorage.usage(chainID)
+		if err != nil {
+			return StorageUsage{}, err
+		}
+		usage.Chains = append(usage.Chains, chainUsage)
+	}
+	sort.Slice(usage.Chains, func(i, j int) bool {
+		return bytes.Compare(usage.Chains[i].ChainID[:], usage.Chains[j].ChainID[:]) == -1
+	})
+	return usage, nil
+}
+
+// sizeOf returns the approximate size of all of the keys in [db]
+func sizeOf(db database.Database) (uint64, error) {
+	sizer, ok := db.(database.Sizer)
+	if !ok {
+		return 0, database.ErrNoSizes
+	}
+	return sizer.SizeOf(nil, nil)
+}
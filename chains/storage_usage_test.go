@@ -0,0 +1,83 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/mockdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestStorageUsage(t *testing.T) {
+	db := memdb.New()
+	otherDB := prefixdb.New([]byte("keystore"), db)
+	assert.NoError(t, otherDB.Put([]byte("key"), []byte("value")))
+
+	chainID := ids.GenerateTestID()
+	vmID := ids.GenerateTestID()
+	chainDB := prefixdb.New(chainID[:], db)
+	vmDB := prefixdb.New([]byte(vmDBName), chainDB)
+	bootstrappingDB := prefixdb.New([]byte(bootstrappingDBName), chainDB)
+
+	assert.NoError(t, vmDB.Put([]byte("unprefixed"), make([]byte, 10)))
+	uptimeDB := prefixdb.NewNested([]byte("uptime"), vmDB)
+	assert.NoError(t, uptimeDB.Put([]byte("node"), make([]byte, 100)))
+	assert.NoError(t, bootstrappingDB.Put([]byte("job"), make([]byte, 1000)))
+
+	m := &manager{
+		ManagerConfig: ManagerConfig{DB: db},
+		storage: map[ids.ID]*chainStorage{
+			chainID: {
+				vmID: vmID,
+				dbs: map[string]database.Database{
+					vmDBName:            vmDB,
+					bootstrappingDBName: bootstrappingDB,
+				},
+				vmPrefixes: map[string][]byte{
+					"uptime": []byte("uptime"),
+					"empty":  []byte("empty"),
+					"state":  nil,
+				},
+			},
+		},
+	}
+
+	usage, err := m.StorageUsage()
+	assert.NoError(t, err)
+
+	// memdb reports the exact sizes of the prefixed keys and values
+	vmSize := uint64(32 + len("unprefixed") + 10 + 32 + 32 + len("node") + 100)
+	bootstrappingSize := uint64(32 + len("job") + 1000)
+	otherSize := uint64(32 + len("key") + len("value"))
+	assert.Equal(t, vmSize+bootstrappingSize+otherSize, usage.Total)
+
+	assert.Len(t, usage.Chains, 1)
+	chainUsage := usage.Chains[0]
+	assert.Equal(t, chainID, chainUsage.ChainID)
+	assert.Equal(t, vmID, chainUsage.VMID)
+	assert.Equal(t, vmSize+bootstrappingSize, chainUsage.Total)
+	assert.Equal(t, map[string]uint64{
+		vmDBName:            vmSize,
+		bootstrappingDBName: bootstrappingSize,
+	}, chainUsage.Databases)
+	assert.Equal(t, map[string]uint64{
+		"uptime": uint64(32 + 32 + len("node") + 100),
+		"empty":  0,
+		"state":  vmSize,
+	}, chainUsage.VMPrefixes)
+}
+
+func TestStorageUsageUnsupported(t *testing.T) {
+	m := &manager{
+		ManagerConfig: ManagerConfig{DB: mockdb.New()},
+	}
+	_, err := m.StorageUsage()
+	assert.Equal(t, database.ErrNoSizes, err)
+}
@@ -0,0 +1,143 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+)
+
+// subnetConfigExtension is the extension of the files in the subnet config
+// directory. The config of a subnet is in the file named by its ID.
+const subnetConfigExtension = ".json"
+
+var errInsufficientWeight = errors.New("K is larger than the total weight of the validators")
+
+// ConsensusConfig overrides some of the node's consensus parameters. Fields
+// that aren't set keep the node's value.
+type ConsensusConfig struct {
+	K                 *int `json:"k"`
+	Alpha             *int `json:"alpha"`
+	BetaVirtuous      *int `json:"betaVirtuous"`
+	BetaRogue         *int `json:"betaRogue"`
+	ConcurrentRepolls *int `json:"concurrentRepolls"`
+
+	// Only used by avalanche chains
+	Parents   *int `json:"parents"`
+	BatchSize *int `json:"batchSize"`
+}
+
+// apply the overrides to [params]
+func (c *ConsensusConfig) apply(params *avcon.Parameters) {
+	overrides := []struct {
+		value *int
+		param *int
+	}{
+		{value: c.K, param: &params.K},
+		{value: c.Alpha, param: &params.Alpha},
+		{value: c.BetaVirtuous, param: &params.BetaVirtuous},
+		{value: c.BetaRogue, param: &params.BetaRogue},
+		{value: c.ConcurrentRepolls, param: &params.ConcurrentRepolls},
+		{value: c.Parents, param: &params.Parents},
+		{value: c.BatchSize, param: &params.BatchSize},
+	}
+	for _, override := range overrides {
+		if override.value != nil {
+			*override.param = *override.value
+		}
+	}
+}
+
+// SubnetConfig is the config of the chains of a subnet
+type SubnetConfig struct {
+	// Consensus parameters of every chain of the subnet
+	ConsensusConfig
+
+	// Chains maps the ID of a chain of the subnet to the consensus parameters
+	// of that chain, which override the subnet's
+	Chains map[string]ConsensusConfig `json:"chains"`
+}
+
+// LoadSubnetConfig reads the config of the subnet [subnetID] from [dir].
+// Returns nil if the subnet doesn't have a config.
+func LoadSubnetConfig(dir string, subnetID ids.ID) (*SubnetConfig, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	path := filepath.Join(dir, subnetID.String()+subnetConfigExtension)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config := &SubnetConfig{}
+	if err := json.Unmarshal(b, config); err != nil {
+		return nil, fmt.Errorf("couldn't parse subnet config %s: %w", path, err)
+	}
+	for chainID := range config.Chains {
+		if _, err := ids.FromString(chainID); err != nil {
+			return nil, fmt.Errorf("subnet config %s has invalid chain ID %q: %w", path, chainID, err)
+		}
+	}
+	return config, nil
+}
+
+// ConsensusParams returns [params] with the overrides of the subnet, and then
+// of the chain [chainID], applied
+func (c *SubnetConfig) ConsensusParams(chainID ids.ID, params avcon.Parameters) avcon.Parameters {
+	c.ConsensusConfig.apply(&params)
+	if chainConfig, ok := c.Chains[chainID.String()]; ok {
+		chainConfig.apply(&params)
+	}
+	return params
+}
+
+// consensusParams returns the consensus parameters of the chain [ctx], which
+// are the node's parameters [params] with the overrides of the chain's subnet
+// config applied. If the parameters were overridden, they're verified by
+// [verify] and checked against the number of validators [vdrs]. The check
+// is skipped if there are no validators yet, such as when staking is
+// disabled and no peers have connected.
+func (m *manager) consensusParams(
+	ctx *snow.Context,
+	params avcon.Parameters,
+	vdrs validators.Set,
+	verify func(avcon.Parameters) error,
+) (avcon.Parameters, error) {
+	config, err := LoadSubnetConfig(m.SubnetConfigDir, ctx.SubnetID)
+	if err != nil || config == nil {
+		return params, err
+	}
+
+	params = config.ConsensusParams(ctx.ChainID, params)
+	if err := verify(params); err != nil {
+		return params, fmt.Errorf("invalid consensus parameters for subnet %s: %w", ctx.SubnetID, err)
+	}
+	// Validators are sampled by weight, so a validator may be sampled more
+	// than once and K is bounded by the total weight rather than by the number
+	// of validators
+	switch weight := vdrs.Weight(); {
+	case weight == 0:
+		ctx.Log.Warn("couldn't check K = %d against the validators of subnet %s as it has no validators yet",
+			params.K, ctx.SubnetID)
+	case uint64(params.K) > weight:
+		return params, fmt.Errorf("%w of subnet %s: K = %d, weight = %d", errInsufficientWeight, ctx.SubnetID, params.K, weight)
+	}
+	ctx.Log.Info("using consensus parameters K = %d, Alpha = %d, BetaVirtuous = %d, BetaRogue = %d from the config of subnet %s",
+		params.K, params.Alpha, params.BetaVirtuous, params.BetaRogue, ctx.SubnetID)
+	return params, nil
+}
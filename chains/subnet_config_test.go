@@ -0,0 +1,143 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/validators"
+
+	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+)
+
+var testConsensusParams = avcon.Parameters{
+	Parameters: snowball.Parameters{
+		K:                 20,
+		Alpha:             15,
+		BetaVirtuous:      15,
+		BetaRogue:         20,
+		ConcurrentRepolls: 4,
+	},
+	Parents:   5,
+	BatchSize: 30,
+}
+
+func writeSubnetConfig(t *testing.T, dir string, subnetID ids.ID, config string) {
+	path := filepath.Join(dir, subnetID.String()+subnetConfigExtension)
+	assert.NoError(t, ioutil.WriteFile(path, []byte(config), 0600))
+}
+
+func TestLoadSubnetConfigMissing(t *testing.T) {
+	config, err := LoadSubnetConfig("", ids.GenerateTestID())
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = LoadSubnetConfig(t.TempDir(), ids.GenerateTestID())
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+}
+
+func TestSubnetConfigConsensusParams(t *testing.T) {
+	dir := t.TempDir()
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	writeSubnetConfig(t, dir, subnetID, `{
+		"k": 5,
+		"alpha": 4,
+		"parents": 2,
+		"chains": {
+			"`+chainID.String()+`": {"alpha": 3, "betaVirtuous": 10}
+		}
+	}`)
+
+	config, err := LoadSubnetConfig(dir, subnetID)
+	assert.NoError(t, err)
+	assert.NotNil(t, config)
+
+	// The subnet's overrides apply to every chain of the subnet
+	params := config.ConsensusParams(ids.GenerateTestID(), testConsensusParams)
+	expected := testConsensusParams
+	expected.K = 5
+	expected.Alpha = 4
+	expected.Parents = 2
+	assert.Equal(t, expected, params)
+
+	// The chain's overrides apply on top of the subnet's
+	params = config.ConsensusParams(chainID, testConsensusParams)
+	expected.Alpha = 3
+	expected.BetaVirtuous = 10
+	assert.Equal(t, expected, params)
+}
+
+func TestLoadSubnetConfigInvalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":        `{`,
+		"wrong type":       `{"k": "five"}`,
+		"invalid chain ID": `{"chains": {"not a chain ID": {"k": 5}}}`,
+	}
+	for name, config := range tests {
+		dir := t.TempDir()
+		subnetID := ids.GenerateTestID()
+		writeSubnetConfig(t, dir, subnetID, config)
+
+		_, err := LoadSubnetConfig(dir, subnetID)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestManagerConsensusParams(t *testing.T) {
+	dir := t.TempDir()
+	m := &manager{ManagerConfig: ManagerConfig{SubnetConfigDir: dir}}
+
+	ctx := snow.DefaultContextTest()
+	ctx.SubnetID = ids.GenerateTestID()
+	ctx.ChainID = ids.GenerateTestID()
+
+	vdrs := validators.NewSet()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, vdrs.AddWeight(ids.GenerateTestShortID(), 1))
+	}
+	verify := avcon.Parameters.Valid
+
+	// Without a config, the node's parameters are used without being checked
+	// against the validators
+	params, err := m.consensusParams(ctx, testConsensusParams, vdrs, verify)
+	assert.NoError(t, err)
+	assert.Equal(t, testConsensusParams, params)
+
+	writeSubnetConfig(t, dir, ctx.SubnetID, `{"k": 3, "alpha": 2, "betaVirtuous": 1, "betaRogue": 2, "concurrentRepolls": 1}`)
+	params, err = m.consensusParams(ctx, testConsensusParams, vdrs, verify)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, params.K)
+
+	// Alpha must be larger than K / 2
+	writeSubnetConfig(t, dir, ctx.SubnetID, `{"k": 3, "alpha": 1}`)
+	_, err = m.consensusParams(ctx, testConsensusParams, vdrs, verify)
+	assert.Error(t, err)
+
+	// K may be larger than the number of validators, as validators are
+	// sampled by weight
+	writeSubnetConfig(t, dir, ctx.SubnetID, `{"k": 5, "alpha": 3, "betaVirtuous": 1, "betaRogue": 2, "concurrentRepolls": 1}`)
+	assert.NoError(t, vdrs.AddWeight(ids.GenerateTestShortID(), 2))
+	params, err = m.consensusParams(ctx, testConsensusParams, vdrs, verify)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, params.K)
+
+	writeSubnetConfig(t, dir, ctx.SubnetID, `{"k": 6, "alpha": 4, "betaVirtuous": 1, "betaRogue": 2, "concurrentRepolls": 1}`)
+	_, err = m.consensusParams(ctx, testConsensusParams, vdrs, verify)
+	assert.True(t, errors.Is(err, errInsufficientWeight))
+
+	// The weight isn't checked before there are any validators
+	params, err = m.consensusParams(ctx, testConsensusParams, validators.NewSet(), verify)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, params.K)
+}
@@ -0,0 +1,487 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package badgerdb
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/nodb"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// Database is a persistent key-value store backed by BadgerDB.
+//
+// Badger doesn't support empty keys or keys starting with "!badger!", so
+// writes of these keys fail.
+type Database struct {
+	lock sync.RWMutex
+	// nil once the database is closed
+	db *badger.DB
+	// Iterators that haven't been released yet. They are released when the
+	// database is closed, as their resources are freed by closing badger.
+	iterators map[*iterator]struct{}
+}
+
+// New returns a wrapped BadgerDB object.
+func New(file string) (*Database, error) {
+	return open(badger.DefaultOptions(file).WithLogger(nil))
+}
+
+// NewReadOnly returns a wrapped BadgerDB object that fails all writes.
+func NewReadOnly(file string) (*Database, error) {
+	return open(badger.DefaultOptions(file).WithLogger(nil).WithReadOnly(true))
+}
+
+func open(opts badger.Options) (*Database, error) {
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Database{
+		db:        db,
+		iterators: make(map[*iterator]struct{}),
+	}, nil
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return false, database.ErrClosed
+	}
+	err := db.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(key)
+		return err
+	})
+	switch err {
+	case nil:
+		return true, nil
+	case badger.ErrKeyNotFound:
+		return false, nil
+	default:
+		return false, updateError(err)
+	}
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	var value []byte
+	err := db.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	return value, updateError(err)
+}
+
+// Put sets the value of the provided key to the provided value
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return updateError(db.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(utils.CopyBytes(key), utils.CopyBytes(value))
+	}))
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return updateError(db.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(utils.CopyBytes(key))
+	}))
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch { return &batch{db: db} }
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.newIterator(nil, nil, nil, false)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.newIterator(start, nil, nil, false)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(nil, nil, prefix, false)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(start, nil, prefix, false)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database's keys in [start, end)
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, false)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database's keys in [start, end)
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, true)
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the database ignoring keys that do not start with the provided
+// prefix
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(nil, nil, prefix, true)
+}
+
+func (db *Database) newIterator(start, end, prefix []byte, reverse bool) database.Iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+
+	opts := badger.IteratorOptions{Reverse: reverse}
+	if !reverse {
+		// When iterating in reverse, the seek may land on the first key after
+		// the prefix, which badger would report as the end of the iteration.
+		// The prefix is checked by valid() instead.
+		opts.Prefix = utils.CopyBytes(prefix)
+	}
+	txn := db.db.NewTransaction(false)
+	it := &iterator{
+		db:      db,
+		txn:     txn,
+		it:      txn.NewIterator(opts),
+		start:   utils.CopyBytes(start),
+		end:     utils.CopyBytes(end),
+		prefix:  utils.CopyBytes(prefix),
+		reverse: reverse,
+	}
+	db.iterators[it] = struct{}{}
+	return it
+}
+
+// SizeOf implements the Sizer interface. Badger doesn't estimate the size of a
+// range of keys, so the estimated sizes of the keys in [start, limit) are
+// summed. Values aren't read.
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	size := uint64(0)
+	err := db.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{})
+		defer it.Close()
+
+		for it.Seek(start); it.Valid(); it.Next() {
+			item := it.Item()
+			if limit != nil && bytes.Compare(item.Key(), limit) >= 0 {
+				break
+			}
+			size += uint64(item.EstimatedSize())
+		}
+		return nil
+	})
+	return size, updateError(err)
+}
+
+// Stat isn't supported by badger
+func (db *Database) Stat(property string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return "", database.ErrClosed
+	}
+	return "", database.ErrNotFound
+}
+
+// Compact does nothing, as badger compacts its tables in the background and
+// doesn't support compacting a range of keys
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return nil
+}
+
+// Close implements the Database interface
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	for it := range db.iterators {
+		it.release(database.ErrClosed)
+	}
+	err := db.db.Close()
+	db.db = nil
+	return err
+}
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// batch buffers writes until they are committed. Batches are committed in a
+// single transaction if they fit in one, and are split across transactions
+// otherwise.
+type batch struct {
+	db     *Database
+	writes []keyValue
+	size   int
+}
+
+// Put the value into the batch for later writing
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.size += len(value)
+	return nil
+}
+
+// Delete the key during writing
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int { return b.size }
+
+// Write flushes any accumulated data to disk. The batch is written atomically
+// in a single badger transaction, so batches larger than badger's transaction
+// limits fail with badger.ErrTxnTooBig without writing anything.
+func (b *batch) Write() error {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+	return updateError(b.db.db.Update(func(txn *badger.Txn) error {
+		for _, kv := range b.writes {
+			var err error
+			if kv.delete {
+				err = txn.Delete(kv.key)
+			} else {
+				err = txn.Set(kv.key, kv.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.size = 0
+}
+
+// Replay the batch contents.
+func (b *batch) Replay(w database.KeyValueWriter) error {
+	for _, kv := range b.writes {
+		if kv.delete {
+			if err := w.Delete(kv.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(kv.key, kv.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Inner returns itself
+func (b *batch) Inner() database.Batch { return b }
+
+// iterator iterates over the keys in [start, end) that start with [prefix]
+type iterator struct {
+	db  *Database
+	txn *badger.Txn
+	it  *badger.Iterator
+
+	start, end, prefix []byte
+	reverse            bool
+
+	initialized, exhausted, released bool
+
+	key, value []byte
+	err        error
+}
+
+// Next implements the Iterator interface
+func (it *iterator) Next() bool {
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	if it.released || it.exhausted || it.err != nil {
+		it.key = nil
+		it.value = nil
+		return false
+	}
+
+	if !it.initialized {
+		it.initialized = true
+		it.seek()
+	} else {
+		it.it.Next()
+	}
+
+	if !it.valid() {
+		it.exhausted = true
+		it.key = nil
+		it.value = nil
+		return false
+	}
+
+	item := it.it.Item()
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		it.err = updateError(err)
+		it.key = nil
+		it.value = nil
+		return false
+	}
+	it.key = item.KeyCopy(nil)
+	it.value = value
+	return true
+}
+
+// seek moves the badger iterator to the first key that may be returned
+func (it *iterator) seek() {
+	if !it.reverse {
+		seekKey := it.start
+		if bytes.Compare(it.prefix, seekKey) == 1 {
+			seekKey = it.prefix
+		}
+		it.it.Seek(seekKey)
+		return
+	}
+
+	// When iterating in reverse, Seek moves to the largest key that is less
+	// than or equal to the seek key
+	seekKey := it.end
+	if len(it.prefix) > 0 {
+		prefixEnd := database.PrefixEnd(it.prefix)
+		if seekKey == nil || (prefixEnd != nil && bytes.Compare(prefixEnd, seekKey) == -1) {
+			seekKey = prefixEnd
+		}
+	}
+	if seekKey == nil {
+		it.it.Rewind()
+		return
+	}
+	it.it.Seek(seekKey)
+	if it.it.Valid() && bytes.Equal(it.it.Item().Key(), seekKey) {
+		it.it.Next()
+	}
+}
+
+// valid returns true if the badger iterator is at a key that should be
+// returned
+func (it *iterator) valid() bool {
+	if !it.it.Valid() {
+		return false
+	}
+	key := it.it.Item().Key()
+	return bytes.HasPrefix(key, it.prefix) &&
+		bytes.Compare(key, it.start) >= 0 &&
+		(it.end == nil || bytes.Compare(key, it.end) == -1)
+}
+
+// Error implements the Iterator interface
+func (it *iterator) Error() error {
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	return it.err
+}
+
+// Key implements the Iterator interface
+func (it *iterator) Key() []byte { return it.key }
+
+// Value implements the Iterator interface
+func (it *iterator) Value() []byte { return it.value }
+
+// Release implements the Iterator interface
+func (it *iterator) Release() {
+	it.db.lock.Lock()
+	defer it.db.lock.Unlock()
+
+	it.release(nil)
+}
+
+// release frees the badger iterator and reports [err] from future calls to
+// Error. Assumes the database's lock is held.
+func (it *iterator) release(err error) {
+	it.key = nil
+	it.value = nil
+	if it.released {
+		return
+	}
+	it.released = true
+	if it.err == nil {
+		it.err = err
+	}
+	it.it.Close()
+	it.txn.Discard()
+	delete(it.db.iterators, it)
+}
+
+func updateError(err error) error {
+	switch err {
+	case badger.ErrKeyNotFound:
+		return database.ErrNotFound
+	default:
+		return err
+	}
+}
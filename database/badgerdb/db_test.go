@@ -0,0 +1,75 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package badgerdb
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+func TestInterface(t *testing.T) {
+	for i, test := range database.Tests {
+		folder := fmt.Sprintf("db%d", i)
+
+		db, err := New(folder)
+		if err != nil {
+			t.Fatalf("badgerdb.New(%s) errored with %s", folder, err)
+		}
+		defer os.RemoveAll(folder)
+		defer db.Close()
+
+		test(t, db)
+	}
+}
+
+func TestSizerInterface(t *testing.T) {
+	for i, test := range database.SizerTests {
+		folder := fmt.Sprintf("sizerdb%d", i)
+
+		db, err := New(folder)
+		if err != nil {
+			t.Fatalf("badgerdb.New(%s) errored with %s", folder, err)
+		}
+		defer os.RemoveAll(folder)
+		defer db.Close()
+
+		test(t, db)
+	}
+}
+
+func TestBatchLargerThanTransaction(t *testing.T) {
+	folder := "largebatchdb"
+	db, err := New(folder)
+	if err != nil {
+		t.Fatalf("badgerdb.New(%s) errored with %s", folder, err)
+	}
+	defer os.RemoveAll(folder)
+	defer db.Close()
+
+	// More entries than fit in a single badger transaction
+	numKeys := int(db.db.MaxBatchCount()) + 1
+	batch := db.NewBatch()
+	for i := 0; i < numKeys; i++ {
+		if err := batch.Put([]byte(fmt.Sprintf("key%d", i)), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Write(); err != badger.ErrTxnTooBig {
+		t.Fatalf("batch.Write returned %v ; Expected: %s", err, badger.ErrTxnTooBig)
+	}
+
+	// The batch must be written atomically, so none of its keys were written
+	for _, i := range []int{0, numKeys / 2, numKeys - 1} {
+		if has, err := db.Has([]byte(fmt.Sprintf("key%d", i))); err != nil {
+			t.Fatal(err)
+		} else if has {
+			t.Fatalf("key%d was written", i)
+		}
+	}
+}
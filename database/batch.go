@@ -36,3 +36,43 @@ type Batcher interface {
 	// until a final write is called.
 	NewBatch() Batch
 }
+
+// WriteNotifier is implemented by inner batches that must be told whether the
+// batch they were replayed into by WriteAll was written, such as batches whose
+// writes are cached.
+type WriteNotifier interface {
+	// Written is called with the result of writing the batch that this batch
+	// was replayed into. [err] is non-nil if the writes may not have been
+	// applied.
+	Written(err error)
+}
+
+// WriteAll atomically writes [baseBatch] and [batches] to their underlying
+// database. The batches may be of different views of the database, such as
+// prefixdb, meterdb or versiondb databases, but must all eventually write to
+// the same underlying database. The writes of [batches] are replayed, in order,
+// into [baseBatch]'s batch of the underlying database, so either all of the
+// batches are written or none of them are. This relies on the batches of the
+// underlying database being written atomically, as those of leveldb, badgerdb,
+// memdb and rpcdb are. Batches should not be used after being passed to this
+// function.
+func WriteAll(baseBatch Batch, batches ...Batch) error {
+	baseBatch = baseBatch.Inner()
+	notifiers := []WriteNotifier(nil)
+	err := func() error {
+		for _, batch := range batches {
+			inner := batch.Inner()
+			if notifier, ok := inner.(WriteNotifier); ok {
+				notifiers = append(notifiers, notifier)
+			}
+			if err := inner.Replay(baseBatch); err != nil {
+				return err
+			}
+		}
+		return baseBatch.Write()
+	}()
+	for _, notifier := range notifiers {
+		notifier.Written(err)
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/badgerdb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/nodb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+)
+
+func TestWriteAll(t *testing.T) {
+	db := memdb.New()
+	chainDB := versiondb.New(prefixdb.New([]byte("chain"), db))
+	sharedDB := prefixdb.New([]byte("shared"), db)
+
+	chainKey := []byte("hello")
+	chainValue := []byte("world")
+	sharedKey := []byte("wor")
+	sharedValue := []byte("ld")
+
+	if err := chainDB.Put(chainKey, chainValue); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	chainBatch, err := chainDB.CommitBatch()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.CommitBatch: %s", err)
+	}
+
+	sharedBatch := sharedDB.NewBatch()
+	if err := sharedBatch.Put(sharedKey, sharedValue); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+
+	it := db.NewIterator()
+	if it.Next() {
+		t.Fatalf("Unexpected write to the underlying database")
+	}
+	it.Release()
+
+	if err := database.WriteAll(sharedBatch, chainBatch); err != nil {
+		t.Fatalf("Unexpected error on WriteAll: %s", err)
+	}
+	chainDB.Abort()
+
+	if v, err := chainDB.Get(chainKey); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(chainValue, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, chainValue)
+	} else if v, err := sharedDB.Get(sharedKey); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(sharedValue, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, sharedValue)
+	}
+}
+
+func TestWriteAllReplayFailure(t *testing.T) {
+	db := memdb.New()
+	chainDB := prefixdb.New([]byte("chain"), db)
+	sharedDB := prefixdb.New([]byte("shared"), db)
+
+	sharedBatch := sharedDB.NewBatch()
+	if err := sharedBatch.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+	chainBatch := chainDB.NewBatch()
+	if err := chainBatch.Put([]byte("wor"), []byte("ld")); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+
+	// The batch of a closed database can't be replayed
+	if err := database.WriteAll(sharedBatch, chainBatch, &nodb.Batch{}); err != database.ErrClosed {
+		t.Fatalf("WriteAll returned %v ; Expected: %s", err, database.ErrClosed)
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+	if it.Next() {
+		t.Fatalf("Unexpected write to the underlying database")
+	}
+}
+
+func TestWriteAllWriteFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "writeall")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := badgerdb.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	chainDB := prefixdb.New([]byte("chain"), db)
+	sharedDB := prefixdb.New([]byte("shared"), db)
+
+	sharedBatch := sharedDB.NewBatch()
+	if err := sharedBatch.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+	// More writes than fit in a single badger transaction
+	chainBatch := chainDB.NewBatch()
+	for i := 0; i < 1<<20; i++ {
+		if err := chainBatch.Put([]byte(fmt.Sprintf("key%d", i)), nil); err != nil {
+			t.Fatalf("Unexpected error on batch.Put: %s", err)
+		}
+	}
+
+	if err := database.WriteAll(sharedBatch, chainBatch); err == nil {
+		t.Fatalf("WriteAll should have failed")
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+	if it.Next() {
+		t.Fatalf("Unexpected write to the underlying database")
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("Unexpected error on iterator.Error: %s", err)
+	}
+}
@@ -0,0 +1,409 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cachedb
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/nodb"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Database caches the values of recently read and written keys, as well as
+// recent reads of missing keys, in front of another database.
+//
+// Every write to the underlying database must be made through this database,
+// or its cache may serve stale values. Batches of this database that are
+// written together with database.WriteAll update the cache with their own
+// writes.
+type Database struct {
+	metrics
+	lock sync.RWMutex
+	// Maps the hash of a key to its value, or to nil if the key isn't in the
+	// database
+	cache cache.Cacher
+	// The underlying storage
+	db database.Database
+}
+
+// New returns a new database that caches up to [size] entries of [db]
+func New(
+	namespace string,
+	registerer prometheus.Registerer,
+	size int,
+	db database.Database,
+) (*Database, error) {
+	cacheDB := &Database{
+		cache: &cache.LRU{Size: size},
+		db:    db,
+	}
+	return cacheDB, cacheDB.metrics.Initialize(namespace, registerer)
+}
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return false, database.ErrClosed
+	}
+	cacheKey := hashing.ComputeHash256Array(key)
+	if value, ok := db.cache.Get(cacheKey); ok {
+		db.hits.Inc()
+		return value.([]byte) != nil, nil
+	}
+	db.misses.Inc()
+
+	has, err := db.db.Has(key)
+	if err == nil && !has {
+		db.cache.Put(cacheKey, []byte(nil))
+	}
+	return has, err
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	cacheKey := hashing.ComputeHash256Array(key)
+	if value, ok := db.cache.Get(cacheKey); ok {
+		db.hits.Inc()
+		if value := value.([]byte); value != nil {
+			return copyBytes(value), nil
+		}
+		return nil, database.ErrNotFound
+	}
+	db.misses.Inc()
+
+	value, err := db.db.Get(key)
+	switch err {
+	case nil:
+		db.cache.Put(cacheKey, copyBytes(value))
+	case database.ErrNotFound:
+		db.cache.Put(cacheKey, []byte(nil))
+	}
+	return value, err
+}
+
+// Put implements the Database interface
+func (db *Database) Put(key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	if err := db.db.Put(key, value); err != nil {
+		// The write may have partially succeeded, so the cached value can't be
+		// trusted
+		db.cache.Evict(hashing.ComputeHash256Array(key))
+		return err
+	}
+	db.cache.Put(hashing.ComputeHash256Array(key), copyBytes(value))
+	return nil
+}
+
+// Delete implements the Database interface
+func (db *Database) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	if err := db.db.Delete(key); err != nil {
+		db.cache.Evict(hashing.ComputeHash256Array(key))
+		return err
+	}
+	db.cache.Put(hashing.ComputeHash256Array(key), []byte(nil))
+	return nil
+}
+
+// NewBatch implements the Database interface
+func (db *Database) NewBatch() database.Batch {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Batch{}
+	}
+	return &batch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface. Iterators
+// read from the underlying database, so they always reflect the writes made
+// through this database.
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.db.NewIteratorWithStartAndPrefix(start, prefix)
+}
+
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.db.NewIteratorWithRange(start, end)
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.db.NewReverseIteratorWithRange(start, end)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.db.NewReverseIteratorWithPrefix(prefix)
+}
+
+// NewSnapshot implements the Snapshotter interface. Snapshots read from the
+// underlying database's snapshot, bypassing the cache.
+// Returns ErrNoSnapshots if the underlying database doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshotter, ok := db.db.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrNoSnapshots
+	}
+	return snapshotter.NewSnapshot()
+}
+
+// SizeOf implements the Sizer interface.
+// Returns ErrNoSizes if the underlying database doesn't support size estimates.
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	sizer, ok := db.db.(database.Sizer)
+	if !ok {
+		return 0, database.ErrNoSizes
+	}
+	return sizer.SizeOf(start, limit)
+}
+
+// Stat implements the Database interface
+func (db *Database) Stat(stat string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return "", database.ErrClosed
+	}
+	return db.db.Stat(stat)
+}
+
+// Compact implements the Database interface
+func (db *Database) Compact(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Compact(start, limit)
+}
+
+// Close implements the Database interface. The underlying database isn't
+// closed.
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.db = nil
+	db.cache.Flush()
+	return nil
+}
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Batch of database operations
+type batch struct {
+	database.Batch
+	db *Database
+
+	// Copies of the writes made to this batch, applied to the cache when the
+	// batch is written
+	writes []keyValue
+}
+
+// Put implements the Batch interface
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{copyBytes(key), copyBytes(value), false})
+	return b.Batch.Put(key, value)
+}
+
+// Delete implements the Batch interface
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{copyBytes(key), nil, true})
+	return b.Batch.Delete(key)
+}
+
+// Write flushes any accumulated data to the underlying database and updates
+// the cache.
+func (b *batch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+	err := b.Batch.Write()
+	b.db.updateCache(b.writes, err)
+	return err
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.Batch.Reset()
+}
+
+// Inner returns the inner batch of the underlying database, wrapped so that
+// the cache is kept correct when the batch is written with database.WriteAll.
+// The writes of this batch are evicted from the cache when they're replayed
+// into another batch, and the cache is updated again once that batch was
+// written.
+func (b *batch) Inner() database.Batch {
+	return &innerBatch{
+		Batch:  b.Batch.Inner(),
+		db:     b.db,
+		writes: b.writes,
+	}
+}
+
+// innerBatch is the inner batch of the underlying database, along with the
+// writes made to the cachedb batch it's the inner batch of
+type innerBatch struct {
+	database.Batch
+	db *Database
+
+	writes []keyValue
+}
+
+// Write flushes any accumulated data to the inner database and updates the
+// cache.
+func (b *innerBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+	err := b.Batch.Write()
+	b.db.updateCache(b.writes, err)
+	return err
+}
+
+// Replay replays the batch contents into [w] and evicts them from the cache,
+// as they're written by [w] rather than through this database. Reads made
+// before [w] is written may cache the previous values again, so the cache is
+// updated again by Written once [w] was written.
+func (b *innerBatch) Replay(w database.KeyValueWriter) error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	for _, kv := range b.writes {
+		b.db.cache.Evict(hashing.ComputeHash256Array(kv.key))
+	}
+	return b.Batch.Replay(w)
+}
+
+// Written implements the database.WriteNotifier interface
+func (b *innerBatch) Written(err error) {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	b.db.updateCache(b.writes, err)
+}
+
+// Inner returns itself
+func (b *innerBatch) Inner() database.Batch { return b }
+
+// updateCache applies [writes] to the cache after they were written to the
+// underlying database. If the write failed with [err], the writes may have
+// partially succeeded, so they're evicted from the cache instead.
+// Assumes the lock is held.
+func (db *Database) updateCache(writes []keyValue, err error) {
+	for _, kv := range writes {
+		cacheKey := hashing.ComputeHash256Array(kv.key)
+		switch {
+		case err != nil:
+			db.cache.Evict(cacheKey)
+		case kv.delete:
+			db.cache.Put(cacheKey, []byte(nil))
+		default:
+			db.cache.Put(cacheKey, kv.value)
+		}
+	}
+}
+
+func copyBytes(b []byte) []byte {
+	return append([]byte{}, b...)
+}
@@ -0,0 +1,308 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cachedb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+)
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		db, err := New("", prometheus.NewRegistry(), 16, memdb.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		db, err := New("", prometheus.NewRegistry(), 16, memdb.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestSizerInterface(t *testing.T) {
+	for _, test := range database.SizerTests {
+		db, err := New("", prometheus.NewRegistry(), 16, memdb.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestCacheHits(t *testing.T) {
+	baseDB := memdb.New()
+	db, err := New("", prometheus.NewRegistry(), 16, baseDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	if _, err := db.Get(key); err != database.ErrNotFound {
+		t.Fatalf("Expected %s on db.Get but got %s", database.ErrNotFound, err)
+	}
+	if has, err := db.Has(key); err != nil {
+		t.Fatalf("Unexpected error on db.Has: %s", err)
+	} else if has {
+		t.Fatalf("db.Has unexpectedly returned true on key %s", key)
+	}
+	if hits, misses := testutil.ToFloat64(db.hits), testutil.ToFloat64(db.misses); hits != 1 || misses != 1 {
+		t.Fatalf("Expected 1 hit and 1 miss but got %v hits and %v misses", hits, misses)
+	}
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	// Writes made directly to the underlying database aren't seen
+	if err := baseDB.Put(key, []byte("stale")); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+	if hits, misses := testutil.ToFloat64(db.hits), testutil.ToFloat64(db.misses); hits != 2 || misses != 1 {
+		t.Fatalf("Expected 2 hits and 1 miss but got %v hits and %v misses", hits, misses)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	db, err := New("", prometheus.NewRegistry(), 1, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if v, err := db.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value1, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	}
+	if hits, misses := testutil.ToFloat64(db.hits), testutil.ToFloat64(db.misses); hits != 0 || misses != 1 {
+		t.Fatalf("Expected 0 hits and 1 miss but got %v hits and %v misses", hits, misses)
+	}
+}
+
+func TestBatchInvalidation(t *testing.T) {
+	baseDB := memdb.New()
+	db, err := New("", prometheus.NewRegistry(), 16, baseDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefixDB := prefixdb.New([]byte("prefix"), db)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if _, err := prefixDB.Get(key2); err != database.ErrNotFound {
+		t.Fatalf("Expected %s on db.Get but got %s", database.ErrNotFound, err)
+	}
+
+	batch := db.NewBatch()
+	if err := batch.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on batch.Delete: %s", err)
+	}
+	prefixBatch := prefixDB.NewBatch()
+	if err := prefixBatch.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+	if err := database.WriteAll(batch, prefixBatch); err != nil {
+		t.Fatalf("Unexpected error on WriteAll: %s", err)
+	}
+
+	if has, err := db.Has(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Has: %s", err)
+	} else if has {
+		t.Fatalf("db.Has unexpectedly returned true on key %s", key1)
+	}
+	if v, err := prefixDB.Get(key2); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value2, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value2)
+	}
+	if has, err := baseDB.Has(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Has: %s", err)
+	} else if has {
+		t.Fatalf("Key %s wasn't deleted from the underlying database", key1)
+	}
+}
+
+// Test that batches of a cache in front of a prefixed view of a database can
+// be written atomically with batches of the database, as is done when a chain
+// commits together with shared memory
+func TestWriteAllUnderPrefix(t *testing.T) {
+	root := versiondb.New(memdb.New())
+	prefixDB := prefixdb.New([]byte("chain"), root)
+	db, err := New("", prometheus.NewRegistry(), 16, prefixDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+	rootKey := []byte("root")
+	rootValue := []byte("value")
+
+	// Cache that the keys are missing
+	for _, key := range [][]byte{key1, key2} {
+		if _, err := db.Get(key); err != database.ErrNotFound {
+			t.Fatalf("Expected %s on db.Get but got %s", database.ErrNotFound, err)
+		}
+	}
+
+	// The batch of the cache is replayed into the batch of the root
+	batch := db.NewBatch()
+	if err := batch.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+	rootBatch := root.NewBatch()
+	if err := rootBatch.Put(rootKey, rootValue); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+	if err := database.WriteAll(rootBatch, batch); err != nil {
+		t.Fatalf("Unexpected error on WriteAll: %s", err)
+	}
+
+	// The batch of the root is replayed into the batch of the cache
+	batch = db.NewBatch()
+	if err := batch.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+	rootBatch = root.NewBatch()
+	if err := rootBatch.Delete(rootKey); err != nil {
+		t.Fatalf("Unexpected error on batch.Delete: %s", err)
+	}
+	if err := database.WriteAll(batch, rootBatch); err != nil {
+		t.Fatalf("Unexpected error on WriteAll: %s", err)
+	}
+
+	for _, kv := range [][2][]byte{{key1, value1}, {key2, value2}} {
+		key, value := kv[0], kv[1]
+		if v, err := db.Get(key); err != nil {
+			t.Fatalf("Unexpected error on db.Get: %s", err)
+		} else if !bytes.Equal(value, v) {
+			t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+		}
+		if has, err := root.Has(key); err != nil {
+			t.Fatalf("Unexpected error on root.Has: %s", err)
+		} else if has {
+			t.Fatalf("Key %s was written to the root without its prefix", key)
+		}
+		if has, err := prefixDB.Has(key); err != nil {
+			t.Fatalf("Unexpected error on prefixDB.Has: %s", err)
+		} else if !has {
+			t.Fatalf("Key %s wasn't written to the root under its prefix", key)
+		}
+	}
+	if has, err := root.Has(rootKey); err != nil {
+		t.Fatalf("Unexpected error on root.Has: %s", err)
+	} else if has {
+		t.Fatalf("Key %s wasn't deleted from the root", rootKey)
+	}
+}
+
+// readingBatch reads from a database right before it's written
+type readingBatch struct {
+	database.Batch
+	read func()
+}
+
+func (b *readingBatch) Write() error {
+	b.read()
+	return b.Batch.Write()
+}
+
+func (b *readingBatch) Inner() database.Batch { return b }
+
+// Test that reads made after a batch of the cache was replayed, but before the
+// batch it was replayed into was written, don't leave stale values in the cache
+func TestWriteAllConcurrentRead(t *testing.T) {
+	root := memdb.New()
+	db, err := New("", prometheus.NewRegistry(), 16, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	batch := db.NewBatch()
+	if err := batch.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	}
+	rootBatch := &readingBatch{
+		Batch: root.NewBatch(),
+		read: func() {
+			// The key hasn't been written yet, so its absence is cached
+			if _, err := db.Get(key); err != database.ErrNotFound {
+				t.Fatalf("Expected %s on db.Get but got %s", database.ErrNotFound, err)
+			}
+		},
+	}
+	if err := database.WriteAll(rootBatch, batch); err != nil {
+		t.Fatalf("Unexpected error on WriteAll: %s", err)
+	}
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+func TestNewBatchAfterClose(t *testing.T) {
+	db, err := New("", prometheus.NewRegistry(), 16, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := db.NewBatch()
+	if err := batch.Put([]byte("hello"), []byte("world")); err != database.ErrClosed {
+		t.Fatalf("Expected %s on batch.Put but got %v", database.ErrClosed, err)
+	}
+	if err := batch.Write(); err != database.ErrClosed {
+		t.Fatalf("Expected %s on batch.Write but got %v", database.ErrClosed, err)
+	}
+}
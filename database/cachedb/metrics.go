@@ -0,0 +1,37 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cachedb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	hits, misses prometheus.Counter
+}
+
+func (m *metrics) Initialize(
+	namespace string,
+	registerer prometheus.Registerer,
+) error {
+	m.hits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_hits",
+		Help:      "Number of reads that were served from the cache",
+	})
+	m.misses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_misses",
+		Help:      "Number of reads that were served from the underlying database",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.hits),
+		registerer.Register(m.misses),
+	)
+	return errs.Err
+}
@@ -49,6 +49,40 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Sizer wraps the SizeOf method of a backing data store.
+type Sizer interface {
+	// SizeOf returns the approximate number of bytes of storage used by the
+	// keys in [start, limit). A nil limit is treated as a key after all keys
+	// in the DB. Recently written data may not be included in the estimate.
+	SizeOf(start, limit []byte) (uint64, error)
+}
+
+// SizeOfPrefix returns the approximate number of bytes of storage used by the
+// keys in [db] that start with [prefix].
+func SizeOfPrefix(db Sizer, prefix []byte) (uint64, error) {
+	return db.SizeOf(prefix, PrefixEnd(prefix))
+}
+
+// Snapshot is a read-only view of a backing data store at a point in time.
+// Writes made to the data store after the snapshot was taken aren't visible
+// through the snapshot.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases the resources held by the snapshot. After Release is
+	// called, reads from the snapshot fail with ErrClosed. Release should
+	// always succeed and can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot returns a consistent, read-only view of the current state of
+	// the key-value data store. The snapshot must be released after use.
+	NewSnapshot() (Snapshot, error)
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
@@ -4,8 +4,12 @@
 package encdb
 
 import (
+	"bytes"
 	"crypto/cipher"
-	"crypto/rand"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"sort"
 	"sync"
 
 	"golang.org/x/crypto/chacha20poly1305"
@@ -14,7 +18,6 @@ import (
 	"github.com/ava-labs/avalanchego/database/nodb"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/codec"
-	"github.com/ava-labs/avalanchego/utils/hashing"
 )
 
 const (
@@ -26,23 +29,85 @@ type Database struct {
 	lock   sync.RWMutex
 	codec  codec.Manager
 	cipher cipher.AEAD
+	// True if the HMACs of keys are stored rather than the keys
+	hashKeys bool
+	// Key of the HMACs of keys
+	macKey []byte
 	db     database.Database
 }
 
-// New returns a new encrypted database
+// New returns a new encrypted database. If [db] is empty or was written before
+// the format was versioned, it is written in the current format with the
+// default config. See NewWithConfig.
 func New(password []byte, db database.Database) (*Database, error) {
-	h := hashing.ComputeHash256(password)
-	aead, err := chacha20poly1305.NewX(h)
+	return NewWithConfig(password, db, Config{})
+}
+
+// NewWithConfig returns a new encrypted database. If [db] is empty, it is
+// formatted using [config]. If [db] was written before the format was
+// versioned, it is upgraded in place to the current format using [config].
+// Otherwise, [db] is opened with the format it was created with.
+func NewWithConfig(password []byte, db database.Database, config Config) (*Database, error) {
+	return NewWithKeyCache(password, db, config, nil)
+}
+
+// NewWithKeyCache returns a new encrypted database, as NewWithConfig does. The
+// keys derived from [password] are cached in [keys], so that opening a
+// database again with [keys] doesn't derive them again. [keys] may be nil.
+func NewWithKeyCache(password []byte, db database.Database, config Config, keys *KeyCache) (*Database, error) {
+	manager, err := newCodec()
+	if err != nil {
+		return nil, err
+	}
+
+	mdBytes, err := db.Get(metadataKey)
+	switch err {
+	case nil:
+		md := metadata{}
+		if _, err := manager.Unmarshal(mdBytes, &md); err != nil {
+			return nil, fmt.Errorf("couldn't parse encdb metadata: %w", err)
+		}
+		if md.Version != currentVersion {
+			return nil, fmt.Errorf("%w %d", errUnknownVersion, md.Version)
+		}
+		encKey, macKey := keys.deriveKeys(&md, password)
+		if !hmac.Equal(computeMAC(macKey, checkMessage), md.Check[:]) {
+			return nil, errIncorrectPassword
+		}
+		return newDatabase(db, manager, &md, encKey, macKey)
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	// [db] is either empty or in the legacy format
+	md, err := newMetadata(config)
+	if err != nil {
+		return nil, err
+	}
+	encKey, macKey := keys.deriveKeys(md, password)
+	copy(md.Check[:], computeMAC(macKey, checkMessage))
+
+	encDB, err := newDatabase(db, manager, md, encKey, macKey)
+	if err != nil {
+		return nil, err
+	}
+	return encDB, encDB.upgrade(password, md)
+}
+
+// newDatabase returns the encrypted database described by [md]
+func newDatabase(db database.Database, manager codec.Manager, md *metadata, encKey, macKey []byte) (*Database, error) {
+	aead, err := chacha20poly1305.NewX(encKey)
 	if err != nil {
 		return nil, err
 	}
-	c := codec.NewDefault()
-	manager := codec.NewDefaultManager()
 	return &Database{
-		codec:  manager,
-		cipher: aead,
-		db:     db,
-	}, manager.RegisterCodec(codecVersion, c)
+		codec:    manager,
+		cipher:   aead,
+		hashKeys: md.HashKeys,
+		macKey:   macKey,
+		db:       db,
+	}, nil
 }
 
 // Has implements the Database interface
@@ -53,7 +118,7 @@ func (db *Database) Has(key []byte) (bool, error) {
 	if db.db == nil {
 		return false, database.ErrClosed
 	}
-	return db.db.Has(key)
+	return db.db.Has(db.storageKey(key))
 }
 
 // Get implements the Database interface
@@ -64,11 +129,18 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 	if db.db == nil {
 		return nil, database.ErrClosed
 	}
-	encVal, err := db.db.Get(key)
+	return db.get(db.db, key)
+}
+
+// get returns the value of [key] in [source]
+func (db *Database) get(source database.KeyValueReader, key []byte) ([]byte, error) {
+	storageKey := db.storageKey(key)
+	encVal, err := source.Get(storageKey)
 	if err != nil {
 		return nil, err
 	}
-	return db.decrypt(encVal)
+	_, val, err := db.decrypt(storageKey, encVal)
+	return val, err
 }
 
 // Put implements the Database interface
@@ -80,11 +152,12 @@ func (db *Database) Put(key, value []byte) error {
 		return database.ErrClosed
 	}
 
-	encValue, err := db.encrypt(value)
+	storageKey := db.storageKey(key)
+	encValue, err := db.encrypt(storageKey, key, value)
 	if err != nil {
 		return err
 	}
-	return db.db.Put(key, encValue)
+	return db.db.Put(storageKey, encValue)
 }
 
 // Delete implements the Database interface
@@ -95,7 +168,7 @@ func (db *Database) Delete(key []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
-	return db.db.Delete(key)
+	return db.db.Delete(db.storageKey(key))
 }
 
 // NewBatch implements the Database interface
@@ -108,33 +181,157 @@ func (db *Database) NewBatch() database.Batch {
 
 // NewIterator implements the Database interface
 func (db *Database) NewIterator() database.Iterator {
-	return db.NewIteratorWithStartAndPrefix(nil, nil)
+	return db.newIterator(nil, nil, nil, false)
 }
 
 // NewIteratorWithStart implements the Database interface
 func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
-	return db.NewIteratorWithStartAndPrefix(start, nil)
+	return db.newIterator(start, nil, nil, false)
 }
 
 // NewIteratorWithPrefix implements the Database interface
 func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
-	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+	return db.newIterator(nil, nil, prefix, false)
 }
 
 // NewIteratorWithStartAndPrefix implements the Database interface
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(start, nil, prefix, false)
+}
+
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, false)
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(nil, nil, prefix, true)
+}
+
+func (db *Database) newIterator(start, end, prefix []byte, reverse bool) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
 	if db.db == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return db.newIteratorOver(db.db, start, end, prefix, reverse)
+}
+
+// newIteratorOver returns an iterator over the keys of [source] in
+// [start, end) that start with [prefix]. A nil [end] is unbounded.
+func (db *Database) newIteratorOver(source database.Iteratee, start, end, prefix []byte, reverse bool) database.Iterator {
+	if db.hashKeys {
+		return db.newSortedIterator(source, start, end, prefix, reverse)
+	}
+
+	var it database.Iterator
+	switch {
+	case prefix != nil && reverse:
+		it = source.NewReverseIteratorWithPrefix(prefixKey(prefix))
+	case prefix != nil:
+		it = source.NewIteratorWithStartAndPrefix(prefixKey(start), prefixKey(prefix))
+	default:
+		dataStart, dataEnd := dataRange(start, end)
+		if reverse {
+			it = source.NewReverseIteratorWithRange(dataStart, dataEnd)
+		} else {
+			it = source.NewIteratorWithRange(dataStart, dataEnd)
+		}
+	}
 	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: it,
 		db:       db,
 	}
 }
 
+// newSortedIterator decrypts every key of [source] and returns an iterator
+// over the keys in [start, end) that start with [prefix]. Used when keys are
+// hashed, as the underlying database doesn't order the keys.
+func (db *Database) newSortedIterator(source database.Iteratee, start, end, prefix []byte, reverse bool) database.Iterator {
+	it := source.NewIteratorWithPrefix(dataPrefix)
+	defer it.Release()
+
+	entries := []entry(nil)
+	for it.Next() {
+		key, value, err := db.decrypt(it.Key(), it.Value())
+		if err != nil {
+			return &nodb.Iterator{Err: err}
+		}
+		if bytes.HasPrefix(key, prefix) &&
+			bytes.Compare(key, start) >= 0 &&
+			(end == nil || bytes.Compare(key, end) == -1) {
+			entries = append(entries, entry{
+				Key:   key,
+				Value: value,
+			})
+		}
+	}
+	if err := it.Error(); err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].Key, entries[j].Key) == -1
+	})
+	if reverse {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+	return &sortedIterator{
+		db:      db,
+		entries: entries,
+	}
+}
+
+// NewSnapshot implements the Snapshotter interface.
+// Returns ErrNoSnapshots if the underlying database doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshotter, ok := db.db.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrNoSnapshots
+	}
+	snap, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: snap,
+		db:       db,
+	}, nil
+}
+
+// SizeOf implements the Sizer interface. If keys are hashed, only the size of
+// the whole database can be estimated.
+// Returns ErrNoSizes if the underlying database doesn't support size estimates.
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	sizer, ok := db.db.(database.Sizer)
+	if !ok || (db.hashKeys && (start != nil || limit != nil)) {
+		return 0, database.ErrNoSizes
+	}
+	dataStart, dataLimit := dataRange(start, limit)
+	return sizer.SizeOf(dataStart, dataLimit)
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -146,7 +343,8 @@ func (db *Database) Stat(stat string) (string, error) {
 	return db.db.Stat(stat)
 }
 
-// Compact implements the Database interface
+// Compact implements the Database interface. If keys are hashed, every key is
+// compacted.
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -154,7 +352,12 @@ func (db *Database) Compact(start, limit []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
-	return db.db.Compact(start, limit)
+	if db.hashKeys {
+		start = nil
+		limit = nil
+	}
+	dataStart, dataLimit := dataRange(start, limit)
+	return db.db.Compact(dataStart, dataLimit)
 }
 
 // Close implements the Database interface
@@ -184,16 +387,17 @@ type batch struct {
 
 func (b *batch) Put(key, value []byte) error {
 	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
-	encValue, err := b.db.encrypt(value)
+	storageKey := b.db.storageKey(key)
+	encValue, err := b.db.encrypt(storageKey, key, value)
 	if err != nil {
 		return err
 	}
-	return b.Batch.Put(key, encValue)
+	return b.Batch.Put(storageKey, encValue)
 }
 
 func (b *batch) Delete(key []byte) error {
 	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
-	return b.Batch.Delete(key)
+	return b.Batch.Delete(b.db.storageKey(key))
 }
 
 func (b *batch) Write() error {
@@ -231,25 +435,70 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	return s.Snapshot.Has(s.db.storageKey(key))
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	return s.db.get(s.Snapshot, key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.db.newIteratorOver(s.Snapshot, nil, nil, nil, false)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.db.newIteratorOver(s.Snapshot, start, nil, nil, false)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newIteratorOver(s.Snapshot, nil, nil, prefix, false)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.db.newIteratorOver(s.Snapshot, start, nil, prefix, false)
+}
+
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return s.db.newIteratorOver(s.Snapshot, start, end, nil, false)
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return s.db.newIteratorOver(s.Snapshot, start, end, nil, true)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newIteratorOver(s.Snapshot, nil, nil, prefix, true)
+}
+
+// iterator decrypts the values of an iterator over the underlying database
 type iterator struct {
 	database.Iterator
 	db *Database
 
-	val []byte
-	err error
+	key, val []byte
+	err      error
 }
 
 func (it *iterator) Next() bool {
 	next := it.Iterator.Next()
 	if next {
-		encVal := it.Iterator.Value()
-		val, err := it.db.decrypt(encVal)
+		key, val, err := it.db.decrypt(it.Iterator.Key(), it.Iterator.Value())
 		if err != nil {
 			it.err = err
+			it.key = nil
+			it.val = nil
 			return false
 		}
+		it.key = key
 		it.val = val
 	} else {
+		it.key = nil
 		it.val = nil
 	}
 	return next
@@ -262,29 +511,58 @@ func (it *iterator) Error() error {
 	return it.Iterator.Error()
 }
 
+func (it *iterator) Key() []byte { return it.key }
+
 func (it *iterator) Value() []byte { return it.val }
 
-type encryptedValue struct {
-	Ciphertext []byte `serialize:"true"`
-	Nonce      []byte `serialize:"true"`
+// sortedIterator iterates over decrypted entries that have already been read
+type sortedIterator struct {
+	db      *Database
+	entries []entry
+	index   int
+	key     []byte
+	val     []byte
 }
 
-func (db *Database) encrypt(plaintext []byte) ([]byte, error) {
-	nonce := make([]byte, chacha20poly1305.NonceSizeX)
-	if _, err := rand.Read(nonce); err != nil {
-		return nil, err
+func (it *sortedIterator) Next() bool {
+	it.db.lock.RLock()
+	closed := it.db.db == nil
+	it.db.lock.RUnlock()
+
+	if closed || it.index >= len(it.entries) {
+		it.key = nil
+		it.val = nil
+		return false
 	}
-	ciphertext := db.cipher.Seal(nil, nonce, plaintext, nil)
-	return db.codec.Marshal(codecVersion, &encryptedValue{
-		Ciphertext: ciphertext,
-		Nonce:      nonce,
-	})
+	it.key = it.entries[it.index].Key
+	it.val = it.entries[it.index].Value
+	it.index++
+	return true
 }
 
-func (db *Database) decrypt(ciphertext []byte) ([]byte, error) {
-	val := encryptedValue{}
-	if _, err := db.codec.Unmarshal(ciphertext, &val); err != nil {
-		return nil, err
+func (it *sortedIterator) Error() error {
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	if it.db.db == nil {
+		return database.ErrClosed
 	}
-	return db.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+	return nil
+}
+
+func (it *sortedIterator) Key() []byte { return it.key }
+
+func (it *sortedIterator) Value() []byte { return it.val }
+
+func (it *sortedIterator) Release() {
+	it.entries = nil
+	it.key = nil
+	it.val = nil
+}
+
+// computeMAC returns the HMAC-SHA256 of [msg] with [key]
+func computeMAC(key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(msg)
+	return mac.Sum(nil)
 }
@@ -4,10 +4,14 @@
 package encdb
 
 import (
+	"bytes"
 	"testing"
 
+	"golang.org/x/crypto/chacha20poly1305"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 )
 
 func TestInterface(t *testing.T) {
@@ -22,3 +26,230 @@ func TestInterface(t *testing.T) {
 		test(t, db)
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	pw := "lol totally a secure password"
+	for _, test := range database.SnapshotTests {
+		unencryptedDB := memdb.New()
+		db, err := New([]byte(pw), unencryptedDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestSizerInterface(t *testing.T) {
+	pw := "lol totally a secure password"
+	for _, test := range database.SizerTests {
+		unencryptedDB := memdb.New()
+		db, err := New([]byte(pw), unencryptedDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestHashedKeysInterface(t *testing.T) {
+	pw := "lol totally a secure password"
+	tests := append([]func(t *testing.T, db database.Database){}, database.Tests...)
+	tests = append(tests, database.SnapshotTests...)
+	for _, test := range tests {
+		unencryptedDB := memdb.New()
+		db, err := NewWithConfig([]byte(pw), unencryptedDB, Config{HashKeys: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+// putLegacy writes [key] mapped to [value] in the format used before encdb was
+// versioned
+func putLegacy(t *testing.T, password []byte, db database.Database, key, value []byte) {
+	aead, err := chacha20poly1305.NewX(hashing.ComputeHash256(password))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, err := newCodec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	encValue, err := manager.Marshal(codecVersion, &encryptedValue{
+		Ciphertext: aead.Seal(nil, nonce, value, nil),
+		Nonce:      nonce,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(key, encValue); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpgradeLegacy(t *testing.T) {
+	pw := []byte("lol totally a secure password")
+	for _, hashKeys := range []bool{false, true} {
+		unencryptedDB := memdb.New()
+		putLegacy(t, pw, unencryptedDB, []byte("key1"), []byte("value1"))
+		putLegacy(t, pw, unencryptedDB, []byte{0x01, 'k'}, []byte("value2"))
+
+		// An incorrect password fails the upgrade without modifying the
+		// database
+		if _, err := NewWithConfig([]byte("wrong"), unencryptedDB, Config{HashKeys: hashKeys}); err == nil {
+			t.Fatal("should have failed to upgrade with the wrong password")
+		}
+		if has, err := unencryptedDB.Has(metadataKey); err != nil {
+			t.Fatal(err)
+		} else if has {
+			t.Fatal("failed upgrade wrote metadata")
+		}
+
+		db, err := NewWithConfig(pw, unencryptedDB, Config{HashKeys: hashKeys})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if has, err := unencryptedDB.Has([]byte("key1")); err != nil {
+			t.Fatal(err)
+		} else if has {
+			t.Fatal("legacy key wasn't removed by the upgrade")
+		}
+
+		it := db.NewIterator()
+		expected := [][2]string{{"\x01k", "value2"}, {"key1", "value1"}}
+		for _, kv := range expected {
+			if !it.Next() {
+				t.Fatalf("iterator stopped before %q", kv[0])
+			}
+			if key := string(it.Key()); key != kv[0] {
+				t.Fatalf("expected key %q but got %q", kv[0], key)
+			}
+			if value := string(it.Value()); value != kv[1] {
+				t.Fatalf("expected value %q but got %q", kv[1], value)
+			}
+		}
+		if it.Next() {
+			t.Fatal("iterator returned too many keys")
+		}
+		if err := it.Error(); err != nil {
+			t.Fatal(err)
+		}
+		it.Release()
+
+		// Reopening uses the recorded format rather than the config
+		db, err = NewWithConfig(pw, unencryptedDB, Config{HashKeys: !hashKeys})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if db.hashKeys != hashKeys {
+			t.Fatal("reopened database should use its recorded format")
+		}
+		if value, err := db.Get([]byte("key1")); err != nil {
+			t.Fatal(err)
+		} else if string(value) != "value1" {
+			t.Fatalf("expected value1 but got %q", value)
+		}
+	}
+}
+
+func TestIncorrectPassword(t *testing.T) {
+	unencryptedDB := memdb.New()
+	if _, err := New([]byte("password"), unencryptedDB); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New([]byte("not the password"), unencryptedDB); err != errIncorrectPassword {
+		t.Fatalf("expected %s but got %v", errIncorrectPassword, err)
+	}
+}
+
+func TestKeyCache(t *testing.T) {
+	pw := []byte("password")
+	keys := &KeyCache{}
+	unencryptedDB := memdb.New()
+	db, err := NewWithKeyCache(pw, unencryptedDB, Config{}, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys.keys) != 1 {
+		t.Fatalf("expected the keys of 1 database to be cached but got %d", len(keys.keys))
+	}
+
+	// The cached keys shouldn't be given to a different password
+	if _, err := NewWithKeyCache([]byte("not the password"), unencryptedDB, Config{}, keys); err != errIncorrectPassword {
+		t.Fatalf("expected %s but got %v", errIncorrectPassword, err)
+	}
+
+	db, err = NewWithKeyCache(pw, unencryptedDB, Config{}, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := db.Get([]byte("key")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte("value")) {
+		t.Fatalf("expected %q but got %q", "value", value)
+	}
+	if len(keys.keys) != 1 {
+		t.Fatalf("expected the keys of 1 database to be cached but got %d", len(keys.keys))
+	}
+}
+
+func TestSwappedValues(t *testing.T) {
+	pw := []byte("lol totally a secure password")
+	for _, hashKeys := range []bool{false, true} {
+		unencryptedDB := memdb.New()
+		db, err := NewWithConfig(pw, unencryptedDB, Config{HashKeys: hashKeys})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Put([]byte("alice"), []byte("alice's key")); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Put([]byte("bob"), []byte("bob's key")); err != nil {
+			t.Fatal(err)
+		}
+
+		// Move bob's value under alice's key
+		bobValue, err := unencryptedDB.Get(db.storageKey([]byte("bob")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := unencryptedDB.Put(db.storageKey([]byte("alice")), bobValue); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := db.Get([]byte("alice")); err == nil {
+			t.Fatal("should have failed to decrypt a value moved to another key")
+		}
+	}
+}
+
+func TestHashedKeysHidden(t *testing.T) {
+	unencryptedDB := memdb.New()
+	db, err := NewWithConfig([]byte("password"), unencryptedDB, Config{HashKeys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := []byte("a very recognizable key")
+	if err := db.Put(key, []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	it := unencryptedDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		if bytes.Contains(it.Key(), key) || bytes.Contains(it.Value(), key) {
+			t.Fatal("underlying database revealed the key")
+		}
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+}
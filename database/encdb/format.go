@@ -0,0 +1,311 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/codec"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Databases created before the format was versioned (version 0) store each
+// value encrypted with the hash of the password, under its plaintext key, and
+// have no metadata.
+//
+// Version 1 databases store:
+//   - Their metadata under [metadataKey].
+//   - Each value under [dataPrefix] followed by either its key or, if keys are
+//     hashed, the HMAC of its key. The value is encrypted with a key derived
+//     from the password by Argon2id, and the storage key is authenticated as
+//     associated data so that values can't be moved between keys. If keys are
+//     hashed, the key is encrypted along with the value.
+const (
+	currentVersion uint16 = 1
+
+	saltLen = 16
+
+	// Argon2id parameters of new databases. These match the parameters used to
+	// hash keystore passwords.
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+)
+
+var (
+	// metadataKey sorts before every data key
+	metadataKey = []byte("\x00encdb")
+	dataPrefix  = []byte{0x01}
+
+	// checkMessage is authenticated in the metadata to detect an incorrect
+	// password before any value is read
+	checkMessage = []byte("encdb password check")
+
+	errIncorrectPassword = errors.New("incorrect password")
+	errUnknownVersion    = errors.New("unknown encdb version")
+)
+
+// Config is the format of a newly created or upgraded database. The format of
+// an existing database is read from its metadata, so the config is ignored.
+type Config struct {
+	// HashKeys stores the HMAC of each key rather than the key itself, so that
+	// the underlying database doesn't reveal keys. Keys are instead encrypted
+	// with their values. As the underlying database no longer orders the keys,
+	// iterators read, decrypt and sort every key in the database, so this
+	// should only be used for small databases.
+	HashKeys bool
+}
+
+// metadata describes the format of a database
+type metadata struct {
+	Version uint16        `serialize:"true"`
+	Salt    [saltLen]byte `serialize:"true"`
+	Time    uint32        `serialize:"true"`
+	Memory  uint32        `serialize:"true"`
+	Threads uint8         `serialize:"true"`
+	// True if the HMACs of keys are stored rather than the keys
+	HashKeys bool `serialize:"true"`
+	// HMAC of [checkMessage]
+	Check [sha256.Size]byte `serialize:"true"`
+}
+
+// newMetadata returns the metadata of a new database in the current format
+// with a random salt
+func newMetadata(config Config) (*metadata, error) {
+	md := &metadata{
+		Version:  currentVersion,
+		Time:     argon2Time,
+		Memory:   argon2Memory,
+		Threads:  argon2Threads,
+		HashKeys: config.HashKeys,
+	}
+	_, err := rand.Read(md.Salt[:])
+	return md, err
+}
+
+// deriveKeys returns the encryption key and the HMAC key of the database
+// described by [md]
+func (md *metadata) deriveKeys(password []byte) ([]byte, []byte) {
+	keys := argon2.IDKey(password, md.Salt[:], md.Time, md.Memory, md.Threads, 2*chacha20poly1305.KeySize)
+	return keys[:chacha20poly1305.KeySize], keys[chacha20poly1305.KeySize:]
+}
+
+// kdfParams are the inputs, other than the password, that keys are derived
+// from
+type kdfParams struct {
+	salt    [saltLen]byte
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// KeyCache holds the keys derived from a password, so that databases that are
+// opened repeatedly with the same password only run Argon2id once. The zero
+// value is ready to use.
+type KeyCache struct {
+	lock sync.Mutex
+	keys map[kdfParams]cachedKeys
+}
+
+type cachedKeys struct {
+	encKey, macKey []byte
+	// HMAC of the password the keys were derived from, so that a different
+	// password isn't given the cached keys
+	passwordMAC []byte
+}
+
+// deriveKeys returns the encryption key and the HMAC key of the database
+// described by [md], using the cached keys if they were derived from
+// [password]. [c] may be nil, in which case the keys are always derived.
+func (c *KeyCache) deriveKeys(md *metadata, password []byte) ([]byte, []byte) {
+	if c == nil {
+		return md.deriveKeys(password)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	params := kdfParams{
+		salt:    md.Salt,
+		time:    md.Time,
+		memory:  md.Memory,
+		threads: md.Threads,
+	}
+	if keys, ok := c.keys[params]; ok && hmac.Equal(computeMAC(keys.macKey, password), keys.passwordMAC) {
+		return keys.encKey, keys.macKey
+	}
+	if c.keys == nil {
+		c.keys = make(map[kdfParams]cachedKeys)
+	}
+
+	encKey, macKey := md.deriveKeys(password)
+	c.keys[params] = cachedKeys{
+		encKey:      encKey,
+		macKey:      macKey,
+		passwordMAC: computeMAC(macKey, password),
+	}
+	return encKey, macKey
+}
+
+// entry is the plaintext of a value whose key is hashed
+type entry struct {
+	Key   []byte `serialize:"true"`
+	Value []byte `serialize:"true"`
+}
+
+type encryptedValue struct {
+	Ciphertext []byte `serialize:"true"`
+	Nonce      []byte `serialize:"true"`
+}
+
+// storageKey returns the key that [key] is stored under in the underlying
+// database
+func (db *Database) storageKey(key []byte) []byte {
+	if !db.hashKeys {
+		return prefixKey(key)
+	}
+	mac := hmac.New(sha256.New, db.macKey)
+	_, _ = mac.Write(key)
+	return mac.Sum(prefixKey(nil))
+}
+
+// encrypt returns the value stored under [storageKey] to map [key] to [value]
+func (db *Database) encrypt(storageKey, key, value []byte) ([]byte, error) {
+	plaintext := value
+	if db.hashKeys {
+		var err error
+		plaintext, err = db.codec.Marshal(codecVersion, &entry{
+			Key:   key,
+			Value: value,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ciphertext := db.cipher.Seal(nil, nonce, plaintext, storageKey)
+	return db.codec.Marshal(codecVersion, &encryptedValue{
+		Ciphertext: ciphertext,
+		Nonce:      nonce,
+	})
+}
+
+// decrypt returns the key and value stored under [storageKey]
+func (db *Database) decrypt(storageKey, storedValue []byte) ([]byte, []byte, error) {
+	val := encryptedValue{}
+	if _, err := db.codec.Unmarshal(storedValue, &val); err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := db.cipher.Open(nil, val.Nonce, val.Ciphertext, storageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !db.hashKeys {
+		key := make([]byte, len(storageKey)-len(dataPrefix))
+		copy(key, storageKey[len(dataPrefix):])
+		return key, plaintext, nil
+	}
+
+	e := entry{}
+	if _, err := db.codec.Unmarshal(plaintext, &e); err != nil {
+		return nil, nil, err
+	}
+	return e.Key, e.Value, nil
+}
+
+// upgrade rewrites every value of a legacy database, encrypted with
+// [password], in the format described by [md] and records [md]. If the
+// database is empty, only [md] is written. The writes are made in a single
+// batch, so a failed upgrade leaves the database unchanged.
+func (db *Database) upgrade(password []byte, md *metadata) error {
+	legacyCipher, err := chacha20poly1305.NewX(hashing.ComputeHash256(password))
+	if err != nil {
+		return err
+	}
+
+	it := db.db.NewIterator()
+	defer it.Release()
+
+	// Legacy keys are deleted before any value is written, so that a legacy
+	// key can't delete a value written by the upgrade
+	batch := db.db.NewBatch()
+	entries := []entry(nil)
+	for it.Next() {
+		key := it.Key()
+		val := encryptedValue{}
+		if _, err := db.codec.Unmarshal(it.Value(), &val); err != nil {
+			return fmt.Errorf("couldn't parse legacy value: %w", err)
+		}
+		value, err := legacyCipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+		if err != nil {
+			return fmt.Errorf("couldn't decrypt legacy value: %w", err)
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		entries = append(entries, entry{
+			Key:   key,
+			Value: value,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		storageKey := db.storageKey(e.Key)
+		storedValue, err := db.encrypt(storageKey, e.Key, e.Value)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(storageKey, storedValue); err != nil {
+			return err
+		}
+	}
+
+	mdBytes, err := db.codec.Marshal(codecVersion, md)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(metadataKey, mdBytes); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// prefixKey returns [key] prefixed with [dataPrefix]
+func prefixKey(key []byte) []byte {
+	prefixed := make([]byte, len(dataPrefix)+len(key), len(dataPrefix)+len(key)+sha256.Size)
+	copy(prefixed, dataPrefix)
+	copy(prefixed[len(dataPrefix):], key)
+	return prefixed
+}
+
+// dataRange returns the range of the underlying database that holds the values
+// of the keys in [start, limit). A nil [limit] is treated as a key after all
+// keys.
+func dataRange(start, limit []byte) ([]byte, []byte) {
+	if limit == nil {
+		return prefixKey(start), database.PrefixEnd(dataPrefix)
+	}
+	return prefixKey(start), prefixKey(limit)
+}
+
+func newCodec() (codec.Manager, error) {
+	manager := codec.NewDefaultManager()
+	return manager, manager.RegisterCodec(codecVersion, codec.NewDefault())
+}
@@ -0,0 +1,141 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/badgerdb"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+)
+
+const (
+	// LevelDB is the name of the LevelDB storage engine
+	LevelDB = "leveldb"
+
+	// BadgerDB is the name of the BadgerDB storage engine
+	BadgerDB = "badgerdb"
+
+	// typeFile is the name of the file, in a database directory, that records
+	// which engine the database was created with
+	typeFile = "DBTYPE"
+
+	// levelDBCurrentFile is always present in a directory managed by LevelDB.
+	// Databases created before the engine was recorded are all LevelDB
+	// databases.
+	levelDBCurrentFile = "CURRENT"
+)
+
+var (
+	errUnknownEngine = errors.New("unknown database engine")
+	errNoDatabase    = errors.New("no database found")
+)
+
+// Engines is the list of storage engines that can be opened
+var Engines = []string{LevelDB, BadgerDB}
+
+// Verify returns an error if [engine] isn't a known storage engine
+func Verify(engine string) error {
+	for _, known := range Engines {
+		if engine == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q, expected one of %v", errUnknownEngine, engine, Engines)
+}
+
+// Open opens the database in [dir] using [engine]. Returns an error if the
+// database in [dir] was created with a different engine. If [dir] doesn't
+// contain a database, a new one is created and the engine is recorded.
+func Open(dir, engine string) (database.Database, error) {
+	if err := Verify(engine); err != nil {
+		return nil, err
+	}
+
+	recorded, err := Read(dir)
+	if err != nil {
+		return nil, err
+	}
+	switch recorded {
+	case "":
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, err
+		}
+	case engine:
+	default:
+		return nil, fmt.Errorf("database at %s was created with %s but %s was requested", dir, recorded, engine)
+	}
+
+	var db database.Database
+	switch engine {
+	case BadgerDB:
+		db, err = badgerdb.New(dir)
+	default:
+		db, err = leveldb.New(dir, 0, 0, 0)
+	}
+	if err != nil || recorded != "" {
+		return db, err
+	}
+
+	// The engine is only recorded once the database was created, so that a
+	// failed open doesn't leave behind a directory that claims to hold a
+	// database
+	if err := ioutil.WriteFile(filepath.Join(dir, typeFile), []byte(engine), 0600); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("couldn't record the database engine: %w", err)
+	}
+	return db, nil
+}
+
+// OpenReadOnly opens the existing database in [dir] without allowing writes.
+// If [engine] is empty, the engine the database was created with is used.
+// Returns an error if the database in [dir] was created with a different
+// engine.
+func OpenReadOnly(dir, engine string) (database.Database, error) {
+	recorded, err := Read(dir)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case recorded == "":
+		return nil, fmt.Errorf("%w at %s", errNoDatabase, dir)
+	case engine != "" && engine != recorded:
+		return nil, fmt.Errorf("database at %s was created with %s but %s was requested", dir, recorded, engine)
+	}
+	if err := Verify(recorded); err != nil {
+		return nil, err
+	}
+
+	switch recorded {
+	case BadgerDB:
+		return badgerdb.NewReadOnly(dir)
+	default:
+		return leveldb.NewReadOnly(dir)
+	}
+}
+
+// Read returns the engine that the database in [dir] was created with.
+// Returns an empty string if [dir] doesn't contain a database.
+func Read(dir string) (string, error) {
+	engine, err := ioutil.ReadFile(filepath.Join(dir, typeFile))
+	switch {
+	case err == nil:
+		return string(bytes.TrimSpace(engine)), nil
+	case !os.IsNotExist(err):
+		return "", fmt.Errorf("couldn't read the database engine: %w", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, levelDBCurrentFile)); err == nil {
+		return LevelDB, nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	return "", nil
+}
@@ -0,0 +1,122 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/leveldb"
+)
+
+func TestOpenRecordsEngine(t *testing.T) {
+	for _, engine := range Engines {
+		dir, err := ioutil.TempDir("", "engine")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		db, err := Open(dir, engine)
+		if err != nil {
+			t.Fatalf("Open(%s) errored with %s", engine, err)
+		}
+		if err := db.Put([]byte("hello"), []byte("world")); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if recorded, err := Read(dir); err != nil {
+			t.Fatal(err)
+		} else if recorded != engine {
+			t.Fatalf("Read returned %q ; Expected %q", recorded, engine)
+		}
+
+		for _, other := range Engines {
+			if other == engine {
+				continue
+			}
+			if _, err := Open(dir, other); err == nil {
+				t.Fatalf("Open(%s) should have failed on a %s database", other, engine)
+			}
+		}
+
+		db, err = Open(dir, engine)
+		if err != nil {
+			t.Fatalf("Open(%s) errored with %s", engine, err)
+		}
+		if has, err := db.Has([]byte("hello")); err != nil {
+			t.Fatal(err)
+		} else if !has {
+			t.Fatalf("reopened %s database is missing its key", engine)
+		}
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOpenUnrecordedLevelDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := leveldb.New(dir, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorded, err := Read(dir); err != nil {
+		t.Fatal(err)
+	} else if recorded != LevelDB {
+		t.Fatalf("Read returned %q ; Expected %q", recorded, LevelDB)
+	}
+
+	if _, err := Open(dir, BadgerDB); err == nil {
+		t.Fatalf("Open(%s) should have failed on a %s database", BadgerDB, LevelDB)
+	}
+}
+
+func TestOpenUnknownEngine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := Open(dir, "rocksdb"); err == nil {
+		t.Fatalf("Open should have failed with an unknown engine")
+	}
+}
+
+func TestOpenFailureDoesNotRecordEngine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A corrupted manifest prevents badger from opening the directory
+	if err := ioutil.WriteFile(filepath.Join(dir, "MANIFEST"), []byte("corrupted"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(dir, BadgerDB); err == nil {
+		t.Fatalf("Open(%s) should have failed on a corrupted database", BadgerDB)
+	}
+
+	if recorded, err := Read(dir); err != nil {
+		t.Fatal(err)
+	} else if recorded != "" {
+		t.Fatalf("Read returned %q after a failed open ; Expected no engine", recorded)
+	}
+}
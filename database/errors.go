@@ -10,4 +10,6 @@ var (
 	ErrClosed          = errors.New("closed")
 	ErrNotFound        = errors.New("not found")
 	ErrAvoidCorruption = errors.New("closed to avoid possible corruption")
+	ErrNoSnapshots     = errors.New("snapshots aren't supported")
+	ErrNoSizes         = errors.New("size estimates aren't supported")
 )
@@ -55,4 +55,33 @@ type Iteratee interface {
 	// a subset of database content with a particular key prefix starting at a
 	// specified key.
 	NewIteratorWithStartAndPrefix(start, prefix []byte) Iterator
+
+	// NewIteratorWithRange creates a binary-alphabetical iterator over the
+	// subset of database content with keys in [start, end). A nil end places
+	// no upper bound on the keys.
+	NewIteratorWithRange(start, end []byte) Iterator
+
+	// NewReverseIteratorWithRange creates a reverse binary-alphabetical
+	// iterator over the subset of database content with keys in [start, end).
+	// A nil end places no upper bound on the keys.
+	NewReverseIteratorWithRange(start, end []byte) Iterator
+
+	// NewReverseIteratorWithPrefix creates a reverse binary-alphabetical
+	// iterator over a subset of database content with a particular key prefix.
+	NewReverseIteratorWithPrefix(prefix []byte) Iterator
+}
+
+// PrefixEnd returns the smallest key that is larger than every key starting
+// with [prefix]. Returns nil if there is no such key, which is interpreted as
+// no upper bound when used as the end of a range.
+func PrefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
 }
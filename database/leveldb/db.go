@@ -7,6 +7,7 @@ import (
 	"bytes"
 
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/comparer"
 	"github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
@@ -66,7 +67,7 @@ func New(file string, blockCacheSize, writeBufferSize, handleCap int) (*Database
 		Filter:      filter.NewBloomFilter(10),
 	})
 	if _, corrupted := err.(*errors.ErrCorrupted); corrupted {
-		db, err = leveldb.RecoverFile(file, nil)
+		db, err = leveldb.RecoverFile(file, recoveryOptions)
 	}
 	if err != nil {
 		return nil, err
@@ -74,6 +75,54 @@ func New(file string, blockCacheSize, writeBufferSize, handleCap int) (*Database
 	return &Database{DB: db}, nil
 }
 
+// NewReadOnly returns a wrapped LevelDB object that fails all writes. Unlike
+// New, corruptions aren't recovered, as that would modify the database.
+func NewReadOnly(file string) (*Database, error) {
+	db, err := leveldb.OpenFile(file, &opt.Options{
+		ReadOnly:       true,
+		ErrorIfMissing: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Database{DB: db}, nil
+}
+
+// Recover rebuilds the LevelDB database in [file] from its tables, dropping
+// any blocks of the tables that are corrupted. Unlike New, which only does so
+// if the database can't be opened, corrupted blocks that would otherwise only
+// be found when they are read are also dropped. The database must not be open.
+func Recover(file string) error {
+	db, err := leveldb.RecoverFile(file, recoveryOptions)
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+// recoveryOptions are the options used to recover a database. When goleveldb
+// rebuilds a table with corrupted blocks, it shortens the keys of the table's
+// index with the user comparer rather than the internal key comparer, which
+// produces index keys that fail to parse. recoveryComparer doesn't shorten
+// keys, so the full internal keys are used instead.
+var recoveryOptions = &opt.Options{
+	Comparer: recoveryComparer{comparer.DefaultComparer},
+}
+
+// recoveryComparer orders keys like the default comparer, under the same name
+// so that the manifest still matches, but never shortens keys
+type recoveryComparer struct{ comparer.Comparer }
+
+func (recoveryComparer) Separator(dst, a, b []byte) []byte { return nil }
+
+func (recoveryComparer) Successor(dst, b []byte) []byte { return nil }
+
+// IsCorrupted returns true if [err] reports that a LevelDB database is
+// corrupted
+func IsCorrupted(err error) bool {
+	return errors.IsCorrupted(err)
+}
+
 // Has returns if the key is set in the database
 func (db *Database) Has(key []byte) (bool, error) {
 	if db.errored {
@@ -140,12 +189,76 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return &iter{db.DB.NewIterator(iterRange, nil)}
 }
 
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database's keys in [start, end)
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return &iter{db.DB.NewIterator(&util.Range{Start: start, Limit: end}, nil)}
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database's keys in [start, end)
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return &reverseIter{iter: iter{db.DB.NewIterator(&util.Range{Start: start, Limit: end}, nil)}}
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the database ignoring keys that do not start with the provided
+// prefix
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &reverseIter{iter: iter{db.DB.NewIterator(util.BytesPrefix(prefix), nil)}}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database.
+// The snapshot is unaffected by subsequent writes to the database.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if db.errored {
+		return nil, database.ErrAvoidCorruption
+	}
+	snap, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, db.handleError(err)
+	}
+	return &snapshot{
+		db:       db,
+		Snapshot: snap,
+	}, nil
+}
+
 // Stat returns a particular internal stat of the database.
 func (db *Database) Stat(property string) (string, error) {
 	stat, err := db.DB.GetProperty(property)
 	return stat, db.handleError(err)
 }
 
+// SizeOf returns the approximate number of bytes of storage used by the keys
+// in [start, limit). Only data that has been flushed from the memtable into
+// tables is included.
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	if limit == nil {
+		// LevelDB treats a nil limit as the empty key, so the limit is
+		// replaced with the key immediately after the last key.
+		it := db.DB.NewIterator(nil, nil)
+		hasLast := it.Last()
+		if hasLast {
+			limit = make([]byte, len(it.Key())+1)
+			copy(limit, it.Key())
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return 0, db.handleError(err)
+		}
+		if !hasLast || bytes.Compare(limit, start) <= 0 {
+			return 0, nil
+		}
+	}
+	sizes, err := db.DB.SizeOf([]util.Range{{Start: start, Limit: limit}})
+	if err != nil {
+		return 0, db.handleError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
 // This comment is basically copy pasted from the underlying levelDB library:
 
 // Compact the underlying DB for the given key range.
@@ -244,6 +357,77 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writer.Delete(key)
 }
 
+// snapshot is a wrapper around a levelDB snapshot.
+type snapshot struct {
+	*leveldb.Snapshot
+	db *Database
+}
+
+// Has returns if the key is set in the snapshot
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.errored {
+		return false, database.ErrAvoidCorruption
+	}
+	has, err := s.Snapshot.Has(key, nil)
+	return has, s.db.handleError(err)
+}
+
+// Get returns the value the key maps to in the snapshot
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.errored {
+		return nil, database.ErrAvoidCorruption
+	}
+	value, err := s.Snapshot.Get(key, nil)
+	return value, s.db.handleError(err)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return &iter{s.Snapshot.NewIterator(new(util.Range), nil)}
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iter{s.Snapshot.NewIterator(&util.Range{Start: start}, nil)}
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{s.Snapshot.NewIterator(util.BytesPrefix(prefix), nil)}
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return &iter{s.Snapshot.NewIterator(iterRange, nil)}
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot's keys in [start, end)
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return &iter{s.Snapshot.NewIterator(&util.Range{Start: start, Limit: end}, nil)}
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot's keys in [start, end)
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return &reverseIter{iter: iter{s.Snapshot.NewIterator(&util.Range{Start: start, Limit: end}, nil)}}
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the snapshot ignoring keys that do not start with the provided
+// prefix
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &reverseIter{iter: iter{s.Snapshot.NewIterator(util.BytesPrefix(prefix), nil)}}
+}
+
 type iter struct{ iterator.Iterator }
 
 // Error implements the Iterator interface
@@ -255,9 +439,24 @@ func (it *iter) Key() []byte { return utils.CopyBytes(it.Iterator.Key()) }
 // Value implements the Iterator interface
 func (it *iter) Value() []byte { return utils.CopyBytes(it.Iterator.Value()) }
 
+// reverseIter iterates from the last key to the first key
+type reverseIter struct {
+	iter
+	initialized bool
+}
+
+// Next implements the Iterator interface
+func (it *reverseIter) Next() bool {
+	if !it.initialized {
+		it.initialized = true
+		return it.Iterator.Last()
+	}
+	return it.Iterator.Prev()
+}
+
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
@@ -5,7 +5,9 @@ package leveldb
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -25,3 +27,99 @@ func TestInterface(t *testing.T) {
 		test(t, db)
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	for i, test := range database.SnapshotTests {
+		folder := fmt.Sprintf("snapshotdb%d", i)
+
+		db, err := New(folder, 0, 0, 0)
+		if err != nil {
+			t.Fatalf("leveldb.New(%s, 0, 0) errored with %s", folder, err)
+		}
+		defer os.RemoveAll(folder)
+		defer db.Close()
+
+		test(t, db)
+	}
+}
+
+func TestSizerInterface(t *testing.T) {
+	for i, test := range database.SizerTests {
+		folder := fmt.Sprintf("sizerdb%d", i)
+
+		db, err := New(folder, 0, 0, 0)
+		if err != nil {
+			t.Fatalf("leveldb.New(%s, 0, 0) errored with %s", folder, err)
+		}
+		defer os.RemoveAll(folder)
+		defer db.Close()
+
+		test(t, db)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	folder := "recoverdb"
+	defer os.RemoveAll(folder)
+
+	db, err := New(folder, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := []byte("prefix")
+	value := make([]byte, 1024)
+	for i := 0; i < 1024; i++ {
+		if err := db.Put(append(prefix, byte(i>>8), byte(i)), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Compact(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the first block of the table
+	tables, err := filepath.Glob(filepath.Join(folder, "*.ldb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table but found %d", len(tables))
+	}
+	tableBytes, err := ioutil.ReadFile(tables[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 100; i < 200; i++ {
+		tableBytes[i] ^= 0xff
+	}
+	if err := ioutil.WriteFile(tables[0], tableBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Recover(folder); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err = New(folder, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// The index of the rebuilt table must support seeking to a range
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+	numKeys := 0
+	for it.Next() {
+		numKeys++
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if numKeys == 0 || numKeys >= 1024 {
+		t.Fatalf("expected the corrupted block to be dropped but read %d keys", numKeys)
+	}
+}
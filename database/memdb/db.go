@@ -114,6 +114,28 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 
 // NewIteratorWithStartAndPrefix implements the Database interface
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(start, nil, prefix, false)
+}
+
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, false)
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(nil, nil, prefix, true)
+}
+
+// newIterator returns an iterator over the keys in [start, end) that start
+// with [prefix]. A nil [end] places no upper bound on the keys. If [reverse] is
+// true, the keys are iterated over in descending order.
+func (db *Database) newIterator(start, end, prefix []byte, reverse bool) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
@@ -122,14 +144,21 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 
 	startString := string(start)
+	endString := string(end)
 	prefixString := string(prefix)
 	keys := make([]string, 0, len(db.db))
 	for key := range db.db {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
+		if strings.HasPrefix(key, prefixString) && key >= startString &&
+			(end == nil || key < endString) {
 			keys = append(keys, key)
 		}
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
+	// Keys need to be in sorted order
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
 	values := make([][]byte, 0, len(keys))
 	for _, key := range keys {
 		values = append(values, db.db[key])
@@ -140,6 +169,44 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot implements the Snapshotter interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+
+	// Values are never modified in place, so they can be shared with the
+	// snapshot
+	copied := make(map[string][]byte, len(db.db))
+	for key, value := range db.db {
+		copied[key] = value
+	}
+	return &snapshot{Database: &Database{db: copied}}, nil
+}
+
+// SizeOf implements the Sizer interface. The size of the keys in
+// [start, limit) is exactly the sum of their key and value lengths.
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	startString := string(start)
+	limitString := string(limit)
+	size := uint64(0)
+	for key, value := range db.db {
+		if key >= startString && (limit == nil || key < limitString) {
+			size += uint64(len(key) + len(value))
+		}
+	}
+	return size, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(property string) (string, error) { return "", database.ErrNotFound }
 
@@ -154,6 +221,12 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return nil
 }
 
+// snapshot is a read-only copy of the database
+type snapshot struct{ *Database }
+
+// Release implements the Snapshot interface
+func (s *snapshot) Release() { _ = s.Close() }
+
 type keyValue struct {
 	key    []byte
 	value  []byte
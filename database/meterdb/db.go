@@ -105,6 +105,78 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewIteratorWithRange(start, end),
+		db:       db,
+	}
+	endTime := db.clock.Time()
+	db.newIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewReverseIteratorWithRange(start, end),
+		db:       db,
+	}
+	endTime := db.clock.Time()
+	db.newIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	start := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewReverseIteratorWithPrefix(prefix),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(start)))
+	return it
+}
+
+// NewSnapshot implements the Snapshotter interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	var (
+		snap database.Snapshot
+		err  = database.ErrNoSnapshots
+	)
+	if snapshotter, ok := db.db.(database.Snapshotter); ok {
+		snap, err = snapshotter.NewSnapshot()
+	}
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		snapshot: snap,
+		db:       db,
+	}, nil
+}
+
+// SizeOf implements the Sizer interface
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	startTime := db.clock.Time()
+	var (
+		size uint64
+		err  = database.ErrNoSizes
+	)
+	if sizer, ok := db.db.(database.Sizer); ok {
+		size, err = sizer.SizeOf(start, limit)
+	}
+	end := db.clock.Time()
+	db.sizeOf.Observe(float64(end.Sub(startTime)))
+	return size, err
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	start := db.clock.Time()
@@ -192,6 +264,93 @@ func (b *batch) Inner() database.Batch {
 	return inner
 }
 
+type snapshot struct {
+	snapshot database.Snapshot
+	db       *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	start := s.db.clock.Time()
+	has, err := s.snapshot.Has(key)
+	end := s.db.clock.Time()
+	s.db.sHas.Observe(float64(end.Sub(start)))
+	return has, err
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	start := s.db.clock.Time()
+	value, err := s.snapshot.Get(key)
+	end := s.db.clock.Time()
+	s.db.sGet.Observe(float64(end.Sub(start)))
+	return value, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithRange(start, end),
+		db:       s.db,
+	}
+	endTime := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewReverseIteratorWithRange(start, end),
+		db:       s.db,
+	}
+	endTime := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	start := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewReverseIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(start)))
+	return it
+}
+
+func (s *snapshot) Release() {
+	start := s.db.clock.Time()
+	s.snapshot.Release()
+	end := s.db.clock.Time()
+	s.db.sRelease.Observe(float64(end.Sub(start)))
+}
+
 type iterator struct {
 	iterator database.Iterator
 	db       *Database
@@ -23,3 +23,27 @@ func TestInterface(t *testing.T) {
 		test(t, db)
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		db, err := New("", prometheus.NewRegistry(), baseDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestSizerInterface(t *testing.T) {
+	for _, test := range database.SizerTests {
+		baseDB := memdb.New()
+		db, err := New("", prometheus.NewRegistry(), baseDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
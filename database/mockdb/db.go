@@ -30,6 +30,9 @@ type Database struct {
 	OnNewIteratorWithStart          func([]byte) database.Iterator
 	OnNewIteratorWithPrefix         func([]byte) database.Iterator
 	OnNewIteratorWithStartAndPrefix func([]byte, []byte) database.Iterator
+	OnNewIteratorWithRange          func([]byte, []byte) database.Iterator
+	OnNewReverseIteratorWithRange   func([]byte, []byte) database.Iterator
+	OnNewReverseIteratorWithPrefix  func([]byte) database.Iterator
 	OnStat                          func(string) (string, error)
 	OnCompact                       func([]byte, []byte) error
 	OnClose                         func() error
@@ -107,6 +110,30 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return db.OnNewIteratorWithStartAndPrefix(start, prefix)
 }
 
+// NewIteratorWithRange implements the database.Database interface
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	if db.OnNewIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewIteratorWithRange(start, end)
+}
+
+// NewReverseIteratorWithRange implements the database.Database interface
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithRange(start, end)
+}
+
+// NewReverseIteratorWithPrefix implements the database.Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithPrefix == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithPrefix(prefix)
+}
+
 // Stat implements the database.Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	if db.OnStat == nil {
@@ -165,6 +165,129 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// NewIteratorWithRange implements the Database interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(start, end, false)
+}
+
+// NewReverseIteratorWithRange implements the Database interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(start, end, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface.
+// It is safe to modify [prefix] after this method returns.
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newReversePrefixIterator(db.db, prefix)
+}
+
+func (db *Database) newRangeIterator(start, end []byte, reverse bool) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newRangeIteratorOver(db.db, start, end, reverse)
+}
+
+// newRangeIteratorOver returns an iterator over the keys of [source] in
+// [start, end) that are in this db's keyspace. A nil [end] is bounded by the
+// end of this db's keyspace.
+func (db *Database) newRangeIteratorOver(source database.Iteratee, start, end []byte, reverse bool) database.Iterator {
+	prefixedStart := db.prefix(start)
+	var prefixedEnd []byte
+	if end == nil {
+		prefixedEnd = database.PrefixEnd(db.dbPrefix)
+	} else {
+		prefixedEnd = db.prefix(end)
+	}
+
+	var it database.Iterator
+	if reverse {
+		it = source.NewReverseIteratorWithRange(prefixedStart, prefixedEnd)
+	} else {
+		it = source.NewIteratorWithRange(prefixedStart, prefixedEnd)
+	}
+	db.bufferPool.Put(prefixedStart)
+	db.bufferPool.Put(prefixedEnd)
+	return &iterator{
+		Iterator: it,
+		db:       db,
+	}
+}
+
+// newReversePrefixIterator returns a reverse iterator over the keys of
+// [source] in this db's keyspace that start with [prefix].
+func (db *Database) newReversePrefixIterator(source database.Iteratee, prefix []byte) database.Iterator {
+	prefixedPrefix := db.prefix(prefix)
+	it := &iterator{
+		Iterator: source.NewReverseIteratorWithPrefix(prefixedPrefix),
+		db:       db,
+	}
+	db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+// NewSnapshot implements the Snapshotter interface.
+// Returns ErrNoSnapshots if the underlying database doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshotter, ok := db.db.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrNoSnapshots
+	}
+	snap, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: snap,
+		db:       db,
+	}, nil
+}
+
+// SizeOf implements the Sizer interface. A nil [limit] is bounded by the end
+// of this db's keyspace.
+// Returns ErrNoSizes if the underlying database doesn't support size estimates.
+func (db *Database) SizeOf(start, limit []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return 0, database.ErrClosed
+	}
+	sizer, ok := db.db.(database.Sizer)
+	if !ok {
+		return 0, database.ErrNoSizes
+	}
+
+	prefixedStart := db.prefix(start)
+	var prefixedLimit []byte
+	if limit == nil {
+		prefixedLimit = database.PrefixEnd(db.dbPrefix)
+	} else {
+		prefixedLimit = db.prefix(limit)
+	}
+	size, err := sizer.SizeOf(prefixedStart, prefixedLimit)
+	db.bufferPool.Put(prefixedStart)
+	db.bufferPool.Put(prefixedLimit)
+	return size, err
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -309,6 +432,77 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+// snapshot of a prefixed database
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has implements the Snapshot interface
+// [key] may be modified after this method returns.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// Get implements the Snapshot interface
+// [key] may be modified after this method returns.
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface.
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+// NewIteratorWithRange implements the Snapshot interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return s.db.newRangeIteratorOver(s.Snapshot, start, end, false)
+}
+
+// NewReverseIteratorWithRange implements the Snapshot interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return s.db.newRangeIteratorOver(s.Snapshot, start, end, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Snapshot interface.
+// It is safe to modify [prefix] after this method returns.
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newReversePrefixIterator(s.Snapshot, prefix)
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -21,3 +21,23 @@ func TestInterface(t *testing.T) {
 		test(t, NewNested([]byte("ld"), New([]byte("wor"), db)))
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		db := memdb.New()
+		test(t, New([]byte("hello"), db))
+		test(t, New([]byte("world"), db))
+		test(t, New([]byte("wor"), New([]byte("ld"), db)))
+		test(t, NewNested([]byte("ld"), New([]byte("wor"), db)))
+	}
+}
+
+func TestSizerInterface(t *testing.T) {
+	for _, test := range database.SizerTests {
+		db := memdb.New()
+		test(t, New([]byte("hello"), db))
+		test(t, New([]byte("world"), db))
+		test(t, New([]byte("wor"), New([]byte("ld"), db)))
+		test(t, NewNested([]byte("ld"), New([]byte("wor"), db)))
+	}
+}
@@ -0,0 +1,139 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcdb
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/rpcdb/rpcdbproto"
+)
+
+const (
+	benchmarkNumKeys   = 1024
+	benchmarkValueSize = 256
+)
+
+// newBenchmarkDB returns a memdb populated with [benchmarkNumKeys] keys and
+// the keys
+func newBenchmarkDB(b *testing.B) (database.Database, [][]byte) {
+	db := memdb.New()
+	keys := make([][]byte, benchmarkNumKeys)
+	value := make([]byte, benchmarkValueSize)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("%08d", i))
+		if err := db.Put(keys[i], value); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return db, keys
+}
+
+// BenchmarkIteratorNext iterates with a round-trip for every element
+func BenchmarkIteratorNext(b *testing.B) {
+	baseDB, _ := newBenchmarkDB(b)
+	db, conn := newClient(b, baseDB, DefaultIteratorPrefetch)
+	defer conn.Close()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		resp, err := db.client.NewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.NewIteratorWithStartAndPrefixRequest{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		for {
+			next, err := db.client.IteratorNext(context.Background(), &rpcdbproto.IteratorNextRequest{
+				Id: resp.Id,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if !next.FoundNext {
+				break
+			}
+		}
+		if _, err := db.client.IteratorRelease(context.Background(), &rpcdbproto.IteratorReleaseRequest{
+			Id: resp.Id,
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkIteratorStream iterates over elements streamed in pages
+func BenchmarkIteratorStream(b *testing.B) {
+	for _, prefetch := range []int{1, 16, DefaultIteratorPrefetch} {
+		b.Run(fmt.Sprintf("prefetch=%d", prefetch), func(b *testing.B) {
+			baseDB, _ := newBenchmarkDB(b)
+			db, conn := newClient(b, baseDB, prefetch)
+			defer conn.Close()
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				it := db.NewIterator()
+				for it.Next() {
+				}
+				if err := it.Error(); err != nil {
+					b.Fatal(err)
+				}
+				it.Release()
+			}
+		})
+	}
+}
+
+// BenchmarkGet reads every key with a round-trip for each key
+func BenchmarkGet(b *testing.B) {
+	baseDB, keys := newBenchmarkDB(b)
+	db, conn := newClient(b, baseDB, DefaultIteratorPrefetch)
+	defer conn.Close()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, key := range keys {
+			if _, err := db.Get(key); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkGetMany reads every key in a single round-trip
+func BenchmarkGetMany(b *testing.B) {
+	baseDB, keys := newBenchmarkDB(b)
+	db, conn := newClient(b, baseDB, DefaultIteratorPrefetch)
+	defer conn.Close()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := db.GetMany(keys); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBatchWrite writes a batch that is sent in multiple chunks
+func BenchmarkBatchWrite(b *testing.B) {
+	db, conn := newClient(b, memdb.New(), DefaultIteratorPrefetch)
+	defer conn.Close()
+
+	value := make([]byte, 4096)
+	batch := db.NewBatch()
+	for i := 0; i < benchmarkNumKeys; i++ {
+		if err := batch.Put([]byte(fmt.Sprintf("%08d", i)), value); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := batch.Write(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package rpcdb
 
 import (
 	"fmt"
+	"io"
 
 	"golang.org/x/net/context"
 
@@ -15,39 +16,82 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+const (
+	// DefaultIteratorPrefetch is the default number of elements that the
+	// server sends in each page of an iterator
+	DefaultIteratorPrefetch = 1024
+
+	// maxBatchChunkBytes is the approximate maximum number of bytes of keys
+	// and values sent in each message when writing a batch
+	maxBatchChunkBytes = 1 << 20
+)
+
 var (
 	errClosed   = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrClosed)
 	errNotFound = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrNotFound)
+
+	errNoSnapshots = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrNoSnapshots)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
-type DatabaseClient struct{ client rpcdbproto.DatabaseClient }
+type DatabaseClient struct {
+	client rpcdbproto.DatabaseClient
+	// Number of elements that the server sends in each page of an iterator
+	iteratorPrefetch int
+}
 
-// NewClient returns a database instance connected to a remote database instance
-func NewClient(client rpcdbproto.DatabaseClient) *DatabaseClient {
-	return &DatabaseClient{client: client}
+// NewClient returns a database instance connected to a remote database
+// instance. Iterators receive up to [iteratorPrefetch] elements from the
+// server at a time.
+func NewClient(client rpcdbproto.DatabaseClient, iteratorPrefetch int) *DatabaseClient {
+	if iteratorPrefetch <= 0 {
+		iteratorPrefetch = 1
+	}
+	return &DatabaseClient{
+		client:           client,
+		iteratorPrefetch: iteratorPrefetch,
+	}
 }
 
 // Has attempts to return if the database has a key with the provided value.
-func (db *DatabaseClient) Has(key []byte) (bool, error) {
-	resp, err := db.client.Has(context.Background(), &rpcdbproto.HasRequest{
-		Key: key,
+func (db *DatabaseClient) Has(key []byte) (bool, error) { return db.has(0, key) }
+
+// Get attempts to return the value that was mapped to the key that was provided
+func (db *DatabaseClient) Get(key []byte) ([]byte, error) { return db.get(0, key) }
+
+// GetMany returns the values that [keys] map to in a single round-trip. The
+// value of a key that isn't in the database is nil.
+func (db *DatabaseClient) GetMany(keys [][]byte) ([][]byte, error) {
+	resp, err := db.client.GetMany(context.Background(), &rpcdbproto.GetManyRequest{
+		Keys: keys,
 	})
 	if err != nil {
-		return false, updateError(err)
+		return nil, updateError(err)
 	}
-	return resp.Has, nil
+	values := make([][]byte, len(keys))
+	for i, found := range resp.Found {
+		if !found {
+			continue
+		}
+		value := resp.Values[i]
+		if value == nil {
+			value = []byte{}
+		}
+		values[i] = value
+	}
+	return values, nil
 }
 
-// Get attempts to return the value that was mapped to the key that was provided
-func (db *DatabaseClient) Get(key []byte) ([]byte, error) {
-	resp, err := db.client.Get(context.Background(), &rpcdbproto.GetRequest{
-		Key: key,
+// HasMany returns whether the database has each of [keys] in a single
+// round-trip
+func (db *DatabaseClient) HasMany(keys [][]byte) ([]bool, error) {
+	resp, err := db.client.HasMany(context.Background(), &rpcdbproto.HasManyRequest{
+		Keys: keys,
 	})
 	if err != nil {
 		return nil, updateError(err)
 	}
-	return resp.Value, nil
+	return resp.Has, nil
 }
 
 // Put attempts to set the value this key maps to
@@ -87,17 +131,34 @@ func (db *DatabaseClient) NewIteratorWithPrefix(prefix []byte) database.Iterator
 
 // NewIteratorWithStartAndPrefix returns a new empty iterator
 func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	resp, err := db.client.NewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.NewIteratorWithStartAndPrefixRequest{
-		Start:  start,
-		Prefix: prefix,
-	})
+	return db.newIterator(0, start, prefix)
+}
+
+// NewIteratorWithRange implements the Database interface
+func (db *DatabaseClient) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(0, start, end, false)
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *DatabaseClient) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return db.newRangeIterator(0, start, end, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *DatabaseClient) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newRangeIterator(0, prefix, database.PrefixEnd(prefix), true)
+}
+
+// NewSnapshot attempts to create a snapshot of the database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbproto.NewSnapshotRequest{})
 	if err != nil {
-		return &nodb.Iterator{Err: updateError(err)}
+		return nil, updateError(err)
 	}
-	return &iterator{
+	return &snapshot{
 		db: db,
 		id: resp.Id,
-	}
+	}, nil
 }
 
 // Stat attempts to return the statistic of this database
@@ -126,6 +187,107 @@ func (db *DatabaseClient) Close() error {
 	return updateError(err)
 }
 
+func (db *DatabaseClient) has(snapshotID uint64, key []byte) (bool, error) {
+	resp, err := db.client.Has(context.Background(), &rpcdbproto.HasRequest{
+		Key:        key,
+		SnapshotId: snapshotID,
+	})
+	if err != nil {
+		return false, updateError(err)
+	}
+	return resp.Has, nil
+}
+
+func (db *DatabaseClient) get(snapshotID uint64, key []byte) ([]byte, error) {
+	resp, err := db.client.Get(context.Background(), &rpcdbproto.GetRequest{
+		Key:        key,
+		SnapshotId: snapshotID,
+	})
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return resp.Value, nil
+}
+
+func (db *DatabaseClient) newIterator(snapshotID uint64, start, prefix []byte) database.Iterator {
+	resp, err := db.client.NewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.NewIteratorWithStartAndPrefixRequest{
+		Start:      start,
+		Prefix:     prefix,
+		SnapshotId: snapshotID,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: updateError(err)}
+	}
+	return db.newStreamIterator(resp.Id)
+}
+
+func (db *DatabaseClient) newRangeIterator(snapshotID uint64, start, end []byte, reverse bool) database.Iterator {
+	resp, err := db.client.NewIteratorWithRange(context.Background(), &rpcdbproto.NewIteratorWithRangeRequest{
+		Start:      start,
+		End:        end,
+		Reverse:    reverse,
+		SnapshotId: snapshotID,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: updateError(err)}
+	}
+	return db.newStreamIterator(resp.Id)
+}
+
+func (db *DatabaseClient) newStreamIterator(id uint64) database.Iterator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &iterator{
+		db:     db,
+		id:     id,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+type snapshot struct {
+	db *DatabaseClient
+	id uint64
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) { return s.db.has(s.id, key) }
+
+func (s *snapshot) Get(key []byte) ([]byte, error) { return s.db.get(s.id, key) }
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.db.newIterator(s.id, nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.db.newIterator(s.id, start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newIterator(s.id, nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.db.newIterator(s.id, start, prefix)
+}
+
+func (s *snapshot) NewIteratorWithRange(start, end []byte) database.Iterator {
+	return s.db.newRangeIterator(s.id, start, end, false)
+}
+
+func (s *snapshot) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	return s.db.newRangeIterator(s.id, start, end, true)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newRangeIterator(s.id, prefix, database.PrefixEnd(prefix), true)
+}
+
+// Release frees any resources held by the snapshot
+func (s *snapshot) Release() {
+	_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbproto.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -152,8 +314,12 @@ func (b *batch) Delete(key []byte) error {
 
 func (b *batch) ValueSize() int { return b.size }
 
+// Write sends the batch to the server in a single message if it's small
+// enough, or otherwise streams it in chunks that the server writes atomically
+// once the stream is closed
 func (b *batch) Write() error {
-	request := &rpcdbproto.WriteBatchRequest{}
+	chunks := []*rpcdbproto.WriteBatchRequest{{}}
+	chunkSize := 0
 
 	keySet := make(map[string]struct{}, len(b.writes))
 	for i := len(b.writes) - 1; i >= 0; i-- {
@@ -164,19 +330,48 @@ func (b *batch) Write() error {
 		}
 		keySet[key] = struct{}{}
 
+		size := len(kv.key) + len(kv.value)
+		if chunkSize > 0 && chunkSize+size > maxBatchChunkBytes {
+			chunks = append(chunks, &rpcdbproto.WriteBatchRequest{})
+			chunkSize = 0
+		}
+		chunkSize += size
+
+		chunk := chunks[len(chunks)-1]
 		if kv.delete {
-			request.Deletes = append(request.Deletes, &rpcdbproto.DeleteRequest{
+			chunk.Deletes = append(chunk.Deletes, &rpcdbproto.DeleteRequest{
 				Key: kv.key,
 			})
 		} else {
-			request.Puts = append(request.Puts, &rpcdbproto.PutRequest{
+			chunk.Puts = append(chunk.Puts, &rpcdbproto.PutRequest{
 				Key:   kv.key,
 				Value: kv.value,
 			})
 		}
 	}
 
-	_, err := b.db.client.WriteBatch(context.Background(), request)
+	if len(chunks) == 1 {
+		_, err := b.db.client.WriteBatch(context.Background(), chunks[0])
+		return updateError(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := b.db.client.WriteBatchStream(ctx)
+	if err != nil {
+		return updateError(err)
+	}
+	for _, chunk := range chunks {
+		if err := stream.Send(chunk); err != nil {
+			// The server's error is reported when the stream is closed
+			if err == io.EOF {
+				break
+			}
+			return updateError(err)
+		}
+	}
+	_, err = stream.CloseAndRecv()
 	return updateError(err)
 }
 
@@ -204,28 +399,67 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 
 func (b *batch) Inner() database.Batch { return b }
 
+// iterator receives the elements of an iterator on the server, which are
+// streamed in pages
 type iterator struct {
-	db    *DatabaseClient
-	id    uint64
-	key   []byte
-	value []byte
-	errs  wrappers.Errs
+	db     *DatabaseClient
+	id     uint64
+	ctx    context.Context
+	cancel context.CancelFunc
+	// Opened on the first call to Next
+	stream rpcdbproto.Database_IteratorStreamClient
+	// The remainder of the last page received from the server
+	page      []*rpcdbproto.PutRequest
+	exhausted bool
+	key       []byte
+	value     []byte
+	errs      wrappers.Errs
 }
 
 // Next attempts to move the iterator to the next element and returns if this
 // succeeded
 func (it *iterator) Next() bool {
-	resp, err := it.db.client.IteratorNext(context.Background(), &rpcdbproto.IteratorNextRequest{
-		Id: it.id,
-	})
-	it.errs.Add(updateError(err))
+	for len(it.page) == 0 {
+		if it.exhausted {
+			it.key = nil
+			it.value = nil
+			return false
+		}
+		if err := it.receive(); err != nil {
+			it.errs.Add(updateError(err))
+			it.exhausted = true
+		}
+	}
+
+	it.key = it.page[0].Key
+	it.value = it.page[0].Value
+	it.page = it.page[1:]
+	return true
+}
+
+// receive the next page of elements from the server
+func (it *iterator) receive() error {
+	if it.stream == nil {
+		stream, err := it.db.client.IteratorStream(it.ctx, &rpcdbproto.IteratorStreamRequest{
+			Id:       it.id,
+			PageSize: uint32(it.db.iteratorPrefetch),
+		})
+		if err != nil {
+			return err
+		}
+		it.stream = stream
+	}
 
+	resp, err := it.stream.Recv()
+	if err == io.EOF {
+		it.exhausted = true
+		return nil
+	}
 	if err != nil {
-		return false
+		return err
 	}
-	it.key = resp.Key
-	it.value = resp.Value
-	return resp.FoundNext
+	it.page = resp.Data
+	return nil
 }
 
 // Error returns any that occurred while iterating
@@ -249,6 +483,9 @@ func (it *iterator) Value() []byte { return it.value }
 
 // Release frees any resources held by the iterator
 func (it *iterator) Release() {
+	it.cancel()
+	it.page = nil
+	it.exhausted = true
 	_, err := it.db.client.IteratorRelease(context.Background(), &rpcdbproto.IteratorReleaseRequest{
 		Id: it.id,
 	})
@@ -267,6 +504,8 @@ func updateError(err error) error {
 		return database.ErrClosed
 	case errNotFound:
 		return database.ErrNotFound
+	case errNoSnapshots:
+		return database.ErrNoSnapshots
 	default:
 		return err
 	}
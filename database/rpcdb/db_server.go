@@ -5,16 +5,26 @@ package rpcdb
 
 import (
 	"errors"
+	"io"
 	"sync"
 
 	"golang.org/x/net/context"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/rpcdb/rpcdbproto"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	// maxPageBytes is the approximate maximum number of bytes of keys and
+	// values sent in a page of an iterator stream, regardless of the requested
+	// page size
+	maxPageBytes = 1 << 20
 )
 
 var (
 	errUnknownIterator = errors.New("unknown iterator")
+	errZeroPageSize    = errors.New("page size must be positive")
 )
 
 // DatabaseServer is a database that is managed over RPC.
@@ -25,6 +35,16 @@ type DatabaseServer struct {
 
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	// Snapshot IDs start at 1. A snapshot ID of 0 refers to the database.
+	lastSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
+}
+
+// reader is the read-only view of either the database or a snapshot of it
+type reader interface {
+	database.KeyValueReader
+	database.Iteratee
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -33,12 +53,17 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batch:     db.NewBatch(),
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
 // Has delegates the Has call to the managed database and returns the result
 func (db *DatabaseServer) Has(_ context.Context, req *rpcdbproto.HasRequest) (*rpcdbproto.HasResponse, error) {
-	has, err := db.db.Has(req.Key)
+	r, err := db.reader(req.SnapshotId)
+	if err != nil {
+		return nil, err
+	}
+	has, err := r.Has(req.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -47,13 +72,54 @@ func (db *DatabaseServer) Has(_ context.Context, req *rpcdbproto.HasRequest) (*r
 
 // Get delegates the Get call to the managed database and returns the result
 func (db *DatabaseServer) Get(_ context.Context, req *rpcdbproto.GetRequest) (*rpcdbproto.GetResponse, error) {
-	value, err := db.db.Get(req.Key)
+	r, err := db.reader(req.SnapshotId)
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.Get(req.Key)
 	if err != nil {
 		return nil, err
 	}
 	return &rpcdbproto.GetResponse{Value: value}, nil
 }
 
+// GetMany delegates a Get call for each of the requested keys to the managed
+// database and returns the results
+func (db *DatabaseServer) GetMany(_ context.Context, req *rpcdbproto.GetManyRequest) (*rpcdbproto.GetManyResponse, error) {
+	resp := &rpcdbproto.GetManyResponse{
+		Values: make([][]byte, len(req.Keys)),
+		Found:  make([]bool, len(req.Keys)),
+	}
+	for i, key := range req.Keys {
+		value, err := db.db.Get(key)
+		switch err {
+		case nil:
+			resp.Values[i] = value
+			resp.Found[i] = true
+		case database.ErrNotFound:
+		default:
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// HasMany delegates a Has call for each of the requested keys to the managed
+// database and returns the results
+func (db *DatabaseServer) HasMany(_ context.Context, req *rpcdbproto.HasManyRequest) (*rpcdbproto.HasManyResponse, error) {
+	resp := &rpcdbproto.HasManyResponse{
+		Has: make([]bool, len(req.Keys)),
+	}
+	for i, key := range req.Keys {
+		has, err := db.db.Has(key)
+		if err != nil {
+			return nil, err
+		}
+		resp.Has[i] = has
+	}
+	return resp, nil
+}
+
 // Put delegates the Put call to the managed database and returns the result
 func (db *DatabaseServer) Put(_ context.Context, req *rpcdbproto.PutRequest) (*rpcdbproto.PutResponse, error) {
 	return &rpcdbproto.PutResponse{}, db.db.Put(req.Key, req.Value)
@@ -108,20 +174,82 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbproto.WriteBat
 	return &rpcdbproto.WriteBatchResponse{}, db.batch.Write()
 }
 
+// WriteBatchStream receives a batch of key-value pairs in chunks and, once the
+// stream is closed by the client, atomically writes them to the internal
+// database
+func (db *DatabaseServer) WriteBatchStream(stream rpcdbproto.Database_WriteBatchStreamServer) error {
+	batch := db.db.NewBatch()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, put := range req.Puts {
+			if err := batch.Put(put.Key, put.Value); err != nil {
+				return err
+			}
+		}
+
+		for _, del := range req.Deletes {
+			if err := batch.Delete(del.Key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&rpcdbproto.WriteBatchResponse{})
+}
+
 // NewIteratorWithStartAndPrefix allocates an iterator and returns the iterator
 // ID
 func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbproto.NewIteratorWithStartAndPrefixRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	r, err := db.reader(req.SnapshotId)
+	if err != nil {
+		return nil, err
+	}
+
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
 	id := db.nextIteratorID
-	it := db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	it := r.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
 	db.iterators[id] = it
 
 	db.nextIteratorID++
 	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
 }
 
+// NewIteratorWithRange allocates an iterator over a range of keys and returns
+// the iterator ID
+func (db *DatabaseServer) NewIteratorWithRange(_ context.Context, req *rpcdbproto.NewIteratorWithRangeRequest) (*rpcdbproto.NewIteratorWithRangeResponse, error) {
+	r, err := db.reader(req.SnapshotId)
+	if err != nil {
+		return nil, err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	id := db.nextIteratorID
+	var it database.Iterator
+	if req.Reverse {
+		it = r.NewReverseIteratorWithRange(req.Start, req.End)
+	} else {
+		it = r.NewIteratorWithRange(req.Start, req.End)
+	}
+	db.iterators[id] = it
+
+	db.nextIteratorID++
+	return &rpcdbproto.NewIteratorWithRangeResponse{Id: id}, nil
+}
+
 // IteratorNext attempts to call next on the requested iterator
 func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbproto.IteratorNextRequest) (*rpcdbproto.IteratorNextResponse, error) {
 	db.lock.Lock()
@@ -138,6 +266,60 @@ func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbproto.Iterat
 	}, nil
 }
 
+// IteratorStream sends the remaining elements of the requested iterator in
+// pages of up to [req.PageSize] elements. The stream is closed once the
+// iterator is exhausted. Pages are read ahead of the client, bounded by the
+// flow control of the stream.
+func (db *DatabaseServer) IteratorStream(req *rpcdbproto.IteratorStreamRequest, stream rpcdbproto.Database_IteratorStreamServer) error {
+	if req.PageSize == 0 {
+		return errZeroPageSize
+	}
+	for {
+		page, exhausted, err := db.iteratorPage(req.Id, int(req.PageSize))
+		if err != nil {
+			return err
+		}
+		if len(page) > 0 {
+			if err := stream.Send(&rpcdbproto.IteratorStreamResponse{Data: page}); err != nil {
+				return err
+			}
+		}
+		if exhausted {
+			return nil
+		}
+	}
+}
+
+// iteratorPage returns up to [pageSize] of the next elements of the iterator
+// with ID [id], and whether the iterator is exhausted
+func (db *DatabaseServer) iteratorPage(id uint64, pageSize int) ([]*rpcdbproto.PutRequest, bool, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	it, exists := db.iterators[id]
+	if !exists {
+		return nil, false, errUnknownIterator
+	}
+
+	page := []*rpcdbproto.PutRequest(nil)
+	size := 0
+	for len(page) < pageSize && size < maxPageBytes {
+		if !it.Next() {
+			return page, true, nil
+		}
+		// The iterator may reuse the memory of its key and value once Next is
+		// called
+		key := utils.CopyBytes(it.Key())
+		value := utils.CopyBytes(it.Value())
+		page = append(page, &rpcdbproto.PutRequest{
+			Key:   key,
+			Value: value,
+		})
+		size += len(key) + len(value)
+	}
+	return page, false, nil
+}
+
 // IteratorError attempts to report any errors that occurred during iteration
 func (db *DatabaseServer) IteratorError(_ context.Context, req *rpcdbproto.IteratorErrorRequest) (*rpcdbproto.IteratorErrorResponse, error) {
 	db.lock.Lock()
@@ -162,3 +344,54 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbproto.Ite
 	}
 	return &rpcdbproto.IteratorReleaseResponse{}, nil
 }
+
+// NewSnapshot allocates a snapshot of the managed database and returns the
+// snapshot ID
+func (db *DatabaseServer) NewSnapshot(context.Context, *rpcdbproto.NewSnapshotRequest) (*rpcdbproto.NewSnapshotResponse, error) {
+	snapshotter, ok := db.db.(database.Snapshotter)
+	if !ok {
+		return nil, database.ErrNoSnapshots
+	}
+	snap, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.lastSnapshotID++
+	id := db.lastSnapshotID
+	db.snapshots[id] = snap
+	return &rpcdbproto.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotRelease attempts to release the resources allocated to a snapshot
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbproto.SnapshotReleaseRequest) (*rpcdbproto.SnapshotReleaseResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snap, exists := db.snapshots[req.Id]
+	if exists {
+		delete(db.snapshots, req.Id)
+		snap.Release()
+	}
+	return &rpcdbproto.SnapshotReleaseResponse{}, nil
+}
+
+// reader returns the snapshot with ID [snapshotID], or the managed database if
+// [snapshotID] is 0. Snapshots that have been released are reported as closed.
+func (db *DatabaseServer) reader(snapshotID uint64) (reader, error) {
+	if snapshotID == 0 {
+		return db.db, nil
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snap, exists := db.snapshots[snapshotID]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+	return snap, nil
+}
@@ -4,6 +4,8 @@
 package rpcdb
 
 import (
+	"bytes"
+	"fmt"
 	"log"
 	"net"
 	"testing"
@@ -24,28 +26,160 @@ const (
 
 func TestInterface(t *testing.T) {
 	for _, test := range database.Tests {
-		listener := bufconn.Listen(bufSize)
-		server := grpc.NewServer()
-		rpcdbproto.RegisterDatabaseServer(server, NewServer(memdb.New()))
-		go func() {
-			if err := server.Serve(listener); err != nil {
-				log.Fatalf("Server exited with error: %v", err)
-			}
-		}()
-
-		dialer := grpc.WithContextDialer(
-			func(context.Context, string) (net.Conn, error) {
-				return listener.Dial()
-			})
-
-		ctx := context.Background()
-		conn, err := grpc.DialContext(ctx, "", dialer, grpc.WithInsecure())
-		if err != nil {
-			t.Fatalf("Failed to dial: %s", err)
+		testWithServer(t, test, DefaultIteratorPrefetch)
+		testWithServer(t, test, 1)
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		testWithServer(t, test, DefaultIteratorPrefetch)
+	}
+}
+
+func TestGetMany(t *testing.T) {
+	db, conn := newClient(t, memdb.New(), DefaultIteratorPrefetch)
+	defer conn.Close()
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte{}
+	key3 := []byte("hello3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	values, err := db.GetMany([][]byte{key1, key2, key3})
+	if err != nil {
+		t.Fatalf("Unexpected error on db.GetMany: %s", err)
+	}
+	if len(values) != 3 {
+		t.Fatalf("db.GetMany returned %d values but expected 3", len(values))
+	}
+	if !bytes.Equal(value1, values[0]) {
+		t.Fatalf("db.GetMany: Returned: 0x%x ; Expected: 0x%x", values[0], value1)
+	}
+	if values[1] == nil || len(values[1]) != 0 {
+		t.Fatalf("db.GetMany: Returned: %v ; Expected an empty value", values[1])
+	}
+	if values[2] != nil {
+		t.Fatalf("db.GetMany: Returned: 0x%x ; Expected: nil", values[2])
+	}
+
+	has, err := db.HasMany([][]byte{key1, key2, key3})
+	if err != nil {
+		t.Fatalf("Unexpected error on db.HasMany: %s", err)
+	}
+	if len(has) != 3 || !has[0] || !has[1] || has[2] {
+		t.Fatalf("db.HasMany: Returned: %v ; Expected: [true true false]", has)
+	}
+}
+
+func TestBatchChunks(t *testing.T) {
+	db, conn := newClient(t, memdb.New(), DefaultIteratorPrefetch)
+	defer conn.Close()
+
+	if err := db.Put([]byte{0}, []byte("deleted")); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	// The batch is larger than a single chunk
+	value := make([]byte, 1024)
+	numKeys := 3 * maxBatchChunkBytes / len(value)
+	batch := db.NewBatch()
+	if err := batch.Delete([]byte{0}); err != nil {
+		t.Fatalf("Unexpected error on batch.Delete: %s", err)
+	}
+	for i := 1; i <= numKeys; i++ {
+		if err := batch.Put([]byte(fmt.Sprintf("%08d", i)), value); err != nil {
+			t.Fatalf("Unexpected error on batch.Put: %s", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatalf("Unexpected error on batch.Write: %s", err)
+	}
+
+	if has, err := db.Has([]byte{0}); err != nil {
+		t.Fatalf("Unexpected error on db.Has: %s", err)
+	} else if has {
+		t.Fatalf("db.Has unexpectedly returned true on a deleted key")
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	count := 0
+	for it.Next() {
+		if !bytes.Equal(value, it.Value()) {
+			t.Fatalf("Wrong value for key %s", it.Key())
+		}
+		count++
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("Unexpected error on it.Error: %s", err)
+	}
+	if count != numKeys {
+		t.Fatalf("Iterated over %d keys but expected %d", count, numKeys)
+	}
+}
+
+func TestIteratorReleaseEarly(t *testing.T) {
+	db, conn := newClient(t, memdb.New(), 2)
+	defer conn.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := db.Put([]byte{byte(i)}, []byte{byte(i)}); err != nil {
+			t.Fatalf("Unexpected error on db.Put: %s", err)
 		}
+	}
+
+	it := db.NewIterator()
+	for i := 0; i < 3; i++ {
+		if !it.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+		}
+		if key := it.Key(); !bytes.Equal([]byte{byte(i)}, key) {
+			t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, []byte{byte(i)})
+		}
+	}
+	it.Release()
+
+	if it.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	}
+}
+
+func testWithServer(t *testing.T, test func(t *testing.T, db database.Database), iteratorPrefetch int) {
+	db, conn := newClient(t, memdb.New(), iteratorPrefetch)
+	test(t, db)
+	conn.Close()
+}
+
+// newClient returns a client of a server that serves [db]
+func newClient(tb testing.TB, db database.Database, iteratorPrefetch int) (*DatabaseClient, *grpc.ClientConn) {
+	listener := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	rpcdbproto.RegisterDatabaseServer(server, NewServer(db))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+	}()
+
+	dialer := grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		})
 
-		db := NewClient(rpcdbproto.NewDatabaseClient(conn))
-		test(t, db)
-		conn.Close()
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", dialer, grpc.WithInsecure())
+	if err != nil {
+		tb.Fatalf("Failed to dial: %s", err)
 	}
+	return NewClient(rpcdbproto.NewDatabaseClient(conn), iteratorPrefetch), conn
 }
@@ -27,6 +27,7 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type HasRequest struct {
 	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	SnapshotId           uint64   `protobuf:"varint,2,opt,name=snapshotId,proto3" json:"snapshotId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -64,6 +65,13 @@ func (m *HasRequest) GetKey() []byte {
 	return nil
 }
 
+func (m *HasRequest) GetSnapshotId() uint64 {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return 0
+}
+
 type HasResponse struct {
 	Has                  bool     `protobuf:"varint,1,opt,name=has,proto3" json:"has,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -105,6 +113,7 @@ func (m *HasResponse) GetHas() bool {
 
 type GetRequest struct {
 	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	SnapshotId           uint64   `protobuf:"varint,2,opt,name=snapshotId,proto3" json:"snapshotId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -142,6 +151,13 @@ func (m *GetRequest) GetKey() []byte {
 	return nil
 }
 
+func (m *GetRequest) GetSnapshotId() uint64 {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return 0
+}
+
 type GetResponse struct {
 	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -181,6 +197,170 @@ func (m *GetResponse) GetValue() []byte {
 	return nil
 }
 
+type GetManyRequest struct {
+	Keys                 [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetManyRequest) Reset()         { *m = GetManyRequest{} }
+func (m *GetManyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetManyRequest) ProtoMessage()    {}
+func (*GetManyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{4}
+}
+
+func (m *GetManyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetManyRequest.Unmarshal(m, b)
+}
+func (m *GetManyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetManyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetManyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetManyRequest.Merge(m, src)
+}
+func (m *GetManyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetManyRequest.Size(m)
+}
+func (m *GetManyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetManyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetManyRequest proto.InternalMessageInfo
+
+func (m *GetManyRequest) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type GetManyResponse struct {
+	Values               [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	Found                []bool   `protobuf:"varint,2,rep,packed,name=found,proto3" json:"found,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetManyResponse) Reset()         { *m = GetManyResponse{} }
+func (m *GetManyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetManyResponse) ProtoMessage()    {}
+func (*GetManyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{5}
+}
+
+func (m *GetManyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetManyResponse.Unmarshal(m, b)
+}
+func (m *GetManyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetManyResponse.Marshal(b, m, deterministic)
+}
+func (m *GetManyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetManyResponse.Merge(m, src)
+}
+func (m *GetManyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetManyResponse.Size(m)
+}
+func (m *GetManyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetManyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetManyResponse proto.InternalMessageInfo
+
+func (m *GetManyResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *GetManyResponse) GetFound() []bool {
+	if m != nil {
+		return m.Found
+	}
+	return nil
+}
+
+type HasManyRequest struct {
+	Keys                 [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HasManyRequest) Reset()         { *m = HasManyRequest{} }
+func (m *HasManyRequest) String() string { return proto.CompactTextString(m) }
+func (*HasManyRequest) ProtoMessage()    {}
+func (*HasManyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{6}
+}
+
+func (m *HasManyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HasManyRequest.Unmarshal(m, b)
+}
+func (m *HasManyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HasManyRequest.Marshal(b, m, deterministic)
+}
+func (m *HasManyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HasManyRequest.Merge(m, src)
+}
+func (m *HasManyRequest) XXX_Size() int {
+	return xxx_messageInfo_HasManyRequest.Size(m)
+}
+func (m *HasManyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HasManyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HasManyRequest proto.InternalMessageInfo
+
+func (m *HasManyRequest) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type HasManyResponse struct {
+	Has                  []bool   `protobuf:"varint,1,rep,packed,name=has,proto3" json:"has,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HasManyResponse) Reset()         { *m = HasManyResponse{} }
+func (m *HasManyResponse) String() string { return proto.CompactTextString(m) }
+func (*HasManyResponse) ProtoMessage()    {}
+func (*HasManyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{7}
+}
+
+func (m *HasManyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HasManyResponse.Unmarshal(m, b)
+}
+func (m *HasManyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HasManyResponse.Marshal(b, m, deterministic)
+}
+func (m *HasManyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HasManyResponse.Merge(m, src)
+}
+func (m *HasManyResponse) XXX_Size() int {
+	return xxx_messageInfo_HasManyResponse.Size(m)
+}
+func (m *HasManyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HasManyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HasManyResponse proto.InternalMessageInfo
+
+func (m *HasManyResponse) GetHas() []bool {
+	if m != nil {
+		return m.Has
+	}
+	return nil
+}
+
 type PutRequest struct {
 	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -193,7 +373,7 @@ func (m *PutRequest) Reset()         { *m = PutRequest{} }
 func (m *PutRequest) String() string { return proto.CompactTextString(m) }
 func (*PutRequest) ProtoMessage()    {}
 func (*PutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{4}
+	return fileDescriptor_af52f4b90339c3f4, []int{8}
 }
 
 func (m *PutRequest) XXX_Unmarshal(b []byte) error {
@@ -238,7 +418,7 @@ func (m *PutResponse) Reset()         { *m = PutResponse{} }
 func (m *PutResponse) String() string { return proto.CompactTextString(m) }
 func (*PutResponse) ProtoMessage()    {}
 func (*PutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{5}
+	return fileDescriptor_af52f4b90339c3f4, []int{9}
 }
 
 func (m *PutResponse) XXX_Unmarshal(b []byte) error {
@@ -270,7 +450,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{6}
+	return fileDescriptor_af52f4b90339c3f4, []int{10}
 }
 
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
@@ -308,7 +488,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{7}
+	return fileDescriptor_af52f4b90339c3f4, []int{11}
 }
 
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
@@ -340,7 +520,7 @@ func (m *StatRequest) Reset()         { *m = StatRequest{} }
 func (m *StatRequest) String() string { return proto.CompactTextString(m) }
 func (*StatRequest) ProtoMessage()    {}
 func (*StatRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{8}
+	return fileDescriptor_af52f4b90339c3f4, []int{12}
 }
 
 func (m *StatRequest) XXX_Unmarshal(b []byte) error {
@@ -379,7 +559,7 @@ func (m *StatResponse) Reset()         { *m = StatResponse{} }
 func (m *StatResponse) String() string { return proto.CompactTextString(m) }
 func (*StatResponse) ProtoMessage()    {}
 func (*StatResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{9}
+	return fileDescriptor_af52f4b90339c3f4, []int{13}
 }
 
 func (m *StatResponse) XXX_Unmarshal(b []byte) error {
@@ -419,7 +599,7 @@ func (m *CompactRequest) Reset()         { *m = CompactRequest{} }
 func (m *CompactRequest) String() string { return proto.CompactTextString(m) }
 func (*CompactRequest) ProtoMessage()    {}
 func (*CompactRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{10}
+	return fileDescriptor_af52f4b90339c3f4, []int{14}
 }
 
 func (m *CompactRequest) XXX_Unmarshal(b []byte) error {
@@ -464,7 +644,7 @@ func (m *CompactResponse) Reset()         { *m = CompactResponse{} }
 func (m *CompactResponse) String() string { return proto.CompactTextString(m) }
 func (*CompactResponse) ProtoMessage()    {}
 func (*CompactResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{11}
+	return fileDescriptor_af52f4b90339c3f4, []int{15}
 }
 
 func (m *CompactResponse) XXX_Unmarshal(b []byte) error {
@@ -495,7 +675,7 @@ func (m *CloseRequest) Reset()         { *m = CloseRequest{} }
 func (m *CloseRequest) String() string { return proto.CompactTextString(m) }
 func (*CloseRequest) ProtoMessage()    {}
 func (*CloseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{12}
+	return fileDescriptor_af52f4b90339c3f4, []int{16}
 }
 
 func (m *CloseRequest) XXX_Unmarshal(b []byte) error {
@@ -526,7 +706,7 @@ func (m *CloseResponse) Reset()         { *m = CloseResponse{} }
 func (m *CloseResponse) String() string { return proto.CompactTextString(m) }
 func (*CloseResponse) ProtoMessage()    {}
 func (*CloseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{13}
+	return fileDescriptor_af52f4b90339c3f4, []int{17}
 }
 
 func (m *CloseResponse) XXX_Unmarshal(b []byte) error {
@@ -559,7 +739,7 @@ func (m *WriteBatchRequest) Reset()         { *m = WriteBatchRequest{} }
 func (m *WriteBatchRequest) String() string { return proto.CompactTextString(m) }
 func (*WriteBatchRequest) ProtoMessage()    {}
 func (*WriteBatchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{14}
+	return fileDescriptor_af52f4b90339c3f4, []int{18}
 }
 
 func (m *WriteBatchRequest) XXX_Unmarshal(b []byte) error {
@@ -604,7 +784,7 @@ func (m *WriteBatchResponse) Reset()         { *m = WriteBatchResponse{} }
 func (m *WriteBatchResponse) String() string { return proto.CompactTextString(m) }
 func (*WriteBatchResponse) ProtoMessage()    {}
 func (*WriteBatchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{15}
+	return fileDescriptor_af52f4b90339c3f4, []int{19}
 }
 
 func (m *WriteBatchResponse) XXX_Unmarshal(b []byte) error {
@@ -635,7 +815,7 @@ func (m *NewIteratorRequest) Reset()         { *m = NewIteratorRequest{} }
 func (m *NewIteratorRequest) String() string { return proto.CompactTextString(m) }
 func (*NewIteratorRequest) ProtoMessage()    {}
 func (*NewIteratorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{16}
+	return fileDescriptor_af52f4b90339c3f4, []int{20}
 }
 
 func (m *NewIteratorRequest) XXX_Unmarshal(b []byte) error {
@@ -659,6 +839,7 @@ var xxx_messageInfo_NewIteratorRequest proto.InternalMessageInfo
 type NewIteratorWithStartAndPrefixRequest struct {
 	Start                []byte   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
 	Prefix               []byte   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	SnapshotId           uint64   `protobuf:"varint,3,opt,name=snapshotId,proto3" json:"snapshotId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -668,7 +849,7 @@ func (m *NewIteratorWithStartAndPrefixRequest) Reset()         { *m = NewIterato
 func (m *NewIteratorWithStartAndPrefixRequest) String() string { return proto.CompactTextString(m) }
 func (*NewIteratorWithStartAndPrefixRequest) ProtoMessage()    {}
 func (*NewIteratorWithStartAndPrefixRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{17}
+	return fileDescriptor_af52f4b90339c3f4, []int{21}
 }
 
 func (m *NewIteratorWithStartAndPrefixRequest) XXX_Unmarshal(b []byte) error {
@@ -703,6 +884,13 @@ func (m *NewIteratorWithStartAndPrefixRequest) GetPrefix() []byte {
 	return nil
 }
 
+func (m *NewIteratorWithStartAndPrefixRequest) GetSnapshotId() uint64 {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return 0
+}
+
 type NewIteratorWithStartAndPrefixResponse struct {
 	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -714,7 +902,7 @@ func (m *NewIteratorWithStartAndPrefixResponse) Reset()         { *m = NewIterat
 func (m *NewIteratorWithStartAndPrefixResponse) String() string { return proto.CompactTextString(m) }
 func (*NewIteratorWithStartAndPrefixResponse) ProtoMessage()    {}
 func (*NewIteratorWithStartAndPrefixResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{18}
+	return fileDescriptor_af52f4b90339c3f4, []int{22}
 }
 
 func (m *NewIteratorWithStartAndPrefixResponse) XXX_Unmarshal(b []byte) error {
@@ -742,6 +930,108 @@ func (m *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
 	return 0
 }
 
+type NewIteratorWithRangeRequest struct {
+	Start                []byte   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Reverse              bool     `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	SnapshotId           uint64   `protobuf:"varint,4,opt,name=snapshotId,proto3" json:"snapshotId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewIteratorWithRangeRequest) Reset()         { *m = NewIteratorWithRangeRequest{} }
+func (m *NewIteratorWithRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*NewIteratorWithRangeRequest) ProtoMessage()    {}
+func (*NewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{23}
+}
+
+func (m *NewIteratorWithRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Unmarshal(m, b)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewIteratorWithRangeRequest.Merge(m, src)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Size(m)
+}
+func (m *NewIteratorWithRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewIteratorWithRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewIteratorWithRangeRequest proto.InternalMessageInfo
+
+func (m *NewIteratorWithRangeRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *NewIteratorWithRangeRequest) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *NewIteratorWithRangeRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *NewIteratorWithRangeRequest) GetSnapshotId() uint64 {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return 0
+}
+
+type NewIteratorWithRangeResponse struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewIteratorWithRangeResponse) Reset()         { *m = NewIteratorWithRangeResponse{} }
+func (m *NewIteratorWithRangeResponse) String() string { return proto.CompactTextString(m) }
+func (*NewIteratorWithRangeResponse) ProtoMessage()    {}
+func (*NewIteratorWithRangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{24}
+}
+
+func (m *NewIteratorWithRangeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewIteratorWithRangeResponse.Unmarshal(m, b)
+}
+func (m *NewIteratorWithRangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewIteratorWithRangeResponse.Marshal(b, m, deterministic)
+}
+func (m *NewIteratorWithRangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewIteratorWithRangeResponse.Merge(m, src)
+}
+func (m *NewIteratorWithRangeResponse) XXX_Size() int {
+	return xxx_messageInfo_NewIteratorWithRangeResponse.Size(m)
+}
+func (m *NewIteratorWithRangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewIteratorWithRangeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewIteratorWithRangeResponse proto.InternalMessageInfo
+
+func (m *NewIteratorWithRangeResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
 type IteratorNextRequest struct {
 	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -753,7 +1043,7 @@ func (m *IteratorNextRequest) Reset()         { *m = IteratorNextRequest{} }
 func (m *IteratorNextRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorNextRequest) ProtoMessage()    {}
 func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{19}
+	return fileDescriptor_af52f4b90339c3f4, []int{25}
 }
 
 func (m *IteratorNextRequest) XXX_Unmarshal(b []byte) error {
@@ -794,7 +1084,7 @@ func (m *IteratorNextResponse) Reset()         { *m = IteratorNextResponse{} }
 func (m *IteratorNextResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorNextResponse) ProtoMessage()    {}
 func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{20}
+	return fileDescriptor_af52f4b90339c3f4, []int{26}
 }
 
 func (m *IteratorNextResponse) XXX_Unmarshal(b []byte) error {
@@ -836,6 +1126,92 @@ func (m *IteratorNextResponse) GetValue() []byte {
 	return nil
 }
 
+type IteratorStreamRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize             uint32   `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IteratorStreamRequest) Reset()         { *m = IteratorStreamRequest{} }
+func (m *IteratorStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*IteratorStreamRequest) ProtoMessage()    {}
+func (*IteratorStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{27}
+}
+
+func (m *IteratorStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IteratorStreamRequest.Unmarshal(m, b)
+}
+func (m *IteratorStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IteratorStreamRequest.Marshal(b, m, deterministic)
+}
+func (m *IteratorStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IteratorStreamRequest.Merge(m, src)
+}
+func (m *IteratorStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_IteratorStreamRequest.Size(m)
+}
+func (m *IteratorStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IteratorStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IteratorStreamRequest proto.InternalMessageInfo
+
+func (m *IteratorStreamRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *IteratorStreamRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type IteratorStreamResponse struct {
+	Data                 []*PutRequest `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *IteratorStreamResponse) Reset()         { *m = IteratorStreamResponse{} }
+func (m *IteratorStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*IteratorStreamResponse) ProtoMessage()    {}
+func (*IteratorStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{28}
+}
+
+func (m *IteratorStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IteratorStreamResponse.Unmarshal(m, b)
+}
+func (m *IteratorStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IteratorStreamResponse.Marshal(b, m, deterministic)
+}
+func (m *IteratorStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IteratorStreamResponse.Merge(m, src)
+}
+func (m *IteratorStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_IteratorStreamResponse.Size(m)
+}
+func (m *IteratorStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IteratorStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IteratorStreamResponse proto.InternalMessageInfo
+
+func (m *IteratorStreamResponse) GetData() []*PutRequest {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 type IteratorErrorRequest struct {
 	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -847,7 +1223,7 @@ func (m *IteratorErrorRequest) Reset()         { *m = IteratorErrorRequest{} }
 func (m *IteratorErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorErrorRequest) ProtoMessage()    {}
 func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{21}
+	return fileDescriptor_af52f4b90339c3f4, []int{29}
 }
 
 func (m *IteratorErrorRequest) XXX_Unmarshal(b []byte) error {
@@ -885,7 +1261,7 @@ func (m *IteratorErrorResponse) Reset()         { *m = IteratorErrorResponse{} }
 func (m *IteratorErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorErrorResponse) ProtoMessage()    {}
 func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{22}
+	return fileDescriptor_af52f4b90339c3f4, []int{30}
 }
 
 func (m *IteratorErrorResponse) XXX_Unmarshal(b []byte) error {
@@ -917,7 +1293,7 @@ func (m *IteratorReleaseRequest) Reset()         { *m = IteratorReleaseRequest{}
 func (m *IteratorReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorReleaseRequest) ProtoMessage()    {}
 func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{23}
+	return fileDescriptor_af52f4b90339c3f4, []int{31}
 }
 
 func (m *IteratorReleaseRequest) XXX_Unmarshal(b []byte) error {
@@ -955,7 +1331,7 @@ func (m *IteratorReleaseResponse) Reset()         { *m = IteratorReleaseResponse
 func (m *IteratorReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorReleaseResponse) ProtoMessage()    {}
 func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{24}
+	return fileDescriptor_af52f4b90339c3f4, []int{32}
 }
 
 func (m *IteratorReleaseResponse) XXX_Unmarshal(b []byte) error {
@@ -976,11 +1352,155 @@ func (m *IteratorReleaseResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IteratorReleaseResponse proto.InternalMessageInfo
 
+type NewSnapshotRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotRequest) Reset()         { *m = NewSnapshotRequest{} }
+func (m *NewSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotRequest) ProtoMessage()    {}
+func (*NewSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{33}
+}
+
+func (m *NewSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotRequest.Unmarshal(m, b)
+}
+func (m *NewSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotRequest.Merge(m, src)
+}
+func (m *NewSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotRequest.Size(m)
+}
+func (m *NewSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotRequest proto.InternalMessageInfo
+
+type NewSnapshotResponse struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotResponse) Reset()         { *m = NewSnapshotResponse{} }
+func (m *NewSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotResponse) ProtoMessage()    {}
+func (*NewSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{34}
+}
+
+func (m *NewSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotResponse.Unmarshal(m, b)
+}
+func (m *NewSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotResponse.Merge(m, src)
+}
+func (m *NewSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotResponse.Size(m)
+}
+func (m *NewSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotResponse proto.InternalMessageInfo
+
+func (m *NewSnapshotResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseRequest) Reset()         { *m = SnapshotReleaseRequest{} }
+func (m *SnapshotReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseRequest) ProtoMessage()    {}
+func (*SnapshotReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{35}
+}
+
+func (m *SnapshotReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseRequest.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseRequest.Merge(m, src)
+}
+func (m *SnapshotReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseRequest.Size(m)
+}
+func (m *SnapshotReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseRequest proto.InternalMessageInfo
+
+func (m *SnapshotReleaseRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseResponse) Reset()         { *m = SnapshotReleaseResponse{} }
+func (m *SnapshotReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseResponse) ProtoMessage()    {}
+func (*SnapshotReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{36}
+}
+
+func (m *SnapshotReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseResponse.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseResponse.Merge(m, src)
+}
+func (m *SnapshotReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseResponse.Size(m)
+}
+func (m *SnapshotReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*HasRequest)(nil), "rpcdbproto.HasRequest")
 	proto.RegisterType((*HasResponse)(nil), "rpcdbproto.HasResponse")
 	proto.RegisterType((*GetRequest)(nil), "rpcdbproto.GetRequest")
 	proto.RegisterType((*GetResponse)(nil), "rpcdbproto.GetResponse")
+	proto.RegisterType((*GetManyRequest)(nil), "rpcdbproto.GetManyRequest")
+	proto.RegisterType((*GetManyResponse)(nil), "rpcdbproto.GetManyResponse")
+	proto.RegisterType((*HasManyRequest)(nil), "rpcdbproto.HasManyRequest")
+	proto.RegisterType((*HasManyResponse)(nil), "rpcdbproto.HasManyResponse")
 	proto.RegisterType((*PutRequest)(nil), "rpcdbproto.PutRequest")
 	proto.RegisterType((*PutResponse)(nil), "rpcdbproto.PutResponse")
 	proto.RegisterType((*DeleteRequest)(nil), "rpcdbproto.DeleteRequest")
@@ -996,59 +1516,86 @@ func init() {
 	proto.RegisterType((*NewIteratorRequest)(nil), "rpcdbproto.NewIteratorRequest")
 	proto.RegisterType((*NewIteratorWithStartAndPrefixRequest)(nil), "rpcdbproto.NewIteratorWithStartAndPrefixRequest")
 	proto.RegisterType((*NewIteratorWithStartAndPrefixResponse)(nil), "rpcdbproto.NewIteratorWithStartAndPrefixResponse")
+	proto.RegisterType((*NewIteratorWithRangeRequest)(nil), "rpcdbproto.NewIteratorWithRangeRequest")
+	proto.RegisterType((*NewIteratorWithRangeResponse)(nil), "rpcdbproto.NewIteratorWithRangeResponse")
 	proto.RegisterType((*IteratorNextRequest)(nil), "rpcdbproto.IteratorNextRequest")
 	proto.RegisterType((*IteratorNextResponse)(nil), "rpcdbproto.IteratorNextResponse")
+	proto.RegisterType((*IteratorStreamRequest)(nil), "rpcdbproto.IteratorStreamRequest")
+	proto.RegisterType((*IteratorStreamResponse)(nil), "rpcdbproto.IteratorStreamResponse")
 	proto.RegisterType((*IteratorErrorRequest)(nil), "rpcdbproto.IteratorErrorRequest")
 	proto.RegisterType((*IteratorErrorResponse)(nil), "rpcdbproto.IteratorErrorResponse")
 	proto.RegisterType((*IteratorReleaseRequest)(nil), "rpcdbproto.IteratorReleaseRequest")
 	proto.RegisterType((*IteratorReleaseResponse)(nil), "rpcdbproto.IteratorReleaseResponse")
+	proto.RegisterType((*NewSnapshotRequest)(nil), "rpcdbproto.NewSnapshotRequest")
+	proto.RegisterType((*NewSnapshotResponse)(nil), "rpcdbproto.NewSnapshotResponse")
+	proto.RegisterType((*SnapshotReleaseRequest)(nil), "rpcdbproto.SnapshotReleaseRequest")
+	proto.RegisterType((*SnapshotReleaseResponse)(nil), "rpcdbproto.SnapshotReleaseResponse")
 }
 
 func init() { proto.RegisterFile("rpcdb.proto", fileDescriptor_af52f4b90339c3f4) }
 
 var fileDescriptor_af52f4b90339c3f4 = []byte{
-	// 654 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5d, 0x4f, 0xd4, 0x40,
-	0x14, 0x0d, 0xbb, 0xcb, 0xd7, 0xd9, 0x0f, 0x60, 0x5c, 0xf9, 0xa8, 0xf2, 0x35, 0x88, 0x41, 0x1f,
-	0x88, 0x82, 0xc1, 0x98, 0x90, 0x18, 0x01, 0x03, 0xc6, 0x84, 0x60, 0x21, 0x21, 0x31, 0xbe, 0x0c,
-	0xec, 0x10, 0x1a, 0x97, 0x6d, 0x6d, 0x67, 0x15, 0xdf, 0x7d, 0xf1, 0x5f, 0x9b, 0x4e, 0x6f, 0xdb,
-	0x99, 0xdd, 0x76, 0xd5, 0xb7, 0xb9, 0x73, 0xcf, 0x39, 0x73, 0xe7, 0xce, 0x3d, 0x83, 0x7a, 0x18,
-	0x5c, 0x77, 0xae, 0xb6, 0x83, 0xd0, 0x57, 0x3e, 0x83, 0x0e, 0xf4, 0x9a, 0xaf, 0x00, 0x27, 0x22,
-	0x72, 0xe5, 0xb7, 0xbe, 0x8c, 0x14, 0x9b, 0x45, 0xf5, 0xab, 0xfc, 0xb9, 0x38, 0xb6, 0x36, 0xb6,
-	0xd5, 0x70, 0xe3, 0x25, 0x5f, 0x45, 0x5d, 0xe7, 0xa3, 0xc0, 0xef, 0x45, 0x32, 0x06, 0xdc, 0x8a,
-	0x48, 0x03, 0xa6, 0xdc, 0x78, 0x19, 0x0b, 0x1c, 0x4b, 0x55, 0x2e, 0xb0, 0x81, 0xba, 0xce, 0x93,
-	0x40, 0x1b, 0xe3, 0xdf, 0x45, 0xb7, 0x2f, 0x09, 0x92, 0x04, 0xfc, 0x15, 0x70, 0xd6, 0x2f, 0x17,
-	0xc9, 0x59, 0x15, 0x93, 0xd5, 0x44, 0x5d, 0xb3, 0x12, 0x69, 0xbe, 0x8e, 0xe6, 0x91, 0xec, 0x4a,
-	0x25, 0xcb, 0x8b, 0x99, 0x45, 0x2b, 0x85, 0x10, 0xe9, 0x19, 0xea, 0xe7, 0x4a, 0x64, 0x47, 0x3b,
-	0x98, 0x0a, 0x42, 0x3f, 0x90, 0xa1, 0x4a, 0x78, 0xd3, 0x6e, 0x16, 0x73, 0x8e, 0x46, 0x02, 0xa5,
-	0xab, 0x30, 0xd4, 0x22, 0x25, 0x14, 0xe1, 0xf4, 0x9a, 0xef, 0xa3, 0x75, 0xe8, 0xdf, 0x05, 0xe2,
-	0x3a, 0x53, 0x6c, 0x63, 0x3c, 0x52, 0x22, 0x54, 0xe9, 0x85, 0x75, 0x10, 0xef, 0x76, 0xbd, 0x3b,
-	0x4f, 0xa5, 0x17, 0xd2, 0x01, 0x9f, 0xc3, 0x4c, 0xc6, 0xa6, 0xfa, 0x5a, 0x68, 0x1c, 0x76, 0xfd,
-	0x28, 0xbd, 0x13, 0x9f, 0x41, 0x93, 0x62, 0x02, 0x28, 0xcc, 0x5d, 0x86, 0x9e, 0x92, 0x07, 0x42,
-	0x5d, 0xdf, 0xa6, 0x87, 0x3e, 0x47, 0x2d, 0xe8, 0xab, 0xf8, 0x9d, 0xaa, 0x5b, 0xf5, 0x9d, 0xf9,
-	0xed, 0xfc, 0xc1, 0xb7, 0xf3, 0x3e, 0xbb, 0x1a, 0xc3, 0x76, 0x31, 0xd9, 0xd1, 0x3d, 0x89, 0x16,
-	0x2b, 0x1a, 0xbe, 0x64, 0xc2, 0xad, 0x8e, 0xba, 0x29, 0x92, 0xb7, 0xc1, 0xcc, 0x53, 0xa9, 0x96,
-	0x36, 0xd8, 0xa9, 0xfc, 0xf1, 0x41, 0xc9, 0x50, 0x28, 0x3f, 0x4c, 0x4b, 0xbe, 0xc0, 0x13, 0x63,
-	0xf7, 0xd2, 0x53, 0xb7, 0xe7, 0x71, 0x0f, 0xde, 0xf5, 0x3a, 0x67, 0xa1, 0xbc, 0xf1, 0xee, 0x47,
-	0x77, 0x6a, 0x1e, 0x13, 0x81, 0x86, 0x51, 0xab, 0x28, 0xe2, 0xaf, 0xb1, 0xf9, 0x17, 0x55, 0x7a,
-	0xa6, 0x16, 0x2a, 0x5e, 0x47, 0x6b, 0xd6, 0xdc, 0x8a, 0xd7, 0xe1, 0x9b, 0x78, 0x90, 0xb2, 0x4e,
-	0xe5, 0x7d, 0xf6, 0x4e, 0x83, 0xb0, 0x2f, 0x68, 0xdb, 0x30, 0x92, 0x7b, 0x8c, 0xe9, 0x1b, 0xbf,
-	0xdf, 0xeb, 0xc4, 0x9b, 0xe4, 0x83, 0x7c, 0x23, 0x1d, 0xb9, 0x4a, 0xc1, 0xe8, 0x56, 0xcd, 0xd1,
-	0x7d, 0x9a, 0xab, 0xbf, 0x0f, 0xc3, 0xac, 0x57, 0x43, 0x55, 0x2c, 0xe0, 0xe1, 0x00, 0x8e, 0x5a,
-	0xbd, 0x85, 0xf9, 0xbc, 0xcf, 0x5d, 0x29, 0xb2, 0x09, 0x19, 0x92, 0x58, 0xc2, 0xc2, 0x10, 0x32,
-	0x11, 0xd9, 0xf9, 0x3d, 0x89, 0xa9, 0x23, 0xa1, 0xc4, 0x95, 0x88, 0x24, 0xdb, 0x43, 0xf5, 0x44,
-	0x44, 0xcc, 0x1a, 0x96, 0xfc, 0x6b, 0x70, 0x16, 0x86, 0xf6, 0xa9, 0x21, 0x7b, 0xa8, 0x1e, 0x4b,
-	0x65, 0xf3, 0xf2, 0x1f, 0xc1, 0xe6, 0x99, 0x3f, 0xc1, 0x1e, 0xaa, 0x67, 0xfd, 0x01, 0x5e, 0x3e,
-	0x9c, 0x36, 0xcf, 0xb0, 0x39, 0x7b, 0x8b, 0x89, 0x64, 0x28, 0x59, 0xf9, 0xa0, 0x3a, 0x4e, 0x51,
-	0x8a, 0x04, 0xde, 0xa0, 0x16, 0xfb, 0x98, 0x59, 0x27, 0x18, 0x9f, 0x80, 0xb3, 0x38, 0x9c, 0x20,
-	0xea, 0x01, 0x26, 0xc9, 0xa0, 0xcc, 0x3a, 0xc1, 0xf6, 0xbc, 0xf3, 0xa8, 0x30, 0x47, 0x1a, 0xfb,
-	0x18, 0xd7, 0x0e, 0x66, 0xd6, 0x31, 0xa6, 0xc9, 0x9d, 0xa5, 0x82, 0x0c, 0xb1, 0x3f, 0x02, 0xb9,
-	0xf1, 0xd8, 0xb2, 0x09, 0x1c, 0xfa, 0x06, 0x9c, 0x95, 0xb2, 0x34, 0x89, 0xfd, 0x1a, 0xc3, 0xf2,
-	0x48, 0x13, 0xb1, 0x17, 0xa6, 0xc2, 0xbf, 0xb8, 0xd8, 0x79, 0xf9, 0x1f, 0x0c, 0x2a, 0xe3, 0x13,
-	0x1a, 0xa6, 0xd5, 0xd8, 0xaa, 0x29, 0x51, 0xe0, 0x55, 0x67, 0xad, 0x1c, 0x40, 0x92, 0x17, 0x68,
-	0x5a, 0xbe, 0x61, 0x85, 0x14, 0xd3, 0x7a, 0xce, 0xfa, 0x08, 0x04, 0xa9, 0x7e, 0xc6, 0xcc, 0x80,
-	0x95, 0x18, 0x2f, 0x62, 0xd9, 0x8e, 0x74, 0x36, 0x46, 0x62, 0x12, 0xed, 0xab, 0x09, 0x9d, 0xde,
-	0xfd, 0x13, 0x00, 0x00, 0xff, 0xff, 0x91, 0xec, 0x29, 0xb2, 0xaa, 0x07, 0x00, 0x00,
+	// 956 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x59, 0x6f, 0x1c, 0x45,
+	0x10, 0xd6, 0x1e, 0xb6, 0x37, 0xdf, 0x5e, 0x4e, 0x67, 0xb3, 0x6b, 0xb7, 0x13, 0x1f, 0xe3, 0x18,
+	0x96, 0x3c, 0x58, 0xc1, 0x41, 0x41, 0x48, 0x11, 0x11, 0xb1, 0x91, 0x1d, 0x21, 0x2c, 0x33, 0x8b,
+	0x14, 0x81, 0x78, 0x69, 0x7b, 0x3b, 0xf1, 0x28, 0xeb, 0x9d, 0x61, 0xa6, 0x37, 0xd8, 0xbc, 0xf0,
+	0xc2, 0x8f, 0xe4, 0xe7, 0xa0, 0xe9, 0xa9, 0xb9, 0x8f, 0xe5, 0x78, 0xeb, 0xea, 0xfe, 0xea, 0xab,
+	0xaf, 0xab, 0xbb, 0xaa, 0xd0, 0x76, 0x9d, 0xab, 0xe9, 0xe5, 0xa1, 0xe3, 0xda, 0xca, 0x66, 0xd0,
+	0x86, 0x5e, 0x1b, 0x5f, 0x03, 0x67, 0xc2, 0x33, 0xe5, 0xaf, 0x0b, 0xe9, 0x29, 0xb6, 0x8e, 0xc6,
+	0x07, 0x79, 0xb7, 0x51, 0xdb, 0xad, 0x8d, 0x3b, 0xa6, 0xbf, 0x64, 0xdb, 0x80, 0x37, 0x17, 0x8e,
+	0x77, 0x6d, 0xab, 0x37, 0xd3, 0x8d, 0xfa, 0x6e, 0x6d, 0xdc, 0x34, 0x13, 0x3b, 0xc6, 0x0e, 0xda,
+	0xda, 0xdf, 0x73, 0xec, 0xb9, 0x27, 0x7d, 0x82, 0x6b, 0xe1, 0x69, 0x82, 0x96, 0xe9, 0x2f, 0xfd,
+	0x00, 0xa7, 0x52, 0xfd, 0xf7, 0x00, 0xfb, 0x68, 0x6b, 0x7f, 0x0a, 0x30, 0xc0, 0xca, 0x47, 0x31,
+	0x5b, 0x48, 0xa2, 0x08, 0x0c, 0xe3, 0x09, 0x7a, 0xa7, 0x52, 0x7d, 0x2f, 0xe6, 0x77, 0x61, 0x20,
+	0x86, 0xe6, 0x07, 0x79, 0xe7, 0x2b, 0x69, 0x8c, 0x3b, 0xa6, 0x5e, 0x1b, 0xaf, 0xd0, 0x8f, 0x50,
+	0x44, 0x37, 0xc4, 0xaa, 0x66, 0x08, 0x81, 0x64, 0xf9, 0x61, 0xde, 0xd9, 0x8b, 0xb9, 0x2f, 0xa8,
+	0x31, 0x6e, 0x99, 0x81, 0xe1, 0x87, 0x39, 0x13, 0xde, 0xb2, 0x30, 0xfb, 0xe8, 0x47, 0xa8, 0x6c,
+	0x5a, 0x1a, 0x61, 0x5a, 0xbe, 0x00, 0x2e, 0x16, 0x15, 0x69, 0x89, 0xee, 0x59, 0x4f, 0xde, 0xb3,
+	0x8b, 0xb6, 0xf6, 0x0a, 0x68, 0x8d, 0x3d, 0x74, 0x4f, 0xe4, 0x4c, 0x2a, 0x59, 0xca, 0x63, 0xac,
+	0xa3, 0x17, 0x42, 0xc8, 0xe9, 0x33, 0xb4, 0x27, 0x4a, 0x44, 0xa1, 0x39, 0x5a, 0x8e, 0x6b, 0x3b,
+	0xd2, 0x55, 0x81, 0xdf, 0x3d, 0x33, 0xb2, 0x0d, 0x03, 0x9d, 0x00, 0x4a, 0xd7, 0x60, 0x68, 0x7a,
+	0x4a, 0x28, 0xc2, 0xe9, 0xb5, 0xf1, 0x12, 0xbd, 0x63, 0xfb, 0xc6, 0x11, 0x57, 0x11, 0xe3, 0x00,
+	0x2b, 0x9e, 0x12, 0xae, 0x0a, 0x9f, 0x48, 0x1b, 0xfe, 0xee, 0xcc, 0xba, 0xb1, 0x54, 0x78, 0x21,
+	0x6d, 0x18, 0xf7, 0xd1, 0x8f, 0xbc, 0x49, 0x5f, 0x0f, 0x9d, 0xe3, 0x99, 0xed, 0x85, 0x77, 0x32,
+	0xfa, 0xe8, 0x92, 0x4d, 0x00, 0x85, 0xfb, 0x6f, 0x5d, 0x4b, 0xc9, 0xd7, 0x42, 0x5d, 0x5d, 0x87,
+	0x41, 0x9f, 0xa2, 0xe9, 0x2c, 0x54, 0x90, 0xe2, 0xf6, 0xd1, 0xf0, 0x30, 0xfe, 0xe2, 0x87, 0x71,
+	0x9e, 0x4d, 0x8d, 0x61, 0xcf, 0xb1, 0x36, 0xd5, 0x39, 0xf1, 0xf4, 0xf3, 0xb6, 0x8f, 0x36, 0x93,
+	0xf0, 0x54, 0x46, 0xcd, 0x10, 0x69, 0x0c, 0xc0, 0x92, 0x51, 0x49, 0xcb, 0x00, 0xec, 0x5c, 0xfe,
+	0xf6, 0x46, 0x49, 0x57, 0x28, 0xdb, 0x0d, 0x25, 0x2b, 0x3c, 0x49, 0xec, 0xbe, 0xb5, 0xd4, 0xf5,
+	0xc4, 0xcf, 0xc1, 0x37, 0xf3, 0xe9, 0x85, 0x2b, 0xdf, 0x59, 0xb7, 0xd5, 0x99, 0x1a, 0x62, 0xd5,
+	0xd1, 0x30, 0x4a, 0x15, 0x59, 0x99, 0x4a, 0x69, 0xe4, 0x2a, 0xe5, 0x4b, 0x1c, 0x2c, 0x89, 0x4a,
+	0xcf, 0xd8, 0x43, 0xdd, 0x9a, 0xea, 0x98, 0x4d, 0xb3, 0x6e, 0x4d, 0x8d, 0x3f, 0xb0, 0x95, 0x71,
+	0x34, 0xc5, 0xfc, 0xbd, 0xac, 0x56, 0xb9, 0x8e, 0x86, 0x9c, 0x4f, 0x49, 0xa2, 0xbf, 0x64, 0x1b,
+	0x58, 0x73, 0xe5, 0x47, 0xe9, 0x7a, 0x52, 0x8b, 0x6b, 0x99, 0xa1, 0x99, 0x51, 0xde, 0xcc, 0x29,
+	0x3f, 0xc4, 0xa3, 0x62, 0x01, 0x25, 0x82, 0x0f, 0xf0, 0x20, 0x04, 0x9f, 0xcb, 0xdb, 0xe8, 0xe3,
+	0x65, 0x61, 0xbf, 0x60, 0x90, 0x86, 0x11, 0xdd, 0x23, 0xdc, 0xd3, 0xf5, 0xec, 0x6f, 0x52, 0xab,
+	0x8a, 0x37, 0xc2, 0x1a, 0xaa, 0x17, 0xd4, 0x62, 0x23, 0x59, 0x8b, 0xc7, 0x78, 0x18, 0xb2, 0x4f,
+	0x94, 0x2b, 0xc5, 0x4d, 0x89, 0x0c, 0x5d, 0x61, 0xe2, 0xbd, 0x9c, 0x58, 0xbf, 0x07, 0xd5, 0xdc,
+	0x35, 0x23, 0xdb, 0x38, 0xc1, 0x30, 0x4b, 0x42, 0x22, 0x9f, 0xa2, 0x39, 0x15, 0x4a, 0x2c, 0xfb,
+	0xd0, 0x3e, 0xc6, 0xf8, 0x24, 0xbe, 0xe8, 0xb7, 0xae, 0x6b, 0xbb, 0x25, 0x4a, 0x8c, 0x11, 0x1e,
+	0x66, 0x70, 0xf4, 0x8d, 0xc7, 0xb1, 0x0c, 0x53, 0xce, 0xa4, 0xf0, 0x64, 0x19, 0xc5, 0x26, 0x46,
+	0x39, 0x64, 0xaa, 0x16, 0x26, 0xf4, 0xac, 0x61, 0x2d, 0x1c, 0xe0, 0x41, 0x6a, 0xb7, 0xe4, 0x49,
+	0xc7, 0x18, 0xc6, 0x98, 0x65, 0x0a, 0x72, 0xc8, 0x80, 0xf4, 0xe8, 0xaf, 0x36, 0x5a, 0x27, 0x42,
+	0x89, 0x4b, 0xe1, 0x49, 0xf6, 0x02, 0x8d, 0x33, 0xe1, 0xb1, 0x54, 0xe6, 0xe2, 0x51, 0xc7, 0x47,
+	0xb9, 0x7d, 0x52, 0xf6, 0x02, 0x8d, 0x53, 0xa9, 0xd2, 0x7e, 0xf1, 0x04, 0xe3, 0xa3, 0xdc, 0x3e,
+	0xf9, 0xbd, 0xc6, 0x1a, 0x4d, 0x17, 0xc6, 0x33, 0x98, 0xc4, 0xc4, 0xe0, 0x5b, 0x85, 0x67, 0x31,
+	0x07, 0x8d, 0x8e, 0x34, 0x47, 0x7a, 0xea, 0xf0, 0xad, 0xc2, 0xb3, 0x58, 0xff, 0xc5, 0x42, 0xb1,
+	0x92, 0x1f, 0xc3, 0x47, 0xb9, 0x7d, 0xf2, 0x7b, 0x85, 0xd5, 0xa0, 0xf5, 0xb1, 0xf2, 0x76, 0xc8,
+	0x79, 0xd1, 0x11, 0x11, 0x7c, 0x85, 0xa6, 0x3f, 0x2d, 0x58, 0x2a, 0x42, 0x62, 0xd4, 0xf0, 0x8d,
+	0xfc, 0x41, 0x7c, 0x6f, 0x1a, 0x03, 0xe9, 0x7b, 0xa7, 0x27, 0x0b, 0xdf, 0x2a, 0x3c, 0x23, 0x8e,
+	0x97, 0x58, 0xd1, 0x73, 0x82, 0xa5, 0xc2, 0x24, 0x47, 0x09, 0xdf, 0x2c, 0x38, 0x21, 0xef, 0xef,
+	0x80, 0xb8, 0xbd, 0xb3, 0xc7, 0x49, 0x60, 0x6e, 0xd8, 0xf0, 0xed, 0xb2, 0x63, 0x22, 0x9b, 0x60,
+	0x3d, 0xde, 0x0d, 0xea, 0xfa, 0x7f, 0x52, 0x8e, 0x6b, 0xec, 0xcf, 0x1a, 0x1e, 0x57, 0xf6, 0x77,
+	0xf6, 0x2c, 0xc9, 0xf1, 0x4f, 0x06, 0x10, 0xff, 0xfc, 0x5f, 0x78, 0xd0, 0xdd, 0x2c, 0x0c, 0x8a,
+	0x7a, 0x35, 0xfb, 0xb4, 0x82, 0x2a, 0x39, 0x4e, 0xf8, 0x78, 0x39, 0x90, 0x42, 0xfd, 0x80, 0x4e,
+	0xb2, 0x7f, 0xb3, 0x9d, 0xa4, 0x67, 0xc1, 0x00, 0xe0, 0xbb, 0xe5, 0x00, 0xa2, 0xfc, 0x09, 0xbd,
+	0x74, 0xbf, 0x65, 0x7b, 0x45, 0x3e, 0xa9, 0x86, 0xce, 0x8d, 0x2a, 0x48, 0x40, 0xfc, 0xac, 0xc6,
+	0x7e, 0x44, 0x37, 0xd5, 0x5c, 0x59, 0xa1, 0x9a, 0x64, 0x7f, 0xe6, 0x7b, 0x15, 0x08, 0x12, 0xfc,
+	0x33, 0xfa, 0x99, 0x7e, 0xcb, 0x0a, 0xe5, 0xa4, 0x9b, 0x26, 0xdf, 0xaf, 0xc4, 0x10, 0xf7, 0x39,
+	0xda, 0x89, 0xd6, 0xcc, 0xb6, 0x33, 0x0f, 0x93, 0xe9, 0xe4, 0x7c, 0xa7, 0xf4, 0x3c, 0xd6, 0x9a,
+	0xe9, 0xcc, 0x69, 0xad, 0xc5, 0x0d, 0x9e, 0xef, 0x57, 0x62, 0x02, 0xee, 0xcb, 0x55, 0x7d, 0xfc,
+	0xfc, 0xef, 0x01, 0x00, 0xdb, 0x15, 0xad, 0xd6, 0xc9, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1065,16 +1612,23 @@ const _ = grpc.SupportPackageIsVersion6
 type DatabaseClient interface {
 	Has(ctx context.Context, in *HasRequest, opts ...grpc.CallOption) (*HasResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	GetMany(ctx context.Context, in *GetManyRequest, opts ...grpc.CallOption) (*GetManyResponse, error)
+	HasMany(ctx context.Context, in *HasManyRequest, opts ...grpc.CallOption) (*HasManyResponse, error)
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error)
 	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
 	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
 	WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error)
+	WriteBatchStream(ctx context.Context, opts ...grpc.CallOption) (Database_WriteBatchStreamClient, error)
 	NewIteratorWithStartAndPrefix(ctx context.Context, in *NewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithRangeResponse, error)
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
+	IteratorStream(ctx context.Context, in *IteratorStreamRequest, opts ...grpc.CallOption) (Database_IteratorStreamClient, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
+	NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error)
+	SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error)
 }
 
 type databaseClient struct {
@@ -1103,6 +1657,24 @@ func (c *databaseClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *databaseClient) GetMany(ctx context.Context, in *GetManyRequest, opts ...grpc.CallOption) (*GetManyResponse, error) {
+	out := new(GetManyResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/GetMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) HasMany(ctx context.Context, in *HasManyRequest, opts ...grpc.CallOption) (*HasManyResponse, error) {
+	out := new(HasManyResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/HasMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
 	out := new(PutResponse)
 	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/Put", in, out, opts...)
@@ -1157,6 +1729,40 @@ func (c *databaseClient) WriteBatch(ctx context.Context, in *WriteBatchRequest,
 	return out, nil
 }
 
+func (c *databaseClient) WriteBatchStream(ctx context.Context, opts ...grpc.CallOption) (Database_WriteBatchStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Database_serviceDesc.Streams[0], "/rpcdbproto.Database/WriteBatchStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseWriteBatchStreamClient{stream}
+	return x, nil
+}
+
+type Database_WriteBatchStreamClient interface {
+	Send(*WriteBatchRequest) error
+	CloseAndRecv() (*WriteBatchResponse, error)
+	grpc.ClientStream
+}
+
+type databaseWriteBatchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseWriteBatchStreamClient) Send(m *WriteBatchRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *databaseWriteBatchStreamClient) CloseAndRecv() (*WriteBatchResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteBatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *databaseClient) NewIteratorWithStartAndPrefix(ctx context.Context, in *NewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error) {
 	out := new(NewIteratorWithStartAndPrefixResponse)
 	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewIteratorWithStartAndPrefix", in, out, opts...)
@@ -1166,6 +1772,15 @@ func (c *databaseClient) NewIteratorWithStartAndPrefix(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *databaseClient) NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithRangeResponse, error) {
+	out := new(NewIteratorWithRangeResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewIteratorWithRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error) {
 	out := new(IteratorNextResponse)
 	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/IteratorNext", in, out, opts...)
@@ -1175,6 +1790,38 @@ func (c *databaseClient) IteratorNext(ctx context.Context, in *IteratorNextReque
 	return out, nil
 }
 
+func (c *databaseClient) IteratorStream(ctx context.Context, in *IteratorStreamRequest, opts ...grpc.CallOption) (Database_IteratorStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Database_serviceDesc.Streams[1], "/rpcdbproto.Database/IteratorStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseIteratorStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Database_IteratorStreamClient interface {
+	Recv() (*IteratorStreamResponse, error)
+	grpc.ClientStream
+}
+
+type databaseIteratorStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseIteratorStreamClient) Recv() (*IteratorStreamResponse, error) {
+	m := new(IteratorStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *databaseClient) IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error) {
 	out := new(IteratorErrorResponse)
 	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/IteratorError", in, out, opts...)
@@ -1193,20 +1840,45 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 	return out, nil
 }
 
+func (c *databaseClient) NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error) {
+	out := new(NewSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error) {
+	out := new(SnapshotReleaseResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServer is the server API for Database service.
 type DatabaseServer interface {
 	Has(context.Context, *HasRequest) (*HasResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
+	GetMany(context.Context, *GetManyRequest) (*GetManyResponse, error)
+	HasMany(context.Context, *HasManyRequest) (*HasManyResponse, error)
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	Stat(context.Context, *StatRequest) (*StatResponse, error)
 	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
 	Close(context.Context, *CloseRequest) (*CloseResponse, error)
 	WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error)
+	WriteBatchStream(Database_WriteBatchStreamServer) error
 	NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(context.Context, *NewIteratorWithRangeRequest) (*NewIteratorWithRangeResponse, error)
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
+	IteratorStream(*IteratorStreamRequest, Database_IteratorStreamServer) error
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
+	NewSnapshot(context.Context, *NewSnapshotRequest) (*NewSnapshotResponse, error)
+	SnapshotRelease(context.Context, *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error)
 }
 
 // UnimplementedDatabaseServer can be embedded to have forward compatible implementations.
@@ -1219,6 +1891,12 @@ func (*UnimplementedDatabaseServer) Has(ctx context.Context, req *HasRequest) (*
 func (*UnimplementedDatabaseServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (*UnimplementedDatabaseServer) GetMany(ctx context.Context, req *GetManyRequest) (*GetManyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMany not implemented")
+}
+func (*UnimplementedDatabaseServer) HasMany(ctx context.Context, req *HasManyRequest) (*HasManyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasMany not implemented")
+}
 func (*UnimplementedDatabaseServer) Put(ctx context.Context, req *PutRequest) (*PutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
 }
@@ -1237,18 +1915,33 @@ func (*UnimplementedDatabaseServer) Close(ctx context.Context, req *CloseRequest
 func (*UnimplementedDatabaseServer) WriteBatch(ctx context.Context, req *WriteBatchRequest) (*WriteBatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteBatch not implemented")
 }
+func (*UnimplementedDatabaseServer) WriteBatchStream(srv Database_WriteBatchStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteBatchStream not implemented")
+}
 func (*UnimplementedDatabaseServer) NewIteratorWithStartAndPrefix(ctx context.Context, req *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithStartAndPrefix not implemented")
 }
+func (*UnimplementedDatabaseServer) NewIteratorWithRange(ctx context.Context, req *NewIteratorWithRangeRequest) (*NewIteratorWithRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithRange not implemented")
+}
 func (*UnimplementedDatabaseServer) IteratorNext(ctx context.Context, req *IteratorNextRequest) (*IteratorNextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorNext not implemented")
 }
+func (*UnimplementedDatabaseServer) IteratorStream(req *IteratorStreamRequest, srv Database_IteratorStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method IteratorStream not implemented")
+}
 func (*UnimplementedDatabaseServer) IteratorError(ctx context.Context, req *IteratorErrorRequest) (*IteratorErrorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorError not implemented")
 }
 func (*UnimplementedDatabaseServer) IteratorRelease(ctx context.Context, req *IteratorReleaseRequest) (*IteratorReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorRelease not implemented")
 }
+func (*UnimplementedDatabaseServer) NewSnapshot(ctx context.Context, req *NewSnapshotRequest) (*NewSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewSnapshot not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotRelease(ctx context.Context, req *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRelease not implemented")
+}
 
 func RegisterDatabaseServer(s *grpc.Server, srv DatabaseServer) {
 	s.RegisterService(&_Database_serviceDesc, srv)
@@ -1290,6 +1983,42 @@ func _Database_Get_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_GetMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).GetMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/GetMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).GetMany(ctx, req.(*GetManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_HasMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).HasMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/HasMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).HasMany(ctx, req.(*HasManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PutRequest)
 	if err := dec(in); err != nil {
@@ -1398,6 +2127,32 @@ func _Database_WriteBatch_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_WriteBatchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DatabaseServer).WriteBatchStream(&databaseWriteBatchStreamServer{stream})
+}
+
+type Database_WriteBatchStreamServer interface {
+	SendAndClose(*WriteBatchResponse) error
+	Recv() (*WriteBatchRequest, error)
+	grpc.ServerStream
+}
+
+type databaseWriteBatchStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseWriteBatchStreamServer) SendAndClose(m *WriteBatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *databaseWriteBatchStreamServer) Recv() (*WriteBatchRequest, error) {
+	m := new(WriteBatchRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Database_NewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NewIteratorWithStartAndPrefixRequest)
 	if err := dec(in); err != nil {
@@ -1416,6 +2171,24 @@ func _Database_NewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewIteratorWithRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewIteratorWithRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewIteratorWithRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, req.(*NewIteratorWithRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_IteratorNext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IteratorNextRequest)
 	if err := dec(in); err != nil {
@@ -1434,6 +2207,27 @@ func _Database_IteratorNext_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_IteratorStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IteratorStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServer).IteratorStream(m, &databaseIteratorStreamServer{stream})
+}
+
+type Database_IteratorStreamServer interface {
+	Send(*IteratorStreamResponse) error
+	grpc.ServerStream
+}
+
+type databaseIteratorStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseIteratorStreamServer) Send(m *IteratorStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Database_IteratorError_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IteratorErrorRequest)
 	if err := dec(in); err != nil {
@@ -1470,6 +2264,42 @@ func _Database_IteratorRelease_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewSnapshot(ctx, req.(*NewSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, req.(*SnapshotReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Database_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcdbproto.Database",
 	HandlerType: (*DatabaseServer)(nil),
@@ -1482,6 +2312,14 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Database_Get_Handler,
 		},
+		{
+			MethodName: "GetMany",
+			Handler:    _Database_GetMany_Handler,
+		},
+		{
+			MethodName: "HasMany",
+			Handler:    _Database_HasMany_Handler,
+		},
 		{
 			MethodName: "Put",
 			Handler:    _Database_Put_Handler,
@@ -1510,6 +2348,10 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NewIteratorWithStartAndPrefix",
 			Handler:    _Database_NewIteratorWithStartAndPrefix_Handler,
 		},
+		{
+			MethodName: "NewIteratorWithRange",
+			Handler:    _Database_NewIteratorWithRange_Handler,
+		},
 		{
 			MethodName: "IteratorNext",
 			Handler:    _Database_IteratorNext_Handler,
@@ -1522,7 +2364,26 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IteratorRelease",
 			Handler:    _Database_IteratorRelease_Handler,
 		},
+		{
+			MethodName: "NewSnapshot",
+			Handler:    _Database_NewSnapshot_Handler,
+		},
+		{
+			MethodName: "SnapshotRelease",
+			Handler:    _Database_SnapshotRelease_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WriteBatchStream",
+			Handler:       _Database_WriteBatchStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "IteratorStream",
+			Handler:       _Database_IteratorStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpcdb.proto",
 }
@@ -24,6 +24,10 @@ var (
 		TestIteratorStart,
 		TestIteratorPrefix,
 		TestIteratorStartPrefix,
+		TestIteratorRange,
+		TestIteratorReverseRange,
+		TestIteratorReversePrefix,
+		TestIteratorReversePrefixEnd,
 		TestIteratorMemorySafety,
 		TestIteratorClosed,
 		TestStatNoPanic,
@@ -31,6 +35,21 @@ var (
 		TestMemorySafetyDatabase,
 		TestMemorySafetyBatch,
 	}
+
+	// SnapshotTests is a list of all tests of databases that implement
+	// Snapshotter
+	SnapshotTests = []func(t *testing.T, db Database){
+		TestSnapshot,
+		TestSnapshotIterator,
+		TestSnapshotRelease,
+		TestSnapshotClosed,
+	}
+
+	// SizerTests is a list of all tests of databases that implement Sizer
+	SizerTests = []func(t *testing.T, db Database){
+		TestSizeOf,
+		TestSizeOfClosed,
+	}
 )
 
 // TestSimpleKeyValue ...
@@ -705,6 +724,257 @@ func TestIteratorStartPrefix(t *testing.T, db Database) {
 	}
 }
 
+// TestIteratorRange ...
+func TestIteratorRange(t *testing.T, db Database) {
+	key1 := []byte("a")
+	value1 := []byte("world1")
+
+	key2 := []byte("b")
+	value2 := []byte("world2")
+
+	key3 := []byte("c")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewIteratorWithRange(key1, key3)
+	if iterator == nil {
+		t.Fatalf("db.NewIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value1) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value1)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if value := iterator.Value(); !bytes.Equal(value, value2) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value2)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if key := iterator.Key(); key != nil {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+	} else if value := iterator.Value(); value != nil {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: nil", value)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+
+	iterator = db.NewIteratorWithRange(key2, nil)
+	if iterator == nil {
+		t.Fatalf("db.NewIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key3) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key3)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+// TestIteratorReverseRange ...
+func TestIteratorReverseRange(t *testing.T, db Database) {
+	key1 := []byte("a")
+	value1 := []byte("world1")
+
+	key2 := []byte("b")
+	value2 := []byte("world2")
+
+	key3 := []byte("c")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIteratorWithRange(key2, nil)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key3) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key3)
+	} else if value := iterator.Value(); !bytes.Equal(value, value3) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value3)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if value := iterator.Value(); !bytes.Equal(value, value2) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value2)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if key := iterator.Key(); key != nil {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+	} else if value := iterator.Value(); value != nil {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: nil", value)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+
+	iterator = db.NewReverseIteratorWithRange(nil, key3)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value1) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value1)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+// TestIteratorReversePrefix ...
+func TestIteratorReversePrefix(t *testing.T, db Database) {
+	key1 := []byte("hello")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello\xff")
+	value2 := []byte("world2")
+
+	key3 := []byte("hi")
+	value3 := []byte("world3")
+
+	key4 := []byte("goodbye")
+	value4 := []byte("world4")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key4, value4); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIteratorWithPrefix([]byte("hello"))
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithPrefix returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if value := iterator.Value(); !bytes.Equal(value, value2) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value2)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value1) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value1)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+
+	iterator = db.NewReverseIteratorWithPrefix(nil)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithPrefix returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key3) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key3)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+// TestIteratorReversePrefixEnd ...
+func TestIteratorReversePrefixEnd(t *testing.T, db Database) {
+	key1 := []byte("aa")
+	value1 := []byte("world1")
+
+	key2 := []byte("ab1")
+	value2 := []byte("world2")
+
+	key3 := []byte("ab2")
+	value3 := []byte("world3")
+
+	// The first key after the prefix "ab"
+	key4 := []byte("ac")
+	value4 := []byte("world4")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key4, value4); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIteratorWithPrefix([]byte("ab"))
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithPrefix returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key3) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key3)
+	} else if value := iterator.Value(); !bytes.Equal(value, value3) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value3)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if value := iterator.Value(); !bytes.Equal(value, value2) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value2)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
 // TestIteratorMemorySafety ...
 func TestIteratorMemorySafety(t *testing.T, db Database) {
 	key1 := []byte("hello1")
@@ -855,3 +1125,262 @@ func TestCompactNoPanic(t *testing.T, db Database) {
 		t.Fatalf("Expected error %s on db.Close but got %s", ErrClosed, err)
 	}
 }
+
+func newSnapshot(t *testing.T, db Database) Snapshot {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		t.Fatalf("%T doesn't implement Snapshotter", db)
+	}
+	snapshot, err := snapshotter.NewSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.NewSnapshot: %s", err)
+	}
+	return snapshot
+}
+
+// TestSnapshot ...
+func TestSnapshot(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	value1b := []byte("world1b")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot := newSnapshot(t, db)
+	defer snapshot.Release()
+
+	if err := db.Put(key1, value1b); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Delete(key3); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	}
+
+	if v, err := snapshot.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value1, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	} else if has, err := snapshot.Has(key2); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if has {
+		t.Fatalf("snapshot.Has unexpectedly returned true on key %s", key2)
+	} else if v, err := snapshot.Get(key2); err != ErrNotFound {
+		t.Fatalf("Expected %s on snapshot.Get for missing key %s. Returned 0x%x", ErrNotFound, key2, v)
+	} else if v, err := snapshot.Get(key3); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value3, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value3)
+	}
+
+	// The database should still reflect the writes made after the snapshot
+	if v, err := db.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value1b, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value1b)
+	} else if has, err := db.Has(key3); err != nil {
+		t.Fatalf("Unexpected error on db.Has: %s", err)
+	} else if has {
+		t.Fatalf("db.Has unexpectedly returned true on key %s", key3)
+	}
+}
+
+// TestSnapshotIterator ...
+func TestSnapshotIterator(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("goodbye3")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot := newSnapshot(t, db)
+	defer snapshot.Release()
+
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	}
+
+	iterator := snapshot.NewIteratorWithPrefix([]byte("h"))
+	if iterator == nil {
+		t.Fatalf("snapshot.NewIteratorWithPrefix returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value1) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value1)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if key := iterator.Key(); key != nil {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+	} else if value := iterator.Value(); value != nil {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: nil", value)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+
+	iterator = snapshot.NewIteratorWithStart(key3)
+	if iterator == nil {
+		t.Fatalf("snapshot.NewIteratorWithStart returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key3) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key3)
+	} else if value := iterator.Value(); !bytes.Equal(value, value3) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value3)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value1) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value1)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+// TestSnapshotRelease ...
+func TestSnapshotRelease(t *testing.T, db Database) {
+	key := []byte("hello")
+	value := []byte("world")
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot := newSnapshot(t, db)
+	snapshot.Release()
+	snapshot.Release()
+
+	if _, err := snapshot.Get(key); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Get but got %s", ErrClosed, err)
+	} else if _, err := snapshot.Has(key); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Has but got %s", ErrClosed, err)
+	}
+
+	// Releasing the snapshot shouldn't affect the database
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+// TestSnapshotClosed ...
+func TestSnapshotClosed(t *testing.T, db Database) {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		t.Fatalf("%T doesn't implement Snapshotter", db)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("Unexpected error on db.Close: %s", err)
+	}
+
+	if _, err := snapshotter.NewSnapshot(); err != ErrClosed {
+		t.Fatalf("Expected %s on db.NewSnapshot but got %s", ErrClosed, err)
+	}
+}
+
+func newSizer(t *testing.T, db Database) Sizer {
+	sizer, ok := db.(Sizer)
+	if !ok {
+		t.Fatalf("%T doesn't implement Sizer", db)
+	}
+	return sizer
+}
+
+// TestSizeOf ...
+func TestSizeOf(t *testing.T, db Database) {
+	sizer := newSizer(t, db)
+
+	value := make([]byte, 1024)
+	for i := 0; i < 64; i++ {
+		for j := range value {
+			value[j] = byte(i * j)
+		}
+		if err := db.Put([]byte{'a', byte(i)}, value); err != nil {
+			t.Fatalf("Unexpected error on db.Put: %s", err)
+		}
+		if err := db.Put([]byte{'c', byte(i)}, value); err != nil {
+			t.Fatalf("Unexpected error on db.Put: %s", err)
+		}
+	}
+
+	// Some databases only include data that has been flushed to disk in their
+	// estimates
+	if err := db.Compact(nil, nil); err != nil {
+		t.Fatalf("Unexpected error on db.Compact: %s", err)
+	}
+
+	sizeA, err := SizeOfPrefix(sizer, []byte("a"))
+	if err != nil {
+		t.Fatalf("Unexpected error on db.SizeOf: %s", err)
+	} else if sizeA == 0 {
+		t.Fatalf("db.SizeOf returned 0 for a populated prefix")
+	}
+
+	sizeC, err := SizeOfPrefix(sizer, []byte("c"))
+	if err != nil {
+		t.Fatalf("Unexpected error on db.SizeOf: %s", err)
+	} else if sizeC == 0 {
+		t.Fatalf("db.SizeOf returned 0 for a populated prefix")
+	}
+
+	total, err := sizer.SizeOf(nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error on db.SizeOf: %s", err)
+	} else if total < sizeA+sizeC {
+		t.Fatalf("db.SizeOf returned %d for the whole database, which is less than the sum of its prefixes %d", total, sizeA+sizeC)
+	}
+
+	if size, err := sizer.SizeOf([]byte("d"), nil); err != nil {
+		t.Fatalf("Unexpected error on db.SizeOf: %s", err)
+	} else if size != 0 {
+		t.Fatalf("db.SizeOf returned %d for a range after all keys", size)
+	}
+}
+
+// TestSizeOfClosed ...
+func TestSizeOfClosed(t *testing.T, db Database) {
+	sizer := newSizer(t, db)
+
+	if err := db.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Unexpected error on db.Close: %s", err)
+	}
+	if _, err := sizer.SizeOf(nil, nil); err != ErrClosed {
+		t.Fatalf("Expected %s on db.SizeOf after close but got %v", ErrClosed, err)
+	}
+}
@@ -119,25 +119,103 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.mem == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return db.newIterator(
+		db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		nil,
+		prefix,
+		false,
+	)
+}
+
+// NewIteratorWithRange implements the database.Database interface
+func (db *Database) NewIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIterator(
+		db.db.NewIteratorWithRange(start, end),
+		start,
+		end,
+		nil,
+		false,
+	)
+}
+
+// NewReverseIteratorWithRange implements the database.Database interface
+func (db *Database) NewReverseIteratorWithRange(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
 
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIterator(
+		db.db.NewReverseIteratorWithRange(start, end),
+		start,
+		end,
+		nil,
+		true,
+	)
+}
+
+// NewReverseIteratorWithPrefix implements the database.Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIterator(
+		db.db.NewReverseIteratorWithPrefix(prefix),
+		nil,
+		nil,
+		prefix,
+		true,
+	)
+}
+
+// newIterator merges [dbIterator] with the in memory keys in [start, end) that
+// start with [prefix]. A nil [end] places no upper bound on the keys. If
+// [reverse] is true, [dbIterator] must iterate in descending order.
+// Assumes the lock is held.
+func (db *Database) newIterator(
+	dbIterator database.Iterator,
+	start,
+	end,
+	prefix []byte,
+	reverse bool,
+) database.Iterator {
 	startString := string(start)
+	endString := string(end)
 	prefixString := string(prefix)
 	keys := make([]string, 0, len(db.mem))
 	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
+		if strings.HasPrefix(key, prefixString) && key >= startString &&
+			(end == nil || key < endString) {
 			keys = append(keys, key)
 		}
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
+	// Keys need to be in sorted order
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
 	values := make([]valueDelete, len(keys))
 	for i, key := range keys {
 		values[i] = db.mem[key]
 	}
 
 	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: dbIterator,
 		keys:     keys,
 		values:   values,
+		reverse:  reverse,
 	}
 }
 
@@ -342,9 +420,20 @@ type iterator struct {
 	keys   []string
 	values []valueDelete
 
+	// If true, keys are iterated over in descending order
+	reverse bool
+
 	initialized, exhausted bool
 }
 
+// before returns true if [a] should be returned by the iterator before [b]
+func (it *iterator) before(a, b string) bool {
+	if it.reverse {
+		return a > b
+	}
+	return a < b
+}
+
 // Next moves the iterator to the next key/value pair. It returns whether the
 // iterator is exhausted. We must pay careful attention to set the proper values
 // based on if the in memory db or the underlying db should be read next
@@ -385,7 +474,7 @@ func (it *iterator) Next() bool {
 
 			dbStringKey := string(dbKey)
 			switch {
-			case memKey < dbStringKey:
+			case it.before(memKey, dbStringKey):
 				it.keys = it.keys[1:]
 				it.values = it.values[1:]
 
@@ -394,7 +483,7 @@ func (it *iterator) Next() bool {
 					it.value = memValue.value
 					return true
 				}
-			case dbStringKey < memKey:
+			case it.before(dbStringKey, memKey):
 				it.key = dbKey
 				it.value = it.Iterator.Value()
 				it.exhausted = !it.Iterator.Next()
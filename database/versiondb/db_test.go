@@ -179,6 +179,92 @@ func TestIterate(t *testing.T) {
 	}
 }
 
+func TestIterateReverse(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("a")
+	value1 := []byte("world1")
+
+	key2 := []byte("b")
+	value2 := []byte("world2")
+
+	key3 := []byte("c")
+	value3 := []byte("world3")
+
+	key4 := []byte("d")
+	value4 := []byte("world4")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key4, value4); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Commit(); err != nil {
+		t.Fatalf("Unexpected error on db.Commit: %s", err)
+	}
+
+	// Merge pending writes and deletes with the committed keys
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Delete(key3); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	} else if err := db.Put(key1, value4); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIteratorWithRange(key1, key4)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if value := iterator.Value(); !bytes.Equal(value, value2) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value2)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value4) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value4)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if key := iterator.Key(); key != nil {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+
+	iterator = db.NewReverseIteratorWithPrefix(nil)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithPrefix returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key4) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key4)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
 func TestCommit(t *testing.T) {
 	baseDB := memdb.New()
 	db := New(baseDB)
@@ -4,9 +4,11 @@ go 1.14
 
 require (
 	github.com/AppsFlyer/go-sundheit v0.2.0
+	github.com/DataDog/zstd v1.4.5
 	github.com/Microsoft/go-winio v0.4.14
 	github.com/btcsuite/btcutil v1.0.2
 	github.com/decred/dcrd/dcrec/secp256k1/v3 v3.0.0-20200627015759-01fd2de07837
+	github.com/dgraph-io/badger v1.6.2
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/golang/protobuf v1.4.2
 	github.com/golang/snappy v0.0.2-0.20200707131729-196ae77b8a26 // indirect
@@ -0,0 +1,413 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/engine"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+const (
+	// dbCommand is the first argument that runs the offline database tool
+	// rather than the node
+	dbCommand = "db"
+
+	dbCompactCommand = "compact"
+	dbCopyCommand    = "copy"
+	dbInspectCommand = "inspect"
+	dbVerifyCommand  = "verify"
+	dbRepairCommand  = "repair"
+
+	dbPathKey         = "path"
+	dbDstPathKey      = "dst-path"
+	dbDstTypeKey      = "dst-db-type"
+	dbStartKey        = "start"
+	dbLimitKey        = "limit"
+	dbPrefixLengthKey = "prefix-length"
+	dbStatsKey        = "stats"
+	dbChainIDsKey     = "chain-ids"
+	dbQuarantineKey   = "quarantine-path"
+	dbAllowAtomicKey  = "allow-atomic-chains"
+
+	// copyBatchSize, copyBatchKeys and copyBatchKeySize bound the number of
+	// value bytes, keys and key bytes written to the destination database at
+	// a time. Bounding the keys keeps batches of small or empty values within
+	// the transaction limits of the destination engine.
+	copyBatchSize    = 4 * 1024 * 1024
+	copyBatchKeys    = 10000
+	copyBatchKeySize = 1024 * 1024
+
+	// progressInterval is how often progress is reported while copying
+	progressInterval = 5 * time.Second
+
+	// defaultPrefixLength is the length of the prefixes that prefixdb prepends
+	// to the keys of each chain
+	defaultPrefixLength = 32
+)
+
+var (
+	errUnknownDBCommand    = errors.New("unknown db command")
+	errMissingDstPath      = errors.New("the destination path must be provided")
+	errDstNotEmpty         = errors.New("the destination already contains a database")
+	errChecksumMismatch    = errors.New("the destination database doesn't match the source database")
+	errInvalidPrefixLength = errors.New("prefix length must be positive")
+
+	defaultDBPath = filepath.Join(defaultDbDir, constants.MainnetName, dbVersion)
+)
+
+const dbUsage = `Usage: %s db <command> [flags]
+
+Runs maintenance on a node's database while the node is stopped.
+
+Commands:
+  compact  compacts a range of keys
+  copy     copies every key into a new database, possibly with a different engine
+  inspect  prints database stats and the number and size of keys per prefix
+  verify   reads every key, checking checksums and parsing values where the
+           format is known, and reports which chains have damaged data
+  repair   recovers a corrupted database and moves the data of damaged chains
+           aside, so that only those chains are re-bootstrapped. The chains of
+           the primary network exchange atomic transactions through shared
+           memory and may not re-bootstrap consistently with it, so they are
+           only moved aside with --allow-atomic-chains.
+`
+
+// runDBCommand runs the offline database tool with the arguments that follow
+// [dbCommand]. Output is written to [out].
+func runDBCommand(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprintf(out, dbUsage, constants.AppName)
+		return fmt.Errorf("%w: none provided", errUnknownDBCommand)
+	}
+
+	command := args[0]
+	fs := flag.NewFlagSet(fmt.Sprintf("%s %s %s", constants.AppName, dbCommand, command), flag.ContinueOnError)
+	fs.SetOutput(out)
+	path := fs.String(dbPathKey, defaultDBPath, "Directory of the database")
+	dbType := fs.String(dbTypeKey, "", "Storage engine of the database. Defaults to the engine the database was created with")
+
+	switch command {
+	case dbCompactCommand:
+		start := fs.String(dbStartKey, "", "Hex encoded key to start compacting at. Defaults to the first key")
+		limit := fs.String(dbLimitKey, "", "Hex encoded key to stop compacting at. Defaults to after the last key")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return compactCommand(out, *path, *dbType, *start, *limit)
+	case dbCopyCommand:
+		dstPath := fs.String(dbDstPathKey, "", "Directory to create the new database in")
+		dstType := fs.String(dbDstTypeKey, engine.LevelDB, fmt.Sprintf("Storage engine of the new database. One of %v", engine.Engines))
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return copyCommand(out, *path, *dbType, *dstPath, *dstType)
+	case dbInspectCommand:
+		prefixLength := fs.Int(dbPrefixLengthKey, defaultPrefixLength, "Number of leading key bytes to group keys by")
+		stats := fs.String(dbStatsKey, "leveldb.stats", "Comma separated list of Stat properties to print")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return inspectCommand(out, *path, *dbType, *prefixLength, *stats)
+	case dbVerifyCommand:
+		networkName := fs.String(networkNameKey, defaultNetworkName, "Network whose genesis chains are attributed their keys")
+		chainIDs := fs.String(dbChainIDsKey, "", "Comma separated list of the IDs of other chains to verify, such as subnet chains")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return verifyCommand(out, *path, *dbType, *networkName, *chainIDs)
+	case dbRepairCommand:
+		chainIDs := fs.String(dbChainIDsKey, "", "Comma separated list of the IDs of the chains to re-bootstrap")
+		quarantinePath := fs.String(dbQuarantineKey, "", "Directory of the database to move the chains' data to. Defaults to the database's directory with a -quarantine suffix")
+		networkName := fs.String(networkNameKey, defaultNetworkName, "Network whose chains exchange atomic transactions through shared memory")
+		allowAtomic := fs.Bool(dbAllowAtomicKey, false, "Move the data of chains that exchange atomic transactions aside, even though they may not re-bootstrap consistently with shared memory")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return repairCommand(out, *path, *dbType, *networkName, *chainIDs, *quarantinePath, *allowAtomic)
+	default:
+		fmt.Fprintf(out, dbUsage, constants.AppName)
+		return fmt.Errorf("%w %q", errUnknownDBCommand, command)
+	}
+}
+
+func compactCommand(out io.Writer, path, dbType, startStr, limitStr string) error {
+	start, err := hex.DecodeString(startStr)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", dbStartKey, err)
+	}
+	limit, err := hex.DecodeString(limitStr)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", dbLimitKey, err)
+	}
+	if len(start) == 0 {
+		start = nil
+	}
+	if len(limit) == 0 {
+		limit = nil
+	}
+
+	// Compacting rewrites the database, so it can't be opened read-only. As
+	// opening a database for writing creates it if it doesn't exist, the
+	// database is checked to exist first.
+	recorded, err := engine.Read(path)
+	if err != nil {
+		return err
+	}
+	if recorded == "" {
+		return fmt.Errorf("no database found at %s", path)
+	}
+	if dbType == "" {
+		dbType = recorded
+	}
+	db, err := engine.Open(path, dbType)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	fmt.Fprintf(out, "compacting %s\n", path)
+	startTime := time.Now()
+	if err := db.Compact(start, limit); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "compacted %s in %s\n", path, time.Since(startTime))
+	return nil
+}
+
+func copyCommand(out io.Writer, path, dbType, dstPath, dstType string) error {
+	if dstPath == "" {
+		return errMissingDstPath
+	}
+	if existing, err := engine.Read(dstPath); err != nil {
+		return err
+	} else if existing != "" {
+		return fmt.Errorf("%w: %s", errDstNotEmpty, dstPath)
+	}
+
+	src, err := engine.OpenReadOnly(path, dbType)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := engine.Open(dstPath, dstType)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	fmt.Fprintf(out, "copying %s to %s\n", path, dstPath)
+	srcSum, err := copyDB(out, src, dst, progressInterval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "verifying %s\n", dstPath)
+	dstSum, err := checksumDB(dst)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "source:      %s\n", srcSum)
+	fmt.Fprintf(out, "destination: %s\n", dstSum)
+	if !srcSum.equal(dstSum) {
+		return errChecksumMismatch
+	}
+	return nil
+}
+
+func inspectCommand(out io.Writer, path, dbType string, prefixLength int, stats string) error {
+	if prefixLength <= 0 {
+		return errInvalidPrefixLength
+	}
+
+	db, err := engine.OpenReadOnly(path, dbType)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	for _, property := range strings.Split(stats, ",") {
+		property = strings.TrimSpace(property)
+		if property == "" {
+			continue
+		}
+		stat, err := db.Stat(property)
+		if err != nil {
+			fmt.Fprintf(out, "%s: unavailable (%s)\n", property, err)
+			continue
+		}
+		fmt.Fprintf(out, "%s:\n%s\n", property, stat)
+	}
+
+	prefixes, err := inspectPrefixes(db, prefixLength)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PREFIX\tKEYS\tKEY BYTES\tVALUE BYTES")
+	total := prefixStats{}
+	for _, prefix := range prefixes {
+		fmt.Fprintf(w, "%x\t%d\t%d\t%d\n", prefix.prefix, prefix.numKeys, prefix.keySize, prefix.valueSize)
+		total.numKeys += prefix.numKeys
+		total.keySize += prefix.keySize
+		total.valueSize += prefix.valueSize
+	}
+	fmt.Fprintf(w, "total\t%d\t%d\t%d\n", total.numKeys, total.keySize, total.valueSize)
+	return w.Flush()
+}
+
+// dbChecksum summarizes the contents of a database
+type dbChecksum struct {
+	numKeys uint64
+	hash    []byte
+}
+
+func (c dbChecksum) String() string {
+	return fmt.Sprintf("%d keys, sha256 %x", c.numKeys, c.hash)
+}
+
+func (c dbChecksum) equal(other dbChecksum) bool {
+	return c.numKeys == other.numKeys && bytes.Equal(c.hash, other.hash)
+}
+
+// checksummer hashes key/value pairs in the order they are added
+type checksummer struct {
+	numKeys uint64
+	lengths [8]byte
+	writer  hash.Hash
+}
+
+func newChecksummer() *checksummer { return &checksummer{writer: sha256.New()} }
+
+func (c *checksummer) add(key, value []byte) {
+	// Lengths are included so that different splits of the same bytes into
+	// keys and values have different checksums
+	binary.BigEndian.PutUint32(c.lengths[:4], uint32(len(key)))
+	binary.BigEndian.PutUint32(c.lengths[4:], uint32(len(value)))
+	_, _ = c.writer.Write(c.lengths[:])
+	_, _ = c.writer.Write(key)
+	_, _ = c.writer.Write(value)
+	c.numKeys++
+}
+
+func (c *checksummer) checksum() dbChecksum {
+	return dbChecksum{
+		numKeys: c.numKeys,
+		hash:    c.writer.Sum(nil),
+	}
+}
+
+// copyDB writes every key in [src] into [dst], reporting progress to [out]
+// every [interval]. Returns the checksum of the copied keys.
+func copyDB(out io.Writer, src database.Iteratee, dst database.Batcher, interval time.Duration) (dbChecksum, error) {
+	it := src.NewIterator()
+	defer it.Release()
+
+	sum := newChecksummer()
+	batch := dst.NewBatch()
+	batchKeys, batchKeySize := 0, 0
+	var copiedBytes uint64
+	startTime := time.Now()
+	lastReport := startTime
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		sum.add(key, value)
+		copiedBytes += uint64(len(key) + len(value))
+
+		if err := batch.Put(key, value); err != nil {
+			return dbChecksum{}, err
+		}
+		batchKeys++
+		batchKeySize += len(key)
+		if batch.ValueSize() >= copyBatchSize || batchKeys >= copyBatchKeys || batchKeySize >= copyBatchKeySize {
+			if err := batch.Write(); err != nil {
+				return dbChecksum{}, err
+			}
+			batch.Reset()
+			batchKeys, batchKeySize = 0, 0
+		}
+
+		if now := time.Now(); now.Sub(lastReport) >= interval {
+			lastReport = now
+			fmt.Fprintf(out, "copied %d keys (%d bytes) in %s\n", sum.numKeys, copiedBytes, now.Sub(startTime))
+		}
+	}
+	if err := it.Error(); err != nil {
+		return dbChecksum{}, err
+	}
+	if err := batch.Write(); err != nil {
+		return dbChecksum{}, err
+	}
+	fmt.Fprintf(out, "copied %d keys (%d bytes) in %s\n", sum.numKeys, copiedBytes, time.Since(startTime))
+	return sum.checksum(), nil
+}
+
+// checksumDB returns the checksum of every key in [db]
+func checksumDB(db database.Iteratee) (dbChecksum, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	sum := newChecksummer()
+	for it.Next() {
+		sum.add(it.Key(), it.Value())
+	}
+	return sum.checksum(), it.Error()
+}
+
+// prefixStats is the number and size of the keys that share a prefix
+type prefixStats struct {
+	prefix    []byte
+	numKeys   uint64
+	keySize   uint64
+	valueSize uint64
+}
+
+// inspectPrefixes groups the keys in [db] by their first [prefixLength] bytes.
+// Keys shorter than [prefixLength] are grouped by the entire key. Returns the
+// groups in key order.
+func inspectPrefixes(db database.Iteratee, prefixLength int) ([]*prefixStats, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	prefixes := []*prefixStats(nil)
+	for it.Next() {
+		key := it.Key()
+		prefix := key
+		if len(prefix) > prefixLength {
+			prefix = prefix[:prefixLength]
+		}
+
+		// Keys are iterated in order, so keys with the same prefix are
+		// contiguous
+		var stats *prefixStats
+		if numPrefixes := len(prefixes); numPrefixes > 0 && bytes.Equal(prefixes[numPrefixes-1].prefix, prefix) {
+			stats = prefixes[numPrefixes-1]
+		} else {
+			stats = &prefixStats{prefix: prefix}
+			prefixes = append(prefixes, stats)
+		}
+		stats.numKeys++
+		stats.keySize += uint64(len(key))
+		stats.valueSize += uint64(len(it.Value()))
+	}
+	return prefixes, it.Error()
+}
@@ -0,0 +1,344 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/engine"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestCopyDB(t *testing.T) {
+	src := memdb.New()
+	assert.NoError(t, src.Put([]byte{1}, []byte{2}))
+	assert.NoError(t, src.Put([]byte{3, 4}, []byte{}))
+	assert.NoError(t, src.Put([]byte{5}, []byte{6, 7}))
+
+	dst := memdb.New()
+	srcSum, err := copyDB(ioutil.Discard, src, dst, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), srcSum.numKeys)
+
+	dstSum, err := checksumDB(dst)
+	assert.NoError(t, err)
+	assert.True(t, srcSum.equal(dstSum))
+
+	// Moving a byte between a key and its value changes the checksum
+	other := memdb.New()
+	assert.NoError(t, other.Put([]byte{1, 2}, []byte{}))
+	assert.NoError(t, other.Put([]byte{3, 4}, []byte{}))
+	assert.NoError(t, other.Put([]byte{5}, []byte{6, 7}))
+	otherSum, err := checksumDB(other)
+	assert.NoError(t, err)
+	assert.False(t, srcSum.equal(otherSum))
+}
+
+// countingBatcher counts the batches written to a database
+type countingBatcher struct {
+	database.Database
+	writes int
+}
+
+func (b *countingBatcher) NewBatch() database.Batch {
+	return &countingBatch{Batch: b.Database.NewBatch(), batcher: b}
+}
+
+type countingBatch struct {
+	database.Batch
+	batcher *countingBatcher
+}
+
+func (b *countingBatch) Write() error {
+	b.batcher.writes++
+	return b.Batch.Write()
+}
+
+func TestCopyDBBatchesKeys(t *testing.T) {
+	// Empty values never fill a batch by value size
+	src := memdb.New()
+	for i := 0; i < copyBatchKeys+1; i++ {
+		assert.NoError(t, src.Put([]byte(fmt.Sprintf("key%d", i)), nil))
+	}
+
+	dst := &countingBatcher{Database: memdb.New()}
+	srcSum, err := copyDB(ioutil.Discard, src, dst, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dst.writes)
+
+	dstSum, err := checksumDB(dst)
+	assert.NoError(t, err)
+	assert.True(t, srcSum.equal(dstSum))
+}
+
+func TestInspectPrefixes(t *testing.T) {
+	db := memdb.New()
+	assert.NoError(t, db.Put([]byte{1}, []byte{1, 2, 3}))
+	assert.NoError(t, db.Put([]byte{1, 1, 1}, []byte{1}))
+	assert.NoError(t, db.Put([]byte{1, 1, 2}, []byte{1, 2}))
+	assert.NoError(t, db.Put([]byte{2, 1, 1}, nil))
+
+	prefixes, err := inspectPrefixes(db, 2)
+	assert.NoError(t, err)
+	assert.Len(t, prefixes, 3)
+
+	assert.Equal(t, []byte{1}, prefixes[0].prefix)
+	assert.Equal(t, uint64(1), prefixes[0].numKeys)
+	assert.Equal(t, uint64(3), prefixes[0].valueSize)
+
+	assert.Equal(t, []byte{1, 1}, prefixes[1].prefix)
+	assert.Equal(t, uint64(2), prefixes[1].numKeys)
+	assert.Equal(t, uint64(6), prefixes[1].keySize)
+	assert.Equal(t, uint64(3), prefixes[1].valueSize)
+
+	assert.Equal(t, []byte{2, 1}, prefixes[2].prefix)
+	assert.Equal(t, uint64(1), prefixes[2].numKeys)
+}
+
+func TestDBCommandCopy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbcommand")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	srcPath := filepath.Join(dir, "src")
+	dstPath := filepath.Join(dir, "dst")
+
+	src, err := engine.Open(srcPath, engine.LevelDB)
+	assert.NoError(t, err)
+	assert.NoError(t, src.Put([]byte("hello"), []byte("world")))
+	assert.NoError(t, src.Close())
+
+	out := &bytes.Buffer{}
+	err = runDBCommand([]string{
+		dbCopyCommand,
+		"--" + dbPathKey, srcPath,
+		"--" + dbDstPathKey, dstPath,
+		"--" + dbDstTypeKey, engine.BadgerDB,
+	}, out)
+	assert.NoError(t, err, out.String())
+
+	dst, err := engine.OpenReadOnly(dstPath, engine.BadgerDB)
+	assert.NoError(t, err)
+	value, err := dst.Get([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("world"), value)
+	assert.NoError(t, dst.Close())
+
+	// Copying into an existing database fails
+	err = runDBCommand([]string{
+		dbCopyCommand,
+		"--" + dbPathKey, srcPath,
+		"--" + dbDstPathKey, dstPath,
+	}, out)
+	assert.Error(t, err)
+
+	err = runDBCommand([]string{
+		dbInspectCommand,
+		"--" + dbPathKey, dstPath,
+	}, out)
+	assert.NoError(t, err)
+
+	// Compacting a path without a database doesn't create one, even if the
+	// engine is given
+	emptyPath := filepath.Join(dir, "empty")
+	err = runDBCommand([]string{
+		dbCompactCommand,
+		"--" + dbPathKey, emptyPath,
+		"--" + dbTypeKey, engine.LevelDB,
+	}, out)
+	assert.Error(t, err)
+	_, err = os.Stat(emptyPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestVerifyChains(t *testing.T) {
+	db := memdb.New()
+	chainID := ids.GenerateTestID()
+	dbs := chains.Databases(db, chainID)
+	assert.NoError(t, dbs[chains.VMDatabaseName].Put([]byte("hello"), []byte("world")))
+
+	// An unparsable validator of the primary network
+	platformDBs := chains.Databases(db, constants.PlatformChainID)
+	stopDB := prefixdb.NewNested([]byte(constants.PrimaryNetworkID.String()+"stop"), platformDBs[chains.VMDatabaseName])
+	assert.NoError(t, stopDB.Put([]byte{1}, []byte{2}))
+
+	verifications := verifyChains(db, []ids.ID{constants.PlatformChainID, chainID})
+	assert.Len(t, verifications, 2*len(chains.DatabaseNames))
+	for _, v := range verifications {
+		switch {
+		case v.chainID == chainID && v.name == chains.VMDatabaseName:
+			assert.Equal(t, uint64(1), v.numKeys)
+			assert.False(t, v.damaged())
+		case v.chainID == constants.PlatformChainID && v.name == chains.VMDatabaseName:
+			assert.Equal(t, uint64(1), v.numKeys)
+			assert.NoError(t, v.err)
+			assert.Len(t, v.invalid, 1)
+			assert.Equal(t, []byte{1}, v.invalid[0].key)
+		default:
+			assert.Zero(t, v.numKeys)
+			assert.False(t, v.damaged())
+		}
+	}
+}
+
+func TestQuarantineChain(t *testing.T) {
+	db := memdb.New()
+	chainID := ids.GenerateTestID()
+	otherChainID := ids.GenerateTestID()
+	dbs := chains.Databases(db, chainID)
+	otherDBs := chains.Databases(db, otherChainID)
+	for _, name := range chains.DatabaseNames {
+		assert.NoError(t, dbs[name].Put([]byte(name), []byte("value")))
+		assert.NoError(t, otherDBs[name].Put([]byte(name), []byte("value")))
+	}
+
+	quarantineDB := memdb.New()
+	numKeys, err := quarantineChain(db, quarantineDB, chainID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(len(chains.DatabaseNames)), numKeys)
+
+	quarantineDBs := chains.Databases(quarantineDB, chainID)
+	for _, name := range chains.DatabaseNames {
+		has, err := dbs[name].Has([]byte(name))
+		assert.NoError(t, err)
+		assert.False(t, has)
+
+		value, err := quarantineDBs[name].Get([]byte(name))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value"), value)
+
+		has, err = otherDBs[name].Has([]byte(name))
+		assert.NoError(t, err)
+		assert.True(t, has)
+	}
+}
+
+func TestAtomicChains(t *testing.T) {
+	chainAliases, err := networkChains(constants.LocalName)
+	assert.NoError(t, err)
+	atomicChainIDs, err := atomicChains(constants.LocalName)
+	assert.NoError(t, err)
+
+	for chainID, aliases := range chainAliases {
+		switch aliases[0] {
+		case "P", "X", "C":
+			assert.True(t, atomicChainIDs.Contains(chainID), "%s should exchange atomic transactions", aliases[0])
+		default:
+			assert.False(t, atomicChainIDs.Contains(chainID), "%s shouldn't exchange atomic transactions", aliases[0])
+		}
+	}
+	assert.Equal(t, 3, atomicChainIDs.Len())
+}
+
+func TestDBCommandRepairAtomicChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbcommand")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "db")
+	db, err := engine.Open(path, engine.LevelDB)
+	assert.NoError(t, err)
+	vmDB := chains.Databases(db, constants.PlatformChainID)[chains.VMDatabaseName]
+	assert.NoError(t, vmDB.Put([]byte("key"), []byte("value")))
+	assert.NoError(t, db.Close())
+
+	repairArgs := []string{
+		dbRepairCommand,
+		"--" + dbPathKey, path,
+		"--" + networkNameKey, constants.LocalName,
+		"--" + dbChainIDsKey, constants.PlatformChainID.String(),
+	}
+	out := &bytes.Buffer{}
+	err = runDBCommand(repairArgs, out)
+	assert.True(t, errors.Is(err, errAtomicChain), "expected %s but got %v", errAtomicChain, err)
+
+	db, err = engine.Open(path, engine.LevelDB)
+	assert.NoError(t, err)
+	vmDB = chains.Databases(db, constants.PlatformChainID)[chains.VMDatabaseName]
+	has, err := vmDB.Has([]byte("key"))
+	assert.NoError(t, err)
+	assert.True(t, has, "the chain's data shouldn't have been moved")
+	assert.NoError(t, db.Close())
+
+	out.Reset()
+	err = runDBCommand(append(repairArgs, "--"+dbAllowAtomicKey), out)
+	assert.NoError(t, err, out.String())
+
+	db, err = engine.Open(path, engine.LevelDB)
+	assert.NoError(t, err)
+	vmDB = chains.Databases(db, constants.PlatformChainID)[chains.VMDatabaseName]
+	has, err = vmDB.Has([]byte("key"))
+	assert.NoError(t, err)
+	assert.False(t, has, "the chain's data should have been moved")
+	assert.NoError(t, db.Close())
+}
+
+func TestDBCommandVerifyAndRepair(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbcommand")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "db")
+	chainID := ids.GenerateTestID()
+
+	db, err := engine.Open(path, engine.LevelDB)
+	assert.NoError(t, err)
+	vmDB := chains.Databases(db, chainID)[chains.VMDatabaseName]
+	value := make([]byte, 1024)
+	for i := 0; i < 1024; i++ {
+		assert.NoError(t, vmDB.Put([]byte{byte(i >> 8), byte(i)}, value))
+	}
+	// Flush the keys into tables
+	assert.NoError(t, db.Compact(nil, nil))
+	assert.NoError(t, db.Close())
+
+	out := &bytes.Buffer{}
+	verifyArgs := []string{
+		dbVerifyCommand,
+		"--" + dbPathKey, path,
+		"--" + dbChainIDsKey, chainID.String(),
+	}
+	assert.NoError(t, runDBCommand(verifyArgs, out), out.String())
+
+	// Corrupt a block of a table
+	tables, err := filepath.Glob(filepath.Join(path, "*.ldb"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tables)
+	tableBytes, err := ioutil.ReadFile(tables[0])
+	assert.NoError(t, err)
+	for i := 100; i < 200; i++ {
+		tableBytes[i] ^= 0xff
+	}
+	assert.NoError(t, ioutil.WriteFile(tables[0], tableBytes, 0600))
+
+	out.Reset()
+	err = runDBCommand(verifyArgs, out)
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), chainID.String())
+
+	out.Reset()
+	err = runDBCommand([]string{
+		dbRepairCommand,
+		"--" + dbPathKey, path,
+		"--" + dbChainIDsKey, chainID.String(),
+	}, out)
+	assert.NoError(t, err, out.String())
+
+	out.Reset()
+	assert.NoError(t, runDBCommand(verifyArgs, out), out.String())
+}
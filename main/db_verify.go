@@ -0,0 +1,417 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/engine"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/evm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var (
+	errCorruptionFound = errors.New("the database is damaged")
+	errNoChainIDs      = errors.New("at least one chain ID must be provided")
+	errAtomicChain     = errors.New("the chain exchanges atomic transactions through shared memory")
+)
+
+// keyError is a key whose value couldn't be read or parsed
+type keyError struct {
+	key []byte
+	err error
+}
+
+// dbVerification is the result of verifying one of a chain's databases
+type dbVerification struct {
+	chainID ids.ID
+	name    string
+	numKeys uint64
+	size    uint64
+	// The last key that was read before [err] stopped the iteration
+	lastKey []byte
+	// The error that stopped the iteration over the database, if any
+	err error
+	// Keys that could be read, but whose values couldn't be parsed
+	invalid []keyError
+}
+
+func (v *dbVerification) damaged() bool { return v.err != nil || len(v.invalid) > 0 }
+
+func (v *dbVerification) status() string {
+	switch {
+	case v.err != nil && v.lastKey == nil:
+		return fmt.Sprintf("unreadable: %s", v.err)
+	case v.err != nil:
+		return fmt.Sprintf("unreadable after key %x: %s", v.lastKey, v.err)
+	case len(v.invalid) > 0:
+		return fmt.Sprintf("%d invalid values", len(v.invalid))
+	default:
+		return "ok"
+	}
+}
+
+// verifyCommand reads every key of the database in [path], attributes the keys
+// to the chains of network [networkName] and to the chains in [chainIDsStr],
+// and reports which chains have data that can't be read.
+func verifyCommand(out io.Writer, path, dbType, networkName, chainIDsStr string) error {
+	chainAliases, err := networkChains(networkName)
+	if err != nil {
+		return err
+	}
+	chainIDs, err := parseChainIDs(chainIDsStr)
+	if err != nil {
+		return err
+	}
+	for _, chainID := range chainIDs {
+		if _, ok := chainAliases[chainID]; !ok {
+			chainAliases[chainID] = nil
+		}
+	}
+
+	db, err := engine.OpenReadOnly(path, dbType)
+	if leveldb.IsCorrupted(err) {
+		fmt.Fprintf(out, "%s can't be opened: %s\n", path, err)
+		fmt.Fprintf(out, "run `%s %s %s` to recover it\n", constants.AppName, dbCommand, dbRepairCommand)
+		return fmt.Errorf("%w: %s", errCorruptionFound, err)
+	}
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	allChainIDs := make([]ids.ID, 0, len(chainAliases))
+	for chainID := range chainAliases {
+		allChainIDs = append(allChainIDs, chainID)
+	}
+	sort.Slice(allChainIDs, func(i, j int) bool {
+		return allChainIDs[i].String() < allChainIDs[j].String()
+	})
+	verifications := verifyChains(db, allChainIDs)
+
+	// Keys outside of the known chains' databases, such as shared memory, the
+	// keystore and the data of other chains, are only checked to be readable
+	total := &dbVerification{}
+	walkDB(db, total)
+	other := &dbVerification{
+		name:    "other",
+		numKeys: total.numKeys,
+		size:    total.size,
+		lastKey: total.lastKey,
+		err:     total.err,
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "CHAIN\tDATABASE\tKEYS\tBYTES\tSTATUS")
+	damaged := []ids.ID(nil)
+	for _, v := range verifications {
+		// If the walk over the entire database stopped early, the chains may
+		// have more readable keys than were counted in total
+		other.numKeys = subtractOrZero(other.numKeys, v.numKeys)
+		other.size = subtractOrZero(other.size, v.size)
+		if v.numKeys == 0 && !v.damaged() {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", chainName(v.chainID, chainAliases[v.chainID]), v.name, v.numKeys, v.size, v.status())
+		if v.damaged() && (len(damaged) == 0 || damaged[len(damaged)-1] != v.chainID) {
+			damaged = append(damaged, v.chainID)
+		}
+	}
+	fmt.Fprintf(w, "-\t%s\t%d\t%d\t%s\n", other.name, other.numKeys, other.size, other.status())
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, v := range verifications {
+		for _, invalid := range v.invalid {
+			fmt.Fprintf(out, "%s %s: invalid value at key %x: %s\n", chainName(v.chainID, chainAliases[v.chainID]), v.name, invalid.key, invalid.err)
+		}
+	}
+
+	if len(damaged) == 0 && !other.damaged() {
+		fmt.Fprintln(out, "no damage found")
+		return nil
+	}
+	if len(damaged) > 0 {
+		atomicChainIDs, err := atomicChains(networkName)
+		if err != nil {
+			return err
+		}
+		damagedStrs := make([]string, len(damaged))
+		atomicDamaged := false
+		for i, chainID := range damaged {
+			damagedStrs[i] = chainID.String()
+			atomicDamaged = atomicDamaged || atomicChainIDs.Contains(chainID)
+		}
+		fmt.Fprintf(out, "to re-bootstrap only the damaged chains, run `%s %s %s --%s=%s --%s=%s`\n",
+			constants.AppName, dbCommand, dbRepairCommand, networkNameKey, networkName, dbChainIDsKey, strings.Join(damagedStrs, ","))
+		if atomicDamaged {
+			fmt.Fprintf(out, "damaged chains of the primary network exchange atomic transactions through shared memory and may not re-bootstrap consistently with it. They are only moved aside with --%s\n",
+				dbAllowAtomicKey)
+		}
+	}
+	return errCorruptionFound
+}
+
+// repairCommand recovers the database in [path] if it's a LevelDB database,
+// and then moves the data of each of the chains in [chainIDsStr] into the
+// database in [quarantinePath], so that only those chains are re-bootstrapped
+// when the node is restarted. Unless [allowAtomic] is set, chains of network
+// [networkName] that exchange atomic transactions are refused, as the shared
+// memory they wrote to isn't rolled back with them.
+func repairCommand(out io.Writer, path, dbType, networkName, chainIDsStr, quarantinePath string, allowAtomic bool) error {
+	chainIDs, err := parseChainIDs(chainIDsStr)
+	if err != nil {
+		return err
+	}
+	if len(chainIDs) == 0 {
+		return errNoChainIDs
+	}
+	if !allowAtomic {
+		atomicChainIDs, err := atomicChains(networkName)
+		if err != nil {
+			return err
+		}
+		for _, chainID := range chainIDs {
+			if atomicChainIDs.Contains(chainID) {
+				return fmt.Errorf("%w: refusing to move chain %s aside without --%s", errAtomicChain, chainID, dbAllowAtomicKey)
+			}
+		}
+	}
+	if quarantinePath == "" {
+		quarantinePath = path + "-quarantine"
+	}
+
+	if dbType == "" {
+		if dbType, err = engine.Read(path); err != nil {
+			return err
+		}
+		if dbType == "" {
+			return fmt.Errorf("no database found at %s", path)
+		}
+	}
+	if dbType == engine.LevelDB {
+		fmt.Fprintf(out, "recovering %s\n", path)
+		if err := leveldb.Recover(path); err != nil {
+			return fmt.Errorf("couldn't recover %s: %w", path, err)
+		}
+	}
+
+	db, err := engine.Open(path, dbType)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	quarantineDB, err := engine.Open(quarantinePath, dbType)
+	if err != nil {
+		return err
+	}
+	defer quarantineDB.Close()
+
+	for _, chainID := range chainIDs {
+		fmt.Fprintf(out, "moving the data of chain %s to %s\n", chainID, quarantinePath)
+		numKeys, err := quarantineChain(db, quarantineDB, chainID)
+		if err != nil {
+			return fmt.Errorf("couldn't quarantine chain %s after moving %d keys: %w", chainID, numKeys, err)
+		}
+		fmt.Fprintf(out, "moved %d keys of chain %s\n", numKeys, chainID)
+	}
+	return nil
+}
+
+// verifyChains reads every key of each of the databases of [chainIDs] in
+// [db]. The values of databases whose format is known are also parsed.
+func verifyChains(db database.Database, chainIDs []ids.ID) []*dbVerification {
+	verifications := []*dbVerification(nil)
+	for _, chainID := range chainIDs {
+		dbs := chains.Databases(db, chainID)
+		for _, name := range chains.DatabaseNames {
+			v := &dbVerification{
+				chainID: chainID,
+				name:    name,
+			}
+			walkDB(dbs[name], v)
+			verifications = append(verifications, v)
+
+			if chainID != constants.PlatformChainID || name != chains.VMDatabaseName || v.err != nil {
+				continue
+			}
+			// The platform chain's VM is known, so its validator queues can be
+			// parsed
+			v.err = platformvm.VerifyStakers(dbs[name], constants.PrimaryNetworkID, func(queue string, key []byte, err error) {
+				v.invalid = append(v.invalid, keyError{
+					key: key,
+					err: fmt.Errorf("%s queue: %w", queue, err),
+				})
+			})
+		}
+	}
+	return verifications
+}
+
+// walkDB reads every key and value of [db] and records the number and size of
+// the keys, and any error that stopped the iteration, in [v]
+func walkDB(db database.Iteratee, v *dbVerification) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		v.numKeys++
+		v.size += uint64(len(key) + len(it.Value()))
+		v.lastKey = append(v.lastKey[:0], key...)
+	}
+	v.err = it.Error()
+	if v.err == nil {
+		v.lastKey = nil
+	}
+}
+
+// quarantineChain moves the data of [chainID] from [db] into [quarantineDB]
+// and returns the number of keys that were moved
+func quarantineChain(db, quarantineDB database.Database, chainID ids.ID) (uint64, error) {
+	dbs := chains.Databases(db, chainID)
+	quarantineDBs := chains.Databases(quarantineDB, chainID)
+
+	numKeys := uint64(0)
+	for _, name := range chains.DatabaseNames {
+		src := dbs[name]
+		dst := quarantineDBs[name]
+
+		it := src.NewIterator()
+		srcBatch := src.NewBatch()
+		dstBatch := dst.NewBatch()
+		for it.Next() {
+			key := it.Key()
+			if err := dstBatch.Put(key, it.Value()); err != nil {
+				it.Release()
+				return numKeys, err
+			}
+			if err := srcBatch.Delete(key); err != nil {
+				it.Release()
+				return numKeys, err
+			}
+			numKeys++
+
+			if dstBatch.ValueSize() >= copyBatchSize {
+				// The data is written to the quarantine before it's deleted
+				if err := dstBatch.Write(); err != nil {
+					it.Release()
+					return numKeys, err
+				}
+				if err := srcBatch.Write(); err != nil {
+					it.Release()
+					return numKeys, err
+				}
+				dstBatch.Reset()
+				srcBatch.Reset()
+			}
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return numKeys, err
+		}
+		if err := dstBatch.Write(); err != nil {
+			return numKeys, err
+		}
+		if err := srcBatch.Write(); err != nil {
+			return numKeys, err
+		}
+	}
+	return numKeys, nil
+}
+
+// networkChains returns the aliases of each of the chains in the genesis of
+// network [networkName]
+func networkChains(networkName string) (map[ids.ID][]string, error) {
+	networkID, err := constants.NetworkID(networkName)
+	if err != nil {
+		return nil, err
+	}
+	genesisBytes, _, err := genesis.Genesis(networkID)
+	if err != nil {
+		return nil, err
+	}
+	_, chainAliases, _, err := genesis.Aliases(genesisBytes)
+	return chainAliases, err
+}
+
+// atomicChains returns the IDs of the chains of network [networkName] that
+// exchange atomic transactions through shared memory: the platform chain and
+// the AVM and EVM chains created in its genesis
+func atomicChains(networkName string) (ids.Set, error) {
+	networkID, err := constants.NetworkID(networkName)
+	if err != nil {
+		return nil, err
+	}
+	genesisBytes, _, err := genesis.Genesis(networkID)
+	if err != nil {
+		return nil, err
+	}
+	g := &platformvm.Genesis{}
+	if _, err := platformvm.GenesisCodec.Unmarshal(genesisBytes, g); err != nil {
+		return nil, err
+	}
+	if err := g.Initialize(); err != nil {
+		return nil, err
+	}
+
+	chainIDs := ids.Set{}
+	chainIDs.Add(constants.PlatformChainID)
+	for _, chain := range g.Chains {
+		createChainTx, ok := chain.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+		if !ok {
+			continue
+		}
+		if createChainTx.VMID == avm.ID || createChainTx.VMID == evm.ID {
+			chainIDs.Add(chain.ID())
+		}
+	}
+	return chainIDs, nil
+}
+
+// parseChainIDs parses the comma separated list of chain IDs [chainIDsStr]
+func parseChainIDs(chainIDsStr string) ([]ids.ID, error) {
+	chainIDs := []ids.ID(nil)
+	for _, chainIDStr := range strings.Split(chainIDsStr, ",") {
+		chainIDStr = strings.TrimSpace(chainIDStr)
+		if chainIDStr == "" {
+			continue
+		}
+		chainID, err := ids.FromString(chainIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse chain ID %q: %w", chainIDStr, err)
+		}
+		chainIDs = append(chainIDs, chainID)
+	}
+	return chainIDs, nil
+}
+
+func subtractOrZero(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+func chainName(chainID ids.ID, aliases []string) string {
+	if len(aliases) == 0 {
+		return chainID.String()
+	}
+	return fmt.Sprintf("%s (%s)", aliases[0], chainID)
+}
@@ -22,6 +22,7 @@ const (
 	signatureVerificationEnabledKey = "signature-verification-enabled"
 	dbEnabledKey                    = "db-enabled"
 	dbDirKey                        = "db-dir"
+	dbTypeKey                       = "db-type"
 	publicIPKey                     = "public-ip"
 	dynamicUpdateDurationKey        = "dynamic-update-duration"
 	dynamicPublicIPResolverKey      = "dynamic-public-ip"
@@ -54,6 +55,7 @@ const (
 	benchlistPeerSummaryEnabledKey  = "benchlist-peer-summary-enabled"
 	benchlistDurationKey            = "benchlist-duration"
 	benchlistMinFailingDurationKey  = "benchlist-min-failing-duration"
+	benchlistHistorySizeKey         = "benchlist-history-size"
 	pluginDirKey                    = "plugin-dir"
 	logsDirKey                      = "log-dir"
 	logLevelKey                     = "log-level"
@@ -66,6 +68,7 @@ const (
 	snowAvalancheNumParentsKey      = "snow-avalanche-num-parents"
 	snowAvalancheBatchSizeKey       = "snow-avalanche-batch-size"
 	snowConcurrentRepollsKey        = "snow-concurrent-repolls"
+	snowSkipUnavailableKey          = "snow-skip-unavailable-validators"
 	whitelistedSubnetsKey           = "whitelisted-subnets"
 	adminAPIEnabledKey              = "api-admin-enabled"
 	infoAPIEnabledKey               = "api-info-enabled"
@@ -84,4 +87,22 @@ const (
 	disconnectedCheckFreqKey        = "disconnected-check-frequency"
 	disconnectedRestartTimeoutKey   = "disconnected-restart-timeout"
 	restartOnDisconnectedKey        = "restart-on-disconnected"
+	networkCompressionEnabledKey    = "network-compression-enabled"
+	networkCompressionThresholdKey  = "network-compression-threshold"
+	networkPeerBandwidthKey         = "network-peer-bandwidth"
+	networkPeerBandwidthBurstKey    = "network-peer-bandwidth-burst"
+	networkBandwidthKey             = "network-bandwidth"
+	networkBandwidthBurstKey        = "network-bandwidth-burst"
+	networkPingTimeoutKey           = "network-ping-timeout"
+	networkPingFrequencyKey         = "network-ping-frequency"
+	staticPeersOnlyKey              = "static-peers-only"
+	staticPeerIPsKey                = "static-peer-ips"
+	staticPeerIDsKey                = "static-peer-ids"
+	networkMaxInboundPeersKey       = "network-max-inbound-peers"
+	networkMaxOutboundPeersKey      = "network-max-outbound-peers"
+	networkMaxPeersPerIPKey         = "network-max-peers-per-ip"
+	networkMaxPeersPerIPRangeKey    = "network-max-peers-per-ip-range"
+	networkIPRangePrefixLengthKey   = "network-ip-range-prefix-length"
+	subnetConfigDirKey              = "subnet-config-dir"
+	faultInjectionConfigFileKey     = "fault-injection-config-file"
 )
@@ -5,6 +5,8 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/node"
@@ -32,6 +34,15 @@ var (
 
 // main is the primary entry point to Avalanche.
 func main() {
+	// The offline database tool runs instead of the node
+	if len(os.Args) > 1 && os.Args[1] == dbCommand {
+		if err := runDBCommand(os.Args[2:], os.Stdout); err != nil {
+			fmt.Printf("%s %s failed with: %s\n", dbCommand, strings.Join(os.Args[2:], " "), err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// parse config using viper
 	if err := parseViper(); err != nil {
 		fmt.Printf("parsing parameters returned with error %s\n", err)
@@ -18,13 +18,15 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
-	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/engine"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/nat"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/snow/networking/faults"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
@@ -51,6 +53,7 @@ var (
 	defaultDbDir           = filepath.Join(homeDir, prefixedAppName, "db")
 	defaultStakingKeyPath  = filepath.Join(homeDir, prefixedAppName, "staking", "staker.key")
 	defaultStakingCertPath = filepath.Join(homeDir, prefixedAppName, "staking", "staker.crt")
+	defaultSubnetConfigDir = filepath.Join(homeDir, prefixedAppName, "configs", "subnets")
 	defaultPluginDirs      = []string{
 		filepath.Join(".", "build", "plugins"),
 		filepath.Join(".", "plugins"),
@@ -62,9 +65,11 @@ var (
 )
 
 var (
-	errBootstrapMismatch    = errors.New("more bootstrap IDs provided than bootstrap IPs")
-	errStakingRequiresTLS   = errors.New("if staking is enabled, network TLS must also be enabled")
-	errInvalidStakerWeights = errors.New("staking weights must be positive")
+	errBootstrapMismatch     = errors.New("more bootstrap IDs provided than bootstrap IPs")
+	errStakingRequiresTLS    = errors.New("if staking is enabled, network TLS must also be enabled")
+	errInvalidStakerWeights  = errors.New("staking weights must be positive")
+	errStaticPeersRequireTLS = errors.New("if static peers only is enabled, network TLS must also be enabled")
+	errFaultInjectionNetwork = errors.New("fault injection can only be enabled on local and test networks")
 )
 
 // avalancheFlagSet returns the complete set of flags for avalanchego
@@ -117,6 +122,7 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Database:
 	fs.Bool(dbEnabledKey, true, "Turn on persistent storage")
 	fs.String(dbDirKey, defaultString, "Database directory for Avalanche state")
+	fs.String(dbTypeKey, engine.LevelDB, fmt.Sprintf("Storage engine of the database. One of %v. A database can only be reopened with the engine it was created with", engine.Engines))
 
 	// IP:
 	fs.String(publicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT. Ignored if dynamic-public-ip is non-empty.")
@@ -171,11 +177,38 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.Duration(networkTimeoutIncreaseKey, 60*time.Millisecond, "Increase of network timeout after a failed request, in nanoseconds.")
 	fs.Duration(networkTimeoutReductionKey, 12*time.Millisecond, "Decrease of network timeout after a successful request, in nanoseconds.")
 
+	// Network Compression:
+	fs.Bool(networkCompressionEnabledKey, true, "If true, large messages are compressed when sent to peers that support compression")
+	fs.Uint(networkCompressionThresholdKey, network.DefaultCompressionThreshold, "Minimum size, in bytes, of a message before it is compressed")
+
+	// Network Bandwidth Throttling:
+	fs.Uint64(networkPeerBandwidthKey, 0, "Maximum number of bytes per second sent to each peer. If 0, bandwidth to each peer is unlimited.")
+	fs.Uint64(networkPeerBandwidthBurstKey, 0, "Maximum number of bytes that may be sent to a peer in a burst. If 0, defaults to [network-peer-bandwidth]. Otherwise, can't be less than [network-peer-bandwidth].")
+	fs.Uint64(networkBandwidthKey, 0, "Maximum number of bytes per second sent to all peers. If 0, bandwidth is unlimited.")
+	fs.Uint64(networkBandwidthBurstKey, 0, "Maximum number of bytes that may be sent to all peers in a burst. If 0, defaults to [network-bandwidth]. Otherwise, can't be less than [network-bandwidth].")
+
+	// Network Liveness:
+	fs.Duration(networkPingTimeoutKey, network.DefaultPingPongTimeout, "Amount of time a peer has to respond to a ping before it is disconnected.")
+	fs.Duration(networkPingFrequencyKey, network.DefaultPingFrequency, "Frequency of pinging peers. Must be less than [network-ping-timeout].")
+
+	// Network Connection Limits:
+	fs.Int(networkMaxInboundPeersKey, 0, "Maximum number of peers that may connect to this node. Validators evict the oldest non-validator peer when the limit is reached. If 0, the number is unlimited.")
+	fs.Int(networkMaxOutboundPeersKey, 0, "Maximum number of peers that this node may connect to. Validators evict the oldest non-validator peer when the limit is reached. If 0, the number is unlimited.")
+	fs.Int(networkMaxPeersPerIPKey, 0, "Maximum number of peers with the same IP. If 0, the number is unlimited.")
+	fs.Int(networkMaxPeersPerIPRangeKey, 0, "Maximum number of peers with IPs in the same range. If 0, the number is unlimited.")
+	fs.Int(networkIPRangePrefixLengthKey, network.DefaultIPRangePrefixLength, "Number of leading bits shared by IPv4 addresses in the same range. IPv6 addresses are grouped by their /48 prefix.")
+
+	// Static Peers:
+	fs.Bool(staticPeersOnlyKey, false, "If true, only connects to the peers in [static-peer-ips] and only accepts connections from the peers in [static-peer-ids]. Gossiped peer lists are ignored.")
+	fs.String(staticPeerIPsKey, "", "Comma separated list of peer ips that may be connected to when [static-peers-only] is true. Example: 127.0.0.1:9630,127.0.0.1:9631")
+	fs.String(staticPeerIDsKey, "", "Comma separated list of peer ids that may connect when [static-peers-only] is true. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
+
 	// Benchlist Parameters:
 	fs.Int(benchlistFailThresholdKey, 10, "Number of consecutive failed queries before benchlisting a node.")
 	fs.Bool(benchlistPeerSummaryEnabledKey, false, "Enables peer specific query latency metrics.")
 	fs.Duration(benchlistDurationKey, time.Hour, "Amount of time a peer is benchlisted after surpassing the threshold.")
 	fs.Duration(benchlistMinFailingDurationKey, 5*time.Minute, "Minimum amount of time messages to a peer must be failing before the peer is benched.")
+	fs.Int(benchlistHistorySizeKey, 1000, "Number of the most recent benchlist events that are kept in the database.")
 
 	// Plugins:
 	fs.String(pluginDirKey, defaultString, "Plugin directory for Avalanche VMs")
@@ -193,6 +226,7 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.Int(snowAvalancheNumParentsKey, 5, "Number of vertexes for reference from each new vertex")
 	fs.Int(snowAvalancheBatchSizeKey, 30, "Number of operations to batch in each new vertex")
 	fs.Int(snowConcurrentRepollsKey, 4, "Minimum number of concurrent polls for finalizing consensus")
+	fs.Bool(snowSkipUnavailableKey, false, "If true, polls only sample validators that are connected and not benched, weighted by stake")
 
 	// Enable/Disable APIs:
 	fs.Bool(adminAPIEnabledKey, false, "If true, this node exposes the Admin API")
@@ -230,6 +264,14 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Coreth Config
 	fs.String(corethConfigKey, defaultString, "Specifies config to pass into coreth")
 
+	// Subnet Configs
+	fs.String(subnetConfigDirKey, defaultString, "Directory of the subnet configs, which may override the consensus parameters of a subnet's chains. "+
+		"The config of a subnet is in the file [subnetID].json.")
+
+	// Fault Injection
+	fs.String(faultInjectionConfigFileKey, "", "JSON file that describes faults to inject into the messages this node sends. "+
+		"Only allowed on the local and unit test networks. If empty, no faults are injected.")
+
 	return fs
 }
 
@@ -304,7 +346,7 @@ func setNodeConfig(v *viper.Viper) error {
 		}
 		dbDir = os.ExpandEnv(dbDir) // parse any env variables
 		dbPath := path.Join(dbDir, constants.NetworkName(Config.NetworkID), dbVersion)
-		db, err := leveldb.New(dbPath, 0, 0, 0)
+		db, err := engine.Open(dbPath, v.GetString(dbTypeKey))
 		if err != nil {
 			return fmt.Errorf("couldn't create db at %s: %w", dbPath, err)
 		}
@@ -356,6 +398,40 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.ConnMeterResetDuration = v.GetDuration(connMeterResetDurationKey)
 	Config.ConnMeterMaxConns = v.GetInt(connMeterMaxConnsKey)
 
+	Config.NetworkCompressionEnabled = v.GetBool(networkCompressionEnabledKey)
+	Config.NetworkCompressionThreshold = int(v.GetUint(networkCompressionThresholdKey))
+
+	Config.NetworkPeerBandwidth = v.GetUint64(networkPeerBandwidthKey)
+	Config.NetworkPeerBandwidthBurst = v.GetUint64(networkPeerBandwidthBurstKey)
+	Config.NetworkBandwidth = v.GetUint64(networkBandwidthKey)
+	Config.NetworkBandwidthBurst = v.GetUint64(networkBandwidthBurstKey)
+	if Config.NetworkPeerBandwidthBurst == 0 {
+		Config.NetworkPeerBandwidthBurst = Config.NetworkPeerBandwidth
+	}
+	if Config.NetworkBandwidthBurst == 0 {
+		Config.NetworkBandwidthBurst = Config.NetworkBandwidth
+	}
+	switch {
+	case Config.NetworkPeerBandwidthBurst < Config.NetworkPeerBandwidth:
+		return fmt.Errorf("network peer bandwidth burst (%d) can't be less than the network peer bandwidth (%d)",
+			Config.NetworkPeerBandwidthBurst, Config.NetworkPeerBandwidth)
+	case Config.NetworkBandwidthBurst < Config.NetworkBandwidth:
+		return fmt.Errorf("network bandwidth burst (%d) can't be less than the network bandwidth (%d)",
+			Config.NetworkBandwidthBurst, Config.NetworkBandwidth)
+	}
+
+	Config.NetworkPingPongTimeout = v.GetDuration(networkPingTimeoutKey)
+	Config.NetworkPingFrequency = v.GetDuration(networkPingFrequencyKey)
+	switch {
+	case Config.NetworkPingPongTimeout <= 0:
+		return errors.New("network ping timeout must be positive")
+	case Config.NetworkPingFrequency <= 0:
+		return errors.New("network ping frequency must be positive")
+	case Config.NetworkPingFrequency >= Config.NetworkPingPongTimeout:
+		return fmt.Errorf("network ping frequency (%s) must be less than the network ping timeout (%s)",
+			Config.NetworkPingFrequency, Config.NetworkPingPongTimeout)
+	}
+
 	// Staking:
 	Config.EnableStaking = v.GetBool(stakingEnabledKey)
 	Config.EnableP2PTLS = v.GetBool(p2pTLSEnabledKey)
@@ -457,6 +533,56 @@ func setNodeConfig(v *viper.Viper) error {
 		}
 	}
 
+	Config.NetworkConnLimits = network.ConnLimits{
+		MaxInbound:          v.GetInt(networkMaxInboundPeersKey),
+		MaxOutbound:         v.GetInt(networkMaxOutboundPeersKey),
+		MaxPerIP:            v.GetInt(networkMaxPeersPerIPKey),
+		MaxPerIPRange:       v.GetInt(networkMaxPeersPerIPRangeKey),
+		IPRangePrefixLength: v.GetInt(networkIPRangePrefixLengthKey),
+	}
+	if prefixLength := Config.NetworkConnLimits.IPRangePrefixLength; prefixLength < 0 || prefixLength > 32 {
+		return fmt.Errorf("network ip range prefix length must be in [0, 32] but is %d", prefixLength)
+	}
+
+	// Static Peers:
+	Config.StaticPeersOnly = v.GetBool(staticPeersOnlyKey)
+	if Config.StaticPeersOnly && !Config.EnableP2PTLS {
+		return errStaticPeersRequireTLS
+	}
+	for _, ip := range strings.Split(v.GetString(staticPeerIPsKey), ",") {
+		if ip != "" {
+			addr, err := utils.ToIPDesc(ip)
+			if err != nil {
+				return fmt.Errorf("couldn't parse static peer ip %s: %w", ip, err)
+			}
+			Config.StaticPeerIPs = append(Config.StaticPeerIPs, addr)
+		}
+	}
+	for _, id := range strings.Split(v.GetString(staticPeerIDsKey), ",") {
+		if id != "" {
+			peerID, err := ids.ShortFromPrefixedString(id, constants.NodeIDPrefix)
+			if err != nil {
+				return fmt.Errorf("couldn't parse static peer id: %w", err)
+			}
+			Config.StaticPeerIDs.Add(peerID)
+		}
+	}
+	if Config.StaticPeersOnly {
+		staticIPs := make(map[string]struct{}, len(Config.StaticPeerIPs))
+		for _, ip := range Config.StaticPeerIPs {
+			staticIPs[ip.String()] = struct{}{}
+		}
+		for _, peer := range Config.BootstrapPeers {
+			if _, ok := staticIPs[peer.IP.String()]; !ok {
+				return fmt.Errorf("bootstrap ip %s must be in [%s] when [%s] is true", peer.IP, staticPeerIPsKey, staticPeersOnlyKey)
+			}
+			if !Config.StaticPeerIDs.Contains(peer.ID) {
+				return fmt.Errorf("bootstrap id %s must be in [%s] when [%s] is true",
+					peer.ID.PrefixedString(constants.NodeIDPrefix), staticPeerIDsKey, staticPeersOnlyKey)
+			}
+		}
+	}
+
 	Config.WhitelistedSubnets.Add(constants.PrimaryNetworkID)
 	for _, subnet := range strings.Split(v.GetString(whitelistedSubnetsKey), ",") {
 		if subnet != "" {
@@ -575,6 +701,11 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.BenchlistConfig.Duration = v.GetDuration(benchlistDurationKey)
 	Config.BenchlistConfig.MinimumFailingDuration = v.GetDuration(benchlistMinFailingDurationKey)
 	Config.BenchlistConfig.MaxPortion = (1.0 - (float64(Config.ConsensusParams.Alpha) / float64(Config.ConsensusParams.K))) / 3.0
+	Config.BenchlistConfig.MaxTotalPortion = Config.BenchlistConfig.MaxPortion
+	Config.BenchlistConfig.MaxHistory = v.GetInt(benchlistHistorySizeKey)
+	if Config.BenchlistConfig.MaxHistory < 0 {
+		return fmt.Errorf("[%s] can't be negative", benchlistHistorySizeKey)
+	}
 
 	if Config.ConsensusGossipFrequency < 0 {
 		return errors.New("gossip frequency can't be negative")
@@ -637,6 +768,7 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.ConsensusParams.Parents = v.GetInt(snowAvalancheNumParentsKey)
 	Config.ConsensusParams.BatchSize = v.GetInt(snowAvalancheBatchSizeKey)
 	Config.ConsensusParams.ConcurrentRepolls = v.GetInt(snowConcurrentRepollsKey)
+	Config.SkipUnavailableValidators = v.GetBool(snowSkipUnavailableKey)
 
 	Config.ConsensusGossipFrequency = v.GetDuration(consensusGossipFrequencyKey)
 	Config.ConsensusShutdownTimeout = v.GetDuration(consensusShutdownTimeoutKey)
@@ -664,6 +796,24 @@ func setNodeConfig(v *viper.Viper) error {
 	}
 	Config.CorethConfig = corethConfigString
 
+	// Subnet Configs
+	subnetConfigDir := v.GetString(subnetConfigDirKey)
+	if subnetConfigDir == defaultString {
+		subnetConfigDir = defaultSubnetConfigDir
+	}
+	Config.SubnetConfigDir = os.ExpandEnv(subnetConfigDir)
+
+	// Fault Injection
+	if faultInjectionConfigFile := v.GetString(faultInjectionConfigFileKey); faultInjectionConfigFile != "" {
+		if Config.NetworkID != constants.LocalID && Config.NetworkID != constants.UnitTestID {
+			return fmt.Errorf("%w: [%s] is set on network %s", errFaultInjectionNetwork, faultInjectionConfigFileKey, constants.NetworkName(Config.NetworkID))
+		}
+		Config.FaultInjection, err = faults.Load(os.ExpandEnv(faultInjectionConfigFile))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,160 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Prefixes of the keys that bans are persisted under
+const (
+	nodeIDBanPrefix byte = iota
+	ipBanPrefix
+)
+
+// banList tracks the node IDs and IPs that are not allowed to connect to this
+// node. Bans are persisted to [db] so that they are kept across restarts.
+type banList struct {
+	lock  sync.Mutex
+	clock *timer.Clock
+	db    database.Database
+
+	// Node ID --> time the ban expires
+	nodeIDs map[[20]byte]time.Time
+	// IP, in its 16 byte form --> time the ban expires
+	ips map[string]time.Time
+}
+
+// newBanList returns a ban list that contains the unexpired bans stored in
+// [db]
+func newBanList(clock *timer.Clock, db database.Database) (*banList, error) {
+	b := &banList{
+		clock:   clock,
+		db:      db,
+		nodeIDs: make(map[[20]byte]time.Time),
+		ips:     make(map[string]time.Time),
+	}
+	return b, b.load()
+}
+
+func (b *banList) load() error {
+	now := b.clock.Time()
+	expired := [][]byte(nil)
+
+	it := b.db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		p := wrappers.Packer{Bytes: it.Value()}
+		end := time.Unix(int64(p.UnpackLong()), 0)
+		if p.Errored() || len(key) == 0 {
+			continue
+		}
+		if !now.Before(end) {
+			expired = append(expired, key)
+			continue
+		}
+
+		switch key[0] {
+		case nodeIDBanPrefix:
+			nodeID, err := ids.ToShortID(key[1:])
+			if err != nil {
+				continue
+			}
+			b.nodeIDs[nodeID.Key()] = end
+		case ipBanPrefix:
+			b.ips[string(key[1:])] = end
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := b.db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// banNodeID prevents [nodeID] from connecting for [duration]
+func (b *banList) banNodeID(nodeID ids.ShortID, duration time.Duration) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	end := b.clock.Time().Add(duration)
+	key := append([]byte{nodeIDBanPrefix}, nodeID.Bytes()...)
+	if err := b.put(key, end); err != nil {
+		return err
+	}
+	b.nodeIDs[nodeID.Key()] = end
+	return nil
+}
+
+// banIP prevents connections to and from [ip] for [duration]
+func (b *banList) banIP(ip net.IP, duration time.Duration) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	end := b.clock.Time().Add(duration)
+	ipKey := string(ip.To16())
+	key := append([]byte{ipBanPrefix}, ipKey...)
+	if err := b.put(key, end); err != nil {
+		return err
+	}
+	b.ips[ipKey] = end
+	return nil
+}
+
+// assumes the lock is held
+func (b *banList) put(key []byte, end time.Time) error {
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.LongLen)}
+	p.PackLong(uint64(end.Unix()))
+	return b.db.Put(key, p.Bytes)
+}
+
+// isNodeIDBanned returns true if [nodeID] is currently banned
+func (b *banList) isNodeIDBanned(nodeID ids.ShortID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := nodeID.Key()
+	end, ok := b.nodeIDs[key]
+	if !ok {
+		return false
+	}
+	if b.clock.Time().Before(end) {
+		return true
+	}
+	// The ban has expired. It is removed from the database the next time the
+	// bans are loaded.
+	delete(b.nodeIDs, key)
+	return false
+}
+
+// isIPBanned returns true if [ip] is currently banned
+func (b *banList) isIPBanned(ip net.IP) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	key := string(ip.To16())
+	end, ok := b.ips[key]
+	if !ok {
+		return false
+	}
+	if b.clock.Time().Before(end) {
+		return true
+	}
+	delete(b.ips, key)
+	return false
+}
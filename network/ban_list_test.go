@@ -0,0 +1,86 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func TestBanListNodeID(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(1000, 0))
+
+	b, err := newBanList(&clock, memdb.New())
+	assert.NoError(t, err)
+
+	nodeID := ids.NewShortID([20]byte{1})
+	otherNodeID := ids.NewShortID([20]byte{2})
+	assert.False(t, b.isNodeIDBanned(nodeID))
+
+	err = b.banNodeID(nodeID, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, b.isNodeIDBanned(nodeID))
+	assert.False(t, b.isNodeIDBanned(otherNodeID))
+
+	clock.Set(clock.Time().Add(time.Minute))
+	assert.False(t, b.isNodeIDBanned(nodeID))
+}
+
+func TestBanListIP(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(1000, 0))
+
+	b, err := newBanList(&clock, memdb.New())
+	assert.NoError(t, err)
+
+	ip := net.IPv4(1, 2, 3, 4)
+	assert.False(t, b.isIPBanned(ip))
+
+	err = b.banIP(ip, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, b.isIPBanned(ip))
+	assert.True(t, b.isIPBanned(ip.To4()))
+	assert.False(t, b.isIPBanned(net.IPv4(1, 2, 3, 5)))
+
+	clock.Set(clock.Time().Add(time.Minute))
+	assert.False(t, b.isIPBanned(ip))
+}
+
+func TestBanListPersisted(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	db := memdb.New()
+
+	b, err := newBanList(&clock, db)
+	assert.NoError(t, err)
+
+	nodeID := ids.NewShortID([20]byte{1})
+	expiredNodeID := ids.NewShortID([20]byte{2})
+	ip := net.IPv4(1, 2, 3, 4)
+
+	assert.NoError(t, b.banNodeID(nodeID, time.Hour))
+	assert.NoError(t, b.banNodeID(expiredNodeID, time.Minute))
+	assert.NoError(t, b.banIP(ip, time.Hour))
+
+	clock.Set(clock.Time().Add(time.Minute))
+
+	b, err = newBanList(&clock, db)
+	assert.NoError(t, err)
+	assert.True(t, b.isNodeIDBanned(nodeID))
+	assert.False(t, b.isNodeIDBanned(expiredNodeID))
+	assert.True(t, b.isIPBanned(ip))
+
+	// The expired ban should have been removed from the database
+	has, err := db.Has(append([]byte{nodeIDBanPrefix}, expiredNodeID.Bytes()...))
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
@@ -25,6 +25,19 @@ func (m Builder) Version(networkID, nodeID uint32, myTime uint64, ip utils.IPDes
 	})
 }
 
+// VersionWithCompression message. Must only be sent to peers that are able to
+// parse the Compression field.
+func (m Builder) VersionWithCompression(networkID, nodeID uint32, myTime uint64, ip utils.IPDesc, myVersion string, compression byte) (Msg, error) {
+	return m.Pack(Version, map[Field]interface{}{
+		NetworkID:   networkID,
+		NodeID:      nodeID,
+		MyTime:      myTime,
+		IP:          ip,
+		VersionStr:  myVersion,
+		Compression: compression,
+	})
+}
+
 // GetPeerList message
 func (m Builder) GetPeerList() (Msg, error) { return m.Pack(GetPeerList, nil) }
 
@@ -33,12 +46,22 @@ func (m Builder) PeerList(ipDescs []utils.IPDesc) (Msg, error) {
 	return m.Pack(PeerList, map[Field]interface{}{Peers: ipDescs})
 }
 
+// SignedPeerList message
+func (m Builder) SignedPeerList(ipCerts []utils.IPCertDesc) (Msg, error) {
+	return m.Pack(SignedPeerList, map[Field]interface{}{SignedPeers: ipCerts})
+}
+
 // Ping message
 func (m Builder) Ping() (Msg, error) { return m.Pack(Ping, nil) }
 
 // Pong message
 func (m Builder) Pong() (Msg, error) { return m.Pack(Pong, nil) }
 
+// UptimePong message
+func (m Builder) UptimePong(uptimePercentage uint8) (Msg, error) {
+	return m.Pack(UptimePong, map[Field]interface{}{Uptime: uptimePercentage})
+}
+
 // GetAcceptedFrontier message
 func (m Builder) GetAcceptedFrontier(chainID ids.ID, requestID uint32, deadline uint64) (Msg, error) {
 	return m.Pack(GetAcceptedFrontier, map[Field]interface{}{
@@ -164,3 +187,30 @@ func (m Builder) Chits(chainID ids.ID, requestID uint32, containerIDs []ids.ID)
 		ContainerIDs: containerIDBytes,
 	})
 }
+
+// AppRequest message
+func (m Builder) AppRequest(chainID ids.ID, requestID uint32, deadline uint64, appRequestBytes []byte) (Msg, error) {
+	return m.Pack(AppRequest, map[Field]interface{}{
+		ChainID:   chainID[:],
+		RequestID: requestID,
+		Deadline:  deadline,
+		AppBytes:  appRequestBytes,
+	})
+}
+
+// AppResponse message
+func (m Builder) AppResponse(chainID ids.ID, requestID uint32, appResponseBytes []byte) (Msg, error) {
+	return m.Pack(AppResponse, map[Field]interface{}{
+		ChainID:   chainID[:],
+		RequestID: requestID,
+		AppBytes:  appResponseBytes,
+	})
+}
+
+// AppGossip message
+func (m Builder) AppGossip(chainID ids.ID, appGossipBytes []byte) (Msg, error) {
+	return m.Pack(AppGossip, map[Field]interface{}{
+		ChainID:  chainID[:],
+		AppBytes: appGossipBytes,
+	})
+}
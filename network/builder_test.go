@@ -97,6 +97,41 @@ func TestBuildPeerList(t *testing.T) {
 	assert.Equal(t, ips, parsedMsg.Get(Peers))
 }
 
+func TestBuildSignedPeerList(t *testing.T) {
+	key, cert := newTestStakingCert(t)
+	ipCerts := []utils.IPCertDesc{
+		newTestSignedIP(
+			t,
+			key,
+			cert,
+			utils.IPDesc{
+				IP:   net.IPv6loopback,
+				Port: 12345,
+			},
+			5,
+		),
+	}
+
+	msg, err := TestBuilder.SignedPeerList(ipCerts)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, SignedPeerList, msg.Op())
+	assert.Equal(t, ipCerts, msg.Get(SignedPeers))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, SignedPeerList, parsedMsg.Op())
+
+	parsedIPCerts := parsedMsg.Get(SignedPeers).([]utils.IPCertDesc)
+	assert.Len(t, parsedIPCerts, 1)
+	assert.Equal(t, cert.Raw, parsedIPCerts[0].Cert.Raw)
+	assert.True(t, ipCerts[0].IPDesc.Equal(parsedIPCerts[0].IPDesc))
+	assert.Equal(t, ipCerts[0].Time, parsedIPCerts[0].Time)
+	assert.Equal(t, ipCerts[0].Signature, parsedIPCerts[0].Signature)
+	assert.NoError(t, verifyIP(parsedIPCerts[0]))
+}
+
 func TestBuildGetAcceptedFrontier(t *testing.T) {
 	chainID := ids.Empty.Prefix(0)
 	requestID := uint32(5)
@@ -316,3 +351,83 @@ func TestBuildChits(t *testing.T) {
 	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
 	assert.Equal(t, containerIDs, parsedMsg.Get(ContainerIDs))
 }
+
+func TestBuildAppRequest(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	deadline := uint64(15)
+	appRequestBytes := []byte{2}
+
+	msg, err := TestBuilder.AppRequest(chainID, requestID, deadline, appRequestBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppRequest, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, deadline, msg.Get(Deadline))
+	assert.Equal(t, appRequestBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppRequest, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, deadline, parsedMsg.Get(Deadline))
+	assert.Equal(t, appRequestBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestBuildAppResponse(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	appResponseBytes := []byte{2}
+
+	msg, err := TestBuilder.AppResponse(chainID, requestID, appResponseBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppResponse, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, appResponseBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppResponse, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, appResponseBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestBuildAppGossip(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	appGossipBytes := []byte{2}
+
+	msg, err := TestBuilder.AppGossip(chainID, appGossipBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppGossip, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, appGossipBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppGossip, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, appGossipBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestBuildUptimePong(t *testing.T) {
+	msg, err := TestBuilder.UptimePong(99)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, UptimePong, msg.Op())
+	assert.Equal(t, uint8(99), msg.Get(Uptime))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, UptimePong, parsedMsg.Op())
+	assert.Equal(t, uint8(99), parsedMsg.Get(Uptime))
+}
@@ -4,18 +4,59 @@
 package network
 
 import (
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math"
 
+	"github.com/DataDog/zstd"
+
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 var (
 	errMissingField = errors.New("message missing field")
 	errBadOp        = errors.New("input field has invalid operation")
+	errMsgTooLarge  = errors.New("decompressed message exceeds the maximum message size")
+	errNoAlgorithm  = errors.New("compressed message missing compression algorithm")
+	errBadAlgorithm = errors.New("unknown compression algorithm")
+)
+
+// compressedFlag is set in the opcode byte of a message whose body has been
+// compressed. Opcodes never use the high bit, so the flag can't be confused
+// with a valid uncompressed opcode. The byte following the opcode of a
+// compressed message is the algorithm the body was compressed with.
+const compressedFlag byte = 1 << 7
+
+// Compression algorithms that a message body may be compressed with. A node
+// advertises the algorithms it is able to parse as a bitmask in the
+// Compression field of its Version message.
+const (
+	gzipCompression byte = 1 << iota
+	zstdCompression
+
+	// supportedCompression is the set of algorithms this node is able to
+	// parse
+	supportedCompression = gzipCompression | zstdCompression
 )
 
+// selectCompression returns the algorithm that should be used to compress
+// messages sent to a peer that advertised [peerCompression]. Returns 0 if the
+// peer doesn't support any of this node's algorithms.
+func selectCompression(peerCompression byte) byte {
+	switch shared := peerCompression & supportedCompression; {
+	case shared&zstdCompression != 0:
+		return zstdCompression
+	case shared&gzipCompression != 0:
+		return gzipCompression
+	default:
+		return 0
+	}
+}
+
 // Codec defines the serialization and deserialization of network messages
 type Codec struct{}
 
@@ -36,6 +77,15 @@ func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 		}
 		field.Packer()(&p, data)
 	}
+	for _, field := range OptionalFields[op] {
+		data, ok := fields[field]
+		if !ok {
+			// Optional fields are only parsed in order, so none of the
+			// following optional fields can be included either
+			break
+		}
+		field.Packer()(&p, data)
+	}
 
 	return &msg{
 		op:     op,
@@ -45,8 +95,22 @@ func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 }
 
 // Parse attempts to convert bytes into a message.
-// The first byte of the message is the opcode of the message.
+// The first byte of the message is the opcode of the message. If the
+// compressed flag is set in the opcode, the remainder of the message is
+// decompressed before being parsed. Optional fields are only parsed if the
+// message has bytes remaining after its required fields.
 func (Codec) Parse(b []byte) (Msg, error) {
+	if len(b) > 0 && b[0]&compressedFlag != 0 {
+		if len(b) < 2 {
+			return nil, errNoAlgorithm
+		}
+		body, err := decompress(b[1], b[2:])
+		if err != nil {
+			return nil, err
+		}
+		b = append([]byte{b[0] &^ compressedFlag}, body...)
+	}
+
 	p := wrappers.Packer{Bytes: b}
 	op := Op(p.UnpackByte())
 	message, ok := Messages[op]
@@ -58,6 +122,12 @@ func (Codec) Parse(b []byte) (Msg, error) {
 	for _, field := range message {
 		fields[field] = field.Unpacker()(&p)
 	}
+	for _, field := range OptionalFields[op] {
+		if p.Errored() || p.Offset == len(b) {
+			break
+		}
+		fields[field] = field.Unpacker()(&p)
+	}
 
 	if p.Offset != len(b) {
 		p.Add(fmt.Errorf("expected length %d got %d", len(b), p.Offset))
@@ -69,3 +139,57 @@ func (Codec) Parse(b []byte) (Msg, error) {
 		bytes:  b,
 	}, p.Err
 }
+
+// compress returns [msgBytes] with its body compressed with [algorithm] and
+// the compressed flag set in its opcode.
+func compress(algorithm byte, msgBytes []byte) ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte(msgBytes[0] | compressedFlag)
+	buf.WriteByte(algorithm)
+
+	var w io.WriteCloser
+	switch algorithm {
+	case gzipCompression:
+		w = gzip.NewWriter(&buf)
+	case zstdCompression:
+		w = zstd.NewWriter(&buf)
+	default:
+		return nil, errBadAlgorithm
+	}
+	if _, err := w.Write(msgBytes[1:]); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress returns the decompressed form of [body], which was compressed
+// with [algorithm]. Returns an error if the decompressed body would exceed the
+// maximum message size.
+func decompress(algorithm byte, body []byte) ([]byte, error) {
+	var r io.ReadCloser
+	switch algorithm {
+	case gzipCompression:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		r = gzipReader
+	case zstdCompression:
+		r = zstd.NewReader(bytes.NewReader(body))
+	default:
+		return nil, errBadAlgorithm
+	}
+	defer r.Close()
+
+	decompressed, err := ioutil.ReadAll(io.LimitReader(r, int64(DefaultMaxMessageSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) > int(DefaultMaxMessageSize) {
+		return nil, errMsgTooLarge
+	}
+	return decompressed, nil
+}
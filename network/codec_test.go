@@ -5,9 +5,13 @@ package network
 
 import (
 	"math"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 )
 
 var (
@@ -33,3 +37,72 @@ func TestCodecParseExtraSpace(t *testing.T) {
 	_, err := TestCodec.Parse([]byte{byte(GetVersion), 0x00})
 	assert.Error(t, err)
 }
+
+func TestCodecParseCompressed(t *testing.T) {
+	container := make([]byte, 4*DefaultCompressionThreshold)
+	msg, err := TestBuilder.Put(ids.Empty, 1, ids.Empty, container)
+	assert.NoError(t, err)
+
+	for _, algorithm := range []byte{gzipCompression, zstdCompression} {
+		compressedBytes := msg.CompressedBytes(algorithm)
+		assert.NotNil(t, compressedBytes)
+		assert.Less(t, len(compressedBytes), len(msg.Bytes()))
+		assert.NotZero(t, compressedBytes[0]&compressedFlag)
+		assert.Equal(t, algorithm, compressedBytes[1])
+
+		parsedMsg, err := TestCodec.Parse(compressedBytes)
+		assert.NoError(t, err)
+		assert.Equal(t, Put, parsedMsg.Op())
+		assert.Equal(t, container, parsedMsg.Get(ContainerBytes))
+		assert.Equal(t, msg.Bytes(), parsedMsg.Bytes())
+	}
+}
+
+func TestCodecParseCompressedInvalidBody(t *testing.T) {
+	_, err := TestCodec.Parse([]byte{byte(Put) | compressedFlag, gzipCompression, 0x00, 0x01})
+	assert.Error(t, err)
+
+	_, err = TestCodec.Parse([]byte{byte(Put) | compressedFlag, zstdCompression, 0x00, 0x01})
+	assert.Error(t, err)
+}
+
+func TestCodecParseCompressedUnknownAlgorithm(t *testing.T) {
+	_, err := TestCodec.Parse([]byte{byte(Put) | compressedFlag})
+	assert.Equal(t, errNoAlgorithm, err)
+
+	_, err = TestCodec.Parse([]byte{byte(Put) | compressedFlag, 1 << 7, 0x00})
+	assert.Equal(t, errBadAlgorithm, err)
+}
+
+func TestCodecCompressIncompressible(t *testing.T) {
+	msg, err := TestBuilder.GetVersion()
+	assert.NoError(t, err)
+	assert.Nil(t, msg.CompressedBytes(gzipCompression))
+	assert.Nil(t, msg.CompressedBytes(zstdCompression))
+}
+
+func TestCodecOptionalFields(t *testing.T) {
+	ip := utils.IPDesc{IP: net.IPv6loopback}
+
+	// A version without the optional field should still be parsed
+	legacyMsg, err := TestBuilder.Version(1, 2, 3, ip, "v")
+	assert.NoError(t, err)
+	parsedMsg, err := TestCodec.Parse(legacyMsg.Bytes())
+	assert.NoError(t, err)
+	assert.Nil(t, parsedMsg.Get(Compression))
+
+	msg, err := TestBuilder.VersionWithCompression(1, 2, 3, ip, "v", supportedCompression)
+	assert.NoError(t, err)
+	assert.Len(t, msg.Bytes(), len(legacyMsg.Bytes())+1)
+	parsedMsg, err = TestCodec.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, supportedCompression, parsedMsg.Get(Compression))
+	assert.Equal(t, "v", parsedMsg.Get(VersionStr))
+}
+
+func TestSelectCompression(t *testing.T) {
+	assert.Equal(t, zstdCompression, selectCompression(gzipCompression|zstdCompression))
+	assert.Equal(t, gzipCompression, selectCompression(gzipCompression|1<<7))
+	assert.Equal(t, byte(0), selectCompression(1<<7))
+	assert.Equal(t, byte(0), selectCompression(0))
+}
@@ -25,6 +25,10 @@ const (
 	ContainerBytes                   // Used for gossiping
 	ContainerIDs                     // Used for querying
 	MultiContainerBytes              // Used in MultiPut
+	AppBytes                         // Used in application level messages
+	SignedPeers                      // Used in handshake
+	Uptime                           // Used in handshake
+	Compression                      // Used in handshake
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -56,6 +60,14 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackHashes
 	case MultiContainerBytes:
 		return wrappers.TryPack2DBytes
+	case AppBytes:
+		return wrappers.TryPackBytes
+	case SignedPeers:
+		return wrappers.TryPackIPCertList
+	case Uptime:
+		return wrappers.TryPackByte
+	case Compression:
+		return wrappers.TryPackByte
 	default:
 		return nil
 	}
@@ -90,6 +102,14 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackHashes
 	case MultiContainerBytes:
 		return wrappers.TryUnpack2DBytes
+	case AppBytes:
+		return wrappers.TryUnpackBytes
+	case SignedPeers:
+		return wrappers.TryUnpackIPCertList
+	case Uptime:
+		return wrappers.TryUnpackByte
+	case Compression:
+		return wrappers.TryUnpackByte
 	default:
 		return nil
 	}
@@ -123,6 +143,14 @@ func (f Field) String() string {
 		return "Container IDs"
 	case MultiContainerBytes:
 		return "MultiContainerBytes"
+	case AppBytes:
+		return "AppBytes"
+	case SignedPeers:
+		return "SignedPeers"
+	case Uptime:
+		return "Uptime"
+	case Compression:
+		return "Compression"
 	default:
 		return "Unknown Field"
 	}
@@ -167,6 +195,16 @@ func (op Op) String() string {
 		return "pull_query"
 	case Chits:
 		return "chits"
+	case AppRequest:
+		return "app_request"
+	case AppResponse:
+		return "app_response"
+	case AppGossip:
+		return "app_gossip"
+	case SignedPeerList:
+		return "signed_peerlist"
+	case UptimePong:
+		return "uptime_pong"
 	default:
 		return "Unknown Op"
 	}
@@ -194,6 +232,13 @@ const (
 	PushQuery
 	PullQuery
 	Chits
+	// Application level:
+	AppRequest
+	AppResponse
+	AppGossip
+	// Handshake:
+	SignedPeerList
+	UptimePong
 )
 
 // Defines the messages that can be sent/received with this network
@@ -219,5 +264,30 @@ var (
 		PushQuery: {ChainID, RequestID, Deadline, ContainerID, ContainerBytes},
 		PullQuery: {ChainID, RequestID, Deadline, ContainerID},
 		Chits:     {ChainID, RequestID, ContainerIDs},
+		// Application level:
+		AppRequest:  {ChainID, RequestID, Deadline, AppBytes},
+		AppResponse: {ChainID, RequestID, AppBytes},
+		AppGossip:   {ChainID, AppBytes},
+		// Handshake:
+		SignedPeerList: {SignedPeers},
+		UptimePong:     {Uptime},
+	}
+
+	// OptionalFields are appended to a message after the fields in Messages.
+	// Nodes that predate an optional field don't send it, so it is only
+	// parsed if the message has bytes remaining.
+	OptionalFields = map[Op][]Field{
+		Version: {Compression},
 	}
 )
+
+// Compressible returns true if messages with this opcode may carry large
+// container bodies and should be compressed when the peer supports it.
+func (op Op) Compressible() bool {
+	switch op {
+	case Put, MultiPut, PushQuery, AppRequest, AppResponse, AppGossip:
+		return true
+	default:
+		return false
+	}
+}
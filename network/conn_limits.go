@@ -0,0 +1,188 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"net"
+	"sync/atomic"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	// DefaultIPRangePrefixLength is the default number of leading bits of an
+	// IPv4 address that are shared by IPs in the same range
+	DefaultIPRangePrefixLength = 24
+
+	// ipv6RangePrefixLength is the number of leading bits of an IPv6 address
+	// that are shared by IPs in the same range
+	ipv6RangePrefixLength = 48
+)
+
+var (
+	errTooManyInbound   = errors.New("too many inbound peers")
+	errTooManyOutbound  = errors.New("too many outbound peers")
+	errTooManyFromIP    = errors.New("too many peers from the same IP")
+	errTooManyFromRange = errors.New("too many peers from the same IP range")
+)
+
+// ConnLimits bounds the number of peers this node is connected to. A limit of
+// 0 means that the number of peers is unbounded.
+type ConnLimits struct {
+	// MaxInbound is the maximum number of peers that connected to this node
+	MaxInbound int
+	// MaxOutbound is the maximum number of peers that this node connected to
+	MaxOutbound int
+	// MaxPerIP is the maximum number of peers with the same remote IP
+	MaxPerIP int
+	// MaxPerIPRange is the maximum number of peers whose remote IPs share the
+	// first [IPRangePrefixLength] bits
+	MaxPerIPRange int
+	// IPRangePrefixLength is the number of leading bits of an IPv4 address
+	// that define its range. IPv6 addresses are grouped by their /48 prefix.
+	IPRangePrefixLength int
+}
+
+// connLimit is a single bound on the number of peers
+type connLimit struct {
+	max     int
+	err     error
+	matches func(*peer) bool
+}
+
+// assumes the stateLock is held. Returns an error if [p] can't be added
+// because a limit has been reached. If [p] is a validator, a limit may instead
+// be satisfied by evicting the oldest non-validator peer counted against it,
+// in which case the peer to evict is returned.
+func (n *network) admit(p *peer) (*peer, error) {
+	ip := p.remoteIP()
+	ipRange := n.ipRange(ip)
+	limits := []connLimit{
+		{
+			max: n.connLimits.MaxInbound,
+			err: errTooManyInbound,
+			matches: func(other *peer) bool {
+				return p.inbound && other.inbound
+			},
+		},
+		{
+			max: n.connLimits.MaxOutbound,
+			err: errTooManyOutbound,
+			matches: func(other *peer) bool {
+				return !p.inbound && !other.inbound
+			},
+		},
+		{
+			max: n.connLimits.MaxPerIP,
+			err: errTooManyFromIP,
+			matches: func(other *peer) bool {
+				return ip != nil && ip.Equal(other.remoteIP())
+			},
+		},
+		{
+			max: n.connLimits.MaxPerIPRange,
+			err: errTooManyFromRange,
+			matches: func(other *peer) bool {
+				return ipRange != nil && ipRange.Equal(n.ipRange(other.remoteIP()))
+			},
+		},
+	}
+
+	isValidator := n.vdrs.Contains(p.id)
+
+	var evict *peer
+	for _, limit := range limits {
+		if limit.max <= 0 {
+			continue
+		}
+
+		count := 0
+		var oldest *peer
+		for _, other := range n.peers {
+			if other == evict || !limit.matches(other) {
+				continue
+			}
+			count++
+
+			if n.vdrs.Contains(other.id) {
+				continue
+			}
+			if oldest == nil ||
+				atomic.LoadInt64(&other.connectedTime) < atomic.LoadInt64(&oldest.connectedTime) {
+				oldest = other
+			}
+		}
+		if count < limit.max {
+			continue
+		}
+
+		// Only one peer is evicted to make room for a validator
+		if !isValidator || oldest == nil || evict != nil {
+			return nil, limit.err
+		}
+		evict = oldest
+	}
+	return evict, nil
+}
+
+// assumes the stateLock is held. Returns an error if a connection to the IP
+// [str] wouldn't be admitted under the outbound limit. A connection is only
+// expected to be admitted at the limit if [str] belongs to a validator and
+// there is a non-validator outbound peer to evict.
+func (n *network) canDial(str string) error {
+	if n.connLimits.MaxOutbound <= 0 {
+		return nil
+	}
+
+	count := 0
+	evictable := false
+	for _, other := range n.peers {
+		if other.inbound {
+			continue
+		}
+		count++
+		evictable = evictable || !n.vdrs.Contains(other.id)
+	}
+	if count < n.connLimits.MaxOutbound {
+		return nil
+	}
+	if _, isValidator := n.knownValidatorIPs[str]; isValidator && evictable {
+		return nil
+	}
+	return errTooManyOutbound
+}
+
+// assumes the stateLock is held. Marks [ip] as belonging to a validator.
+func (n *network) markValidatorIP(ip utils.IPDesc) {
+	if ip.IsZero() || len(n.knownValidatorIPs) >= maxLatestIPs {
+		return
+	}
+	n.knownValidatorIPs[ip.String()] = struct{}{}
+}
+
+// ipRange returns the prefix of [ip] that defines its range. Returns nil if
+// [ip] is nil.
+func (n *network) ipRange(ip net.IP) net.IP {
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(n.connLimits.IPRangePrefixLength, 8*net.IPv4len))
+	}
+	return ip.Mask(net.CIDRMask(ipv6RangePrefixLength, 8*net.IPv6len))
+}
+
+// remoteIP returns the IP at the other end of the peer's connection. Returns
+// nil if it can't be determined.
+func (p *peer) remoteIP() net.IP {
+	if p.conn == nil {
+		return nil
+	}
+	addr, err := utils.ToIPDesc(p.conn.RemoteAddr().String())
+	if err != nil {
+		return nil
+	}
+	return addr.IP
+}
@@ -0,0 +1,197 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func newLimitsTestPeer(n *network, ip string, inbound bool, connectedTime int64) *peer {
+	return &peer{
+		net: n,
+		id:  ids.GenerateTestShortID(),
+		conn: &testConn{
+			remote: &net.TCPAddr{
+				IP:   net.ParseIP(ip),
+				Port: 9651,
+			},
+		},
+		inbound:       inbound,
+		connectedTime: connectedTime,
+	}
+}
+
+func TestAdmitInboundLimit(t *testing.T) {
+	n := &network{
+		vdrs:  validators.NewSet(),
+		peers: make(map[[20]byte]*peer),
+		connLimits: ConnLimits{
+			MaxInbound: 2,
+		},
+	}
+
+	oldest := newLimitsTestPeer(n, "1.1.1.1", true, 1)
+	newest := newLimitsTestPeer(n, "2.2.2.2", true, 2)
+	outbound := newLimitsTestPeer(n, "3.3.3.3", false, 0)
+	n.peers[oldest.id.Key()] = oldest
+	n.peers[newest.id.Key()] = newest
+	n.peers[outbound.id.Key()] = outbound
+
+	// Outbound peers aren't counted against the inbound limit
+	evict, err := n.admit(newLimitsTestPeer(n, "4.4.4.4", false, 0))
+	assert.NoError(t, err)
+	assert.Nil(t, evict)
+
+	// Non-validators are rejected once the limit is reached
+	_, err = n.admit(newLimitsTestPeer(n, "4.4.4.4", true, 0))
+	assert.Equal(t, errTooManyInbound, err)
+
+	// Validators evict the oldest non-validator
+	validator := newLimitsTestPeer(n, "4.4.4.4", true, 0)
+	assert.NoError(t, n.vdrs.AddWeight(validator.id, 1))
+	evict, err = n.admit(validator)
+	assert.NoError(t, err)
+	assert.Equal(t, oldest, evict)
+
+	// Validators are never evicted
+	assert.NoError(t, n.vdrs.AddWeight(oldest.id, 1))
+	assert.NoError(t, n.vdrs.AddWeight(newest.id, 1))
+	_, err = n.admit(validator)
+	assert.Equal(t, errTooManyInbound, err)
+}
+
+func TestAdmitIPLimits(t *testing.T) {
+	n := &network{
+		vdrs:  validators.NewSet(),
+		peers: make(map[[20]byte]*peer),
+		connLimits: ConnLimits{
+			MaxPerIP:            1,
+			MaxPerIPRange:       2,
+			IPRangePrefixLength: DefaultIPRangePrefixLength,
+		},
+	}
+
+	p0 := newLimitsTestPeer(n, "10.0.0.1", true, 1)
+	p1 := newLimitsTestPeer(n, "10.0.0.2", false, 2)
+	n.peers[p0.id.Key()] = p0
+	n.peers[p1.id.Key()] = p1
+
+	_, err := n.admit(newLimitsTestPeer(n, "10.0.0.1", true, 0))
+	assert.Equal(t, errTooManyFromIP, err)
+
+	_, err = n.admit(newLimitsTestPeer(n, "10.0.0.3", true, 0))
+	assert.Equal(t, errTooManyFromRange, err)
+
+	evict, err := n.admit(newLimitsTestPeer(n, "10.0.1.1", true, 0))
+	assert.NoError(t, err)
+	assert.Nil(t, evict)
+
+	// A validator from the same IP evicts the peer at that IP, which also
+	// makes room in the range
+	validator := newLimitsTestPeer(n, "10.0.0.1", true, 0)
+	assert.NoError(t, n.vdrs.AddWeight(validator.id, 1))
+	evict, err = n.admit(validator)
+	assert.NoError(t, err)
+	assert.Equal(t, p0, evict)
+
+	validator = newLimitsTestPeer(n, "10.0.0.2", true, 0)
+	assert.NoError(t, n.vdrs.AddWeight(validator.id, 1))
+	evict, err = n.admit(validator)
+	assert.NoError(t, err)
+	assert.Equal(t, p1, evict)
+
+	// A single eviction can't satisfy both limits
+	p2 := newLimitsTestPeer(n, "10.0.0.3", true, 3)
+	n.peers[p2.id.Key()] = p2
+	validator = newLimitsTestPeer(n, "10.0.0.2", true, 0)
+	assert.NoError(t, n.vdrs.AddWeight(validator.id, 1))
+	_, err = n.admit(validator)
+	assert.Equal(t, errTooManyFromRange, err)
+}
+
+func TestCanDial(t *testing.T) {
+	n := &network{
+		vdrs:              validators.NewSet(),
+		peers:             make(map[[20]byte]*peer),
+		knownValidatorIPs: make(map[string]struct{}),
+		connLimits: ConnLimits{
+			MaxOutbound: 1,
+		},
+	}
+	validatorIP := utils.IPDesc{IP: net.ParseIP("2.2.2.2"), Port: 9651}
+	n.markValidatorIP(validatorIP)
+
+	// Inbound peers aren't counted against the outbound limit
+	inbound := newLimitsTestPeer(n, "1.1.1.1", true, 0)
+	n.peers[inbound.id.Key()] = inbound
+	assert.NoError(t, n.canDial("3.3.3.3:9651"))
+
+	// Once the limit is reached, only validators are dialed, as they can evict
+	// a non-validator
+	outbound := newLimitsTestPeer(n, "4.4.4.4", false, 0)
+	n.peers[outbound.id.Key()] = outbound
+	assert.Equal(t, errTooManyOutbound, n.canDial("3.3.3.3:9651"))
+	assert.NoError(t, n.canDial(validatorIP.String()))
+
+	// Validators aren't dialed if no peer can be evicted
+	assert.NoError(t, n.vdrs.AddWeight(outbound.id, 1))
+	assert.Equal(t, errTooManyOutbound, n.canDial(validatorIP.String()))
+}
+
+func TestTryAddPeerKeepsRedialingValidators(t *testing.T) {
+	bans, err := newBanList(&timer.Clock{}, memdb.New())
+	assert.NoError(t, err)
+	n := &network{
+		log:               logging.NoLog{},
+		id:                ids.GenerateTestShortID(),
+		vdrs:              validators.NewSet(),
+		beacons:           validators.NewSet(),
+		bans:              bans,
+		peers:             make(map[[20]byte]*peer),
+		disconnectedIPs:   make(map[string]struct{}),
+		retryDelay:        make(map[string]time.Duration),
+		knownValidatorIPs: make(map[string]struct{}),
+		connLimits: ConnLimits{
+			MaxOutbound: 1,
+		},
+	}
+	validator := newLimitsTestPeer(n, "1.1.1.1", false, 0)
+	assert.NoError(t, n.vdrs.AddWeight(validator.id, 1))
+	n.peers[validator.id.Key()] = validator
+
+	newPeer := func(ip string) *peer {
+		p := newLimitsTestPeer(n, ip, false, 0)
+		p.ip = utils.IPDesc{IP: net.ParseIP(ip), Port: 9651}
+		n.disconnectedIPs[p.ip.String()] = struct{}{}
+		return p
+	}
+
+	// Non-validators that aren't admitted are no longer dialed
+	nonValidator := newPeer("2.2.2.2")
+	assert.Equal(t, errTooManyOutbound, n.tryAddPeer(nonValidator))
+	assert.NotContains(t, n.disconnectedIPs, nonValidator.ip.String())
+
+	// Validators and beacons that aren't admitted are redialed
+	otherValidator := newPeer("3.3.3.3")
+	assert.NoError(t, n.vdrs.AddWeight(otherValidator.id, 1))
+	assert.Equal(t, errTooManyOutbound, n.tryAddPeer(otherValidator))
+	assert.Contains(t, n.disconnectedIPs, otherValidator.ip.String())
+	assert.Contains(t, n.knownValidatorIPs, otherValidator.ip.String())
+
+	beacon := newPeer("4.4.4.4")
+	assert.NoError(t, n.beacons.AddWeight(beacon.id, 1))
+	assert.Equal(t, errTooManyOutbound, n.tryAddPeer(beacon))
+	assert.Contains(t, n.disconnectedIPs, beacon.ip.String())
+}
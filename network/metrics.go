@@ -48,16 +48,50 @@ func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registere
 	return nil
 }
 
+type priorityMetrics struct {
+	numDropped, numThrottled prometheus.Counter
+}
+
+func (pm *priorityMetrics) initialize(p priority, registerer prometheus.Registerer) error {
+	pm.numDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_priority_dropped", p),
+		Help:      fmt.Sprintf("Number of %s priority messages dropped before being sent", p),
+	})
+	pm.numThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_priority_throttled", p),
+		Help:      fmt.Sprintf("Number of %s priority messages delayed by bandwidth throttling", p),
+	})
+
+	if err := registerer.Register(pm.numDropped); err != nil {
+		return fmt.Errorf("failed to register dropped statistics of %s priority due to %s",
+			p, err)
+	}
+	if err := registerer.Register(pm.numThrottled); err != nil {
+		return fmt.Errorf("failed to register throttled statistics of %s priority due to %s",
+			p, err)
+	}
+	return nil
+}
+
 type metrics struct {
 	numPeers prometheus.Gauge
 
+	compressedBytesSent, compressedRawBytesSent,
+	compressedBytesReceived, compressedRawBytesReceived prometheus.Counter
+
+	priorities [numPriorities]priorityMetrics
+
 	getVersion, version,
 	getPeerlist, peerlist,
 	ping, pong,
 	getAcceptedFrontier, acceptedFrontier,
 	getAccepted, accepted,
 	get, getAncestors, put, multiPut,
-	pushQuery, pullQuery, chits messageMetrics
+	pushQuery, pullQuery, chits,
+	appRequest, appResponse, appGossip,
+	signedPeerList, uptimePong messageMetrics
 }
 
 func (m *metrics) initialize(registerer prometheus.Registerer) error {
@@ -67,11 +101,51 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		Help:      "Number of network peers",
 	})
 
+	m.compressedBytesSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "compressed_bytes_sent",
+		Help:      "Number of bytes sent in compressed messages",
+	})
+	m.compressedRawBytesSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "compressed_raw_bytes_sent",
+		Help:      "Number of bytes the compressed messages that were sent would have used uncompressed",
+	})
+	m.compressedBytesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "compressed_bytes_received",
+		Help:      "Number of bytes received in compressed messages",
+	})
+	m.compressedRawBytesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "compressed_raw_bytes_received",
+		Help:      "Number of bytes the compressed messages that were received used after decompression",
+	})
+
 	errs := wrappers.Errs{}
 	if err := registerer.Register(m.numPeers); err != nil {
 		errs.Add(fmt.Errorf("failed to register peers statistics due to %s",
 			err))
 	}
+	if err := registerer.Register(m.compressedBytesSent); err != nil {
+		errs.Add(fmt.Errorf("failed to register compressed bytes sent statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.compressedRawBytesSent); err != nil {
+		errs.Add(fmt.Errorf("failed to register compressed raw bytes sent statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.compressedBytesReceived); err != nil {
+		errs.Add(fmt.Errorf("failed to register compressed bytes received statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.compressedRawBytesReceived); err != nil {
+		errs.Add(fmt.Errorf("failed to register compressed raw bytes received statistics due to %s",
+			err))
+	}
+	for p := range m.priorities {
+		errs.Add(m.priorities[p].initialize(priority(p), registerer))
+	}
 	errs.Add(
 		m.getVersion.initialize(GetVersion, registerer),
 		m.version.initialize(Version, registerer),
@@ -90,6 +164,11 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		m.pushQuery.initialize(PushQuery, registerer),
 		m.pullQuery.initialize(PullQuery, registerer),
 		m.chits.initialize(Chits, registerer),
+		m.appRequest.initialize(AppRequest, registerer),
+		m.appResponse.initialize(AppResponse, registerer),
+		m.appGossip.initialize(AppGossip, registerer),
+		m.signedPeerList.initialize(SignedPeerList, registerer),
+		m.uptimePong.initialize(UptimePong, registerer),
 	)
 	return errs.Err
 }
@@ -130,6 +209,16 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.pullQuery
 	case Chits:
 		return &m.chits
+	case AppRequest:
+		return &m.appRequest
+	case AppResponse:
+		return &m.appResponse
+	case AppGossip:
+		return &m.appGossip
+	case SignedPeerList:
+		return &m.signedPeerList
+	case UptimePong:
+		return &m.uptimePong
 	default:
 		return nil
 	}
@@ -3,17 +3,31 @@
 
 package network
 
+import (
+	"sync"
+)
+
 // Msg represents a set of fields that can be serialized into a byte stream
 type Msg interface {
 	Op() Op
 	Get(Field) interface{}
 	Bytes() []byte
+
+	// CompressedBytes returns this message in bytes with its body compressed
+	// with [algorithm]. Returns nil if the message couldn't be made smaller by
+	// compressing it.
+	CompressedBytes(algorithm byte) []byte
 }
 
 type msg struct {
 	op     Op
 	fields map[Field]interface{}
 	bytes  []byte
+
+	// the compressed forms of [bytes] are only calculated once per algorithm,
+	// even if the message is sent to many peers
+	compressLock    sync.Mutex
+	compressedBytes map[byte][]byte
 }
 
 // Field returns the value of the specified field in this message
@@ -24,3 +38,23 @@ func (msg *msg) Get(field Field) interface{} { return msg.fields[field] }
 
 // Bytes returns this message in bytes
 func (msg *msg) Bytes() []byte { return msg.bytes }
+
+// CompressedBytes returns this message in bytes with its body compressed
+func (msg *msg) CompressedBytes(algorithm byte) []byte {
+	msg.compressLock.Lock()
+	defer msg.compressLock.Unlock()
+
+	if compressedBytes, ok := msg.compressedBytes[algorithm]; ok {
+		return compressedBytes
+	}
+	if msg.compressedBytes == nil {
+		msg.compressedBytes = make(map[byte][]byte, 1)
+	}
+
+	compressed, err := compress(algorithm, msg.bytes)
+	if err != nil || len(compressed) >= len(msg.bytes) {
+		compressed = nil
+	}
+	msg.compressedBytes[algorithm] = compressed
+	return compressed
+}
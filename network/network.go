@@ -4,6 +4,9 @@
 package network
 
 import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -15,6 +18,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -24,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/timer"
@@ -45,16 +50,35 @@ const (
 	defaultGetVersionTimeout                         = 2 * time.Second
 	defaultAllowPrivateIPs                           = true
 	defaultGossipSize                                = 50
-	defaultPingPongTimeout                           = time.Minute
-	defaultPingFrequency                             = 3 * defaultPingPongTimeout / 4
+	DefaultPingPongTimeout                           = time.Minute
+	DefaultPingFrequency                             = 3 * DefaultPingPongTimeout / 4
 	defaultReadBufferSize                            = 16 * 1024
 	defaultReadHandshakeTimeout                      = 15 * time.Second
 	defaultConnMeterCacheSize                        = 10000
+	DefaultCompressionThreshold                      = 1 << 10 // 1KB
+	maxLatestIPs                                     = 10000
 )
 
 var (
-	errNetworkClosed = errors.New("network closed")
-	errPeerIsMyself  = errors.New("peer is myself")
+	errNetworkClosed    = errors.New("network closed")
+	errPeerIsMyself     = errors.New("peer is myself")
+	errPeerNotConnected = errors.New("not connected to peer")
+	errNodeIDBanned     = errors.New("node ID is banned")
+	errIPBanned         = errors.New("IP is banned")
+	errNotStaticPeer    = errors.New("peer isn't a static peer")
+
+	// minCompressionVersion is the first version that is able to parse the
+	// compression algorithms advertised in a version message
+	minCompressionVersion = version.NewDefaultVersion(constants.PlatformName, 1, 0, 7)
+	// minAppMsgsVersion is the first version that is able to parse application
+	// level messages
+	minAppMsgsVersion = version.NewDefaultVersion(constants.PlatformName, 1, 0, 7)
+	// minSignedIPsVersion is the first version that is able to parse signed
+	// peer lists
+	minSignedIPsVersion = version.NewDefaultVersion(constants.PlatformName, 1, 0, 7)
+	// minUptimePongVersion is the first version that is able to parse pongs
+	// that report an uptime
+	minUptimePongVersion = version.NewDefaultVersion(constants.PlatformName, 1, 0, 7)
 )
 
 func init() { rand.Seed(time.Now().UnixNano()) }
@@ -79,13 +103,29 @@ type Network interface {
 
 	// Attempt to connect to this IP. Thread safety must be managed internally
 	// to the network. The network will never stop attempting to connect to this
-	// IP.
-	Track(ip utils.IPDesc)
+	// IP. Returns an error if the network is only connecting to static peers
+	// and [ip] isn't one of them.
+	Track(ip utils.IPDesc) error
 
 	// Returns the description of the nodes this network is currently connected
 	// to externally. Thread safety must be managed internally to the network.
 	Peers() []PeerID
 
+	// Close the connection to this node and stop attempting to reconnect to
+	// it. The node is still able to connect to this node again. Thread safety
+	// must be managed internally to the network.
+	Disconnect(nodeID ids.ShortID) error
+
+	// Disconnect from this node and prevent it from connecting for the
+	// provided duration. The ban is persisted across restarts. Thread safety
+	// must be managed internally to the network.
+	Ban(nodeID ids.ShortID, duration time.Duration) error
+
+	// Disconnect from any node at this IP and prevent connections to and from
+	// it for the provided duration. The ban is persisted across restarts.
+	// Thread safety must be managed internally to the network.
+	BanIP(ip net.IP, duration time.Duration) error
+
 	// Close this network and all existing connections it has. Thread safety
 	// must be managed internally to the network. Calling close multiple times
 	// will return a nil error.
@@ -93,6 +133,15 @@ type Network interface {
 
 	// Return the IP of the node
 	IP() utils.IPDesc
+
+	// Returns true if this node has finished the handshake with [nodeID].
+	// Thread safety must be managed internally to the network.
+	IsConnected(nodeID ids.ShortID) bool
+
+	// Returns how the connected validators perceive this node's uptime.
+	// Returns false if this node isn't a validator. Thread safety must be
+	// managed internally to the network.
+	NodeUptime() (UptimeResult, bool)
 }
 
 type network struct {
@@ -134,6 +183,20 @@ type network struct {
 	readHandshakeTimeout               time.Duration
 	connMeterMaxConns                  int
 	connMeter                          ConnMeter
+	compressionEnabled                 bool
+	compressionThreshold               int
+	stakingKey                         crypto.Signer     // nil if the node doesn't sign its IP
+	stakingCert                        *x509.Certificate // nil if the node doesn't sign its IP
+	peerBandwidth                      uint64            // bytes per second that may be sent to each peer, 0 is unlimited
+	peerBandwidthBurst                 uint64
+	bandwidth                          *tokenBucket // limits the bytes per second that may be sent to all peers
+	bans                               *banList
+	uptimes                            UptimeCalculator    // nil if this node doesn't report the uptimes of its peers
+	uptimeRequirement                  float64             // uptime, in [0, 1], required to be rewarded for staking
+	staticPeersOnly                    bool                // if true, only the static peers are connected to
+	staticPeerIDs                      ids.ShortSet        // node IDs that may connect when [staticPeersOnly] is true
+	staticPeerIPs                      map[string]struct{} // IPs that may be dialed when [staticPeersOnly] is true
+	connLimits                         ConnLimits
 	executor                           timer.Executor
 	b                                  Builder
 	// stateLock should never be held when grabbing a peer lock
@@ -146,6 +209,18 @@ type network struct {
 	// TODO: bound the size of [myIPs] to avoid DoS. LRU caching would be ideal
 	myIPs map[string]struct{} // set of IPs that resulted in my ID.
 	peers map[[20]byte]*peer
+	// newest valid IP claim for each validator. Holds at most [maxLatestIPs]
+	// claims.
+	latestIPs map[[20]byte]utils.IPCertDesc
+	// IPs that are known to belong to validators. They're dialed even if the
+	// outbound limit was reached, as long as a non-validator can be evicted to
+	// make room for them. Holds at most [maxLatestIPs] IPs.
+	knownValidatorIPs map[string]struct{}
+
+	// mySignedIP is the most recent claim this node made about its own IP.
+	// Only regenerated when this node's IP changes.
+	mySignedIP     utils.IPCertDesc
+	mySignedIPLock sync.Mutex
 
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
@@ -195,6 +270,23 @@ func NewDefaultNetwork(
 	restartOnDisconnected bool,
 	disconnectedCheckFreq time.Duration,
 	disconnectedRestartTimeout time.Duration,
+	compressionEnabled bool,
+	compressionThreshold int,
+	stakingKey crypto.Signer,
+	stakingCert *x509.Certificate,
+	peerBandwidth uint64,
+	peerBandwidthBurst uint64,
+	bandwidth uint64,
+	bandwidthBurst uint64,
+	db database.Database,
+	pingPongTimeout time.Duration,
+	pingFrequency time.Duration,
+	uptimes UptimeCalculator,
+	uptimeRequirement float64,
+	staticPeersOnly bool,
+	staticPeerIDs ids.ShortSet,
+	staticPeerIPs []utils.IPDesc,
+	connLimits ConnLimits,
 ) Network {
 	return NewNetwork(
 		registerer,
@@ -224,8 +316,8 @@ func NewDefaultNetwork(
 		defaultGetVersionTimeout,
 		defaultAllowPrivateIPs,
 		defaultGossipSize,
-		defaultPingPongTimeout,
-		defaultPingFrequency,
+		pingPongTimeout,
+		pingFrequency,
 		defaultReadBufferSize,
 		defaultReadHandshakeTimeout,
 		connMeterResetDuration,
@@ -235,6 +327,21 @@ func NewDefaultNetwork(
 		restartOnDisconnected,
 		disconnectedCheckFreq,
 		disconnectedRestartTimeout,
+		compressionEnabled,
+		compressionThreshold,
+		stakingKey,
+		stakingCert,
+		peerBandwidth,
+		peerBandwidthBurst,
+		bandwidth,
+		bandwidthBurst,
+		db,
+		uptimes,
+		uptimeRequirement,
+		staticPeersOnly,
+		staticPeerIDs,
+		staticPeerIPs,
+		connLimits,
 	)
 }
 
@@ -278,6 +385,21 @@ func NewNetwork(
 	restartOnDisconnected bool,
 	disconnectedCheckFreq time.Duration,
 	disconnectedRestartTimeout time.Duration,
+	compressionEnabled bool,
+	compressionThreshold int,
+	stakingKey crypto.Signer,
+	stakingCert *x509.Certificate,
+	peerBandwidth uint64,
+	peerBandwidthBurst uint64,
+	bandwidth uint64,
+	bandwidthBurst uint64,
+	db database.Database,
+	uptimes UptimeCalculator,
+	uptimeRequirement float64,
+	staticPeersOnly bool,
+	staticPeerIDs ids.ShortSet,
+	staticPeerIPs []utils.IPDesc,
+	connLimits ConnLimits,
 ) Network {
 	// #nosec G404
 	netw := &network{
@@ -318,10 +440,24 @@ func NewNetwork(
 		retryDelay:                         make(map[string]time.Duration),
 		myIPs:                              map[string]struct{}{ip.IP().String(): {}},
 		peers:                              make(map[[20]byte]*peer),
+		latestIPs:                          make(map[[20]byte]utils.IPCertDesc),
+		knownValidatorIPs:                  make(map[string]struct{}),
 		readBufferSize:                     readBufferSize,
 		readHandshakeTimeout:               readHandshakeTimeout,
 		connMeter:                          NewConnMeter(connMeterResetDuration, connMeterCacheSize),
 		connMeterMaxConns:                  connMeterMaxConns,
+		compressionEnabled:                 compressionEnabled,
+		compressionThreshold:               compressionThreshold,
+		stakingKey:                         stakingKey,
+		stakingCert:                        stakingCert,
+		peerBandwidth:                      peerBandwidth,
+		peerBandwidthBurst:                 peerBandwidthBurst,
+		uptimes:                            uptimes,
+		uptimeRequirement:                  uptimeRequirement,
+		staticPeersOnly:                    staticPeersOnly,
+		staticPeerIDs:                      staticPeerIDs,
+		staticPeerIPs:                      make(map[string]struct{}, len(staticPeerIPs)),
+		connLimits:                         connLimits,
 		restartOnDisconnected:              restartOnDisconnected,
 		connectedCheckerCloser:             make(chan struct{}),
 		disconnectedCheckFreq:              disconnectedCheckFreq,
@@ -329,6 +465,18 @@ func NewNetwork(
 		restarter:                          restarter,
 	}
 
+	for _, ip := range staticPeerIPs {
+		netw.staticPeerIPs[ip.String()] = struct{}{}
+	}
+
+	netw.bandwidth = newTokenBucket(&netw.clock, bandwidth, bandwidthBurst)
+
+	bans, err := newBanList(&netw.clock, db)
+	if err != nil {
+		log.Error("loading the persisted bans failed with: %s", err)
+	}
+	netw.bans = bans
+
 	if err := netw.initialize(registerer); err != nil {
 		log.Warn("initializing network metrics failed with: %s", err)
 	}
@@ -634,6 +782,75 @@ func (n *network) Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint3
 	}
 }
 
+// AppRequest implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppRequest(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, appRequestBytes []byte) {
+	msg, err := n.b.AppRequest(chainID, requestID, uint64(deadline.Sub(n.clock.Time())), appRequestBytes)
+	if err != nil {
+		n.log.Error("failed to build AppRequest(%s, %d): %s. len(appRequestBytes): %d",
+			chainID,
+			requestID,
+			err,
+			len(appRequestBytes))
+		for validatorIDKey := range validatorIDs {
+			vID := ids.NewShortID(validatorIDKey)
+			n.executor.Add(func() { n.router.AppRequestFailed(vID, chainID, requestID) })
+		}
+		return // Packing message failed
+	}
+
+	for _, peerElement := range n.getPeers(validatorIDs) {
+		peer := peerElement.peer
+		vID := peerElement.id
+		if peer == nil || !peer.connected.GetValue() || !peer.appMsgs.GetValue() || !peer.Send(msg) {
+			n.log.Debug("failed to send AppRequest(%s, %s, %d)",
+				vID,
+				chainID,
+				requestID)
+			n.log.Verbo("appRequestBytes: %s", formatting.DumpBytes{Bytes: appRequestBytes})
+			n.executor.Add(func() { n.router.AppRequestFailed(vID, chainID, requestID) })
+			n.appRequest.numFailed.Inc()
+		} else {
+			n.appRequest.numSent.Inc()
+		}
+	}
+}
+
+// AppResponse implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte) {
+	msg, err := n.b.AppResponse(chainID, requestID, appResponseBytes)
+	if err != nil {
+		n.log.Error("failed to build AppResponse(%s, %d): %s. len(appResponseBytes): %d",
+			chainID,
+			requestID,
+			err,
+			len(appResponseBytes))
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.appMsgs.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send AppResponse(%s, %s, %d)",
+			validatorID,
+			chainID,
+			requestID)
+		n.log.Verbo("appResponseBytes: %s", formatting.DumpBytes{Bytes: appResponseBytes})
+		n.appResponse.numFailed.Inc()
+	} else {
+		n.appResponse.numSent.Inc()
+	}
+}
+
+// AppGossip attempts to gossip the application level message to the network
+// assumes the stateLock is not held.
+func (n *network) AppGossip(chainID ids.ID, appGossipBytes []byte) {
+	if err := n.gossipAppMsg(chainID, appGossipBytes); err != nil {
+		n.log.Debug("failed to AppGossip(%s): %s", chainID, err)
+		n.log.Verbo("appGossipBytes:\n%s", formatting.DumpBytes{Bytes: appGossipBytes})
+	}
+}
+
 // Gossip attempts to gossip the container to the network
 // assumes the stateLock is not held.
 func (n *network) Gossip(chainID, containerID ids.ID, container []byte) {
@@ -706,6 +923,7 @@ func (n *network) Dispatch() error {
 				&peer{
 					net:          n,
 					conn:         conn,
+					inbound:      true,
 					tickerCloser: make(chan struct{}),
 				},
 				n.serverUpgrader,
@@ -723,22 +941,112 @@ func (n *network) Peers() []PeerID {
 	n.stateLock.RLock()
 	defer n.stateLock.RUnlock()
 
+	now := n.clock.Time()
 	peers := make([]PeerID, 0, len(n.peers))
 	for _, peer := range n.peers {
 		if peer.connected.GetValue() {
-			peers = append(peers, PeerID{
-				IP:           peer.conn.RemoteAddr().String(),
-				PublicIP:     peer.getIP().String(),
-				ID:           peer.id.PrefixedString(constants.NodeIDPrefix),
-				Version:      peer.versionStr.GetValue().(string),
-				LastSent:     time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
-				LastReceived: time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
-			})
+			connectedSince := time.Unix(atomic.LoadInt64(&peer.connectedTime), 0)
+			peerID := PeerID{
+				IP:                peer.conn.RemoteAddr().String(),
+				PublicIP:          peer.getIP().String(),
+				ID:                peer.id.PrefixedString(constants.NodeIDPrefix),
+				Version:           peer.versionStr.GetValue().(string),
+				LastSent:          time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
+				LastReceived:      time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+				Inbound:           peer.inbound,
+				Validator:         n.vdrs.Contains(peer.id),
+				ConnectedSince:    connectedSince,
+				Uptime:            now.Sub(connectedSince).Truncate(time.Second).String(),
+				SendQueueMessages: json.Uint64(peer.sendQueueLen()),
+				SendQueueBytes:    json.Uint64(atomic.LoadInt64(&peer.pendingBytes)),
+				Messages:          peer.messageStats(),
+				PingLatency:       json.Uint64(atomic.LoadInt64(&peer.pingRTT)),
+			}
+			if uptime, ok := peer.observedUptime(); ok {
+				observedUptime := json.Uint8(uptime)
+				peerID.ObservedUptime = &observedUptime
+			}
+			if peer.cert != nil {
+				certExpiry := peer.cert.NotAfter
+				peerID.CertExpiry = &certExpiry
+			}
+			peers = append(peers, peerID)
 		}
 	}
 	return peers
 }
 
+// IsConnected implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) IsConnected(nodeID ids.ShortID) bool {
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	p, ok := n.peers[nodeID.Key()]
+	return ok && p.connected.GetValue()
+}
+
+// Disconnect implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) Disconnect(nodeID ids.ShortID) error {
+	n.stateLock.RLock()
+	p, ok := n.peers[nodeID.Key()]
+	n.stateLock.RUnlock()
+
+	if !ok {
+		return errPeerNotConnected
+	}
+
+	n.log.Info("disconnecting from %s", nodeID.PrefixedString(constants.NodeIDPrefix))
+	p.discardIP()
+	return nil
+}
+
+// Ban implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) Ban(nodeID ids.ShortID, duration time.Duration) error {
+	if err := n.bans.banNodeID(nodeID, duration); err != nil {
+		return err
+	}
+	n.log.Info("banned %s for %s", nodeID.PrefixedString(constants.NodeIDPrefix), duration)
+
+	n.stateLock.RLock()
+	p, ok := n.peers[nodeID.Key()]
+	n.stateLock.RUnlock()
+
+	if ok {
+		p.discardIP()
+	}
+	return nil
+}
+
+// BanIP implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) BanIP(ip net.IP, duration time.Duration) error {
+	if err := n.bans.banIP(ip, duration); err != nil {
+		return err
+	}
+	n.log.Info("banned %s for %s", ip, duration)
+
+	n.stateLock.RLock()
+	banned := []*peer(nil)
+	for _, p := range n.peers {
+		if p.getIP().IP.Equal(ip) {
+			banned = append(banned, p)
+			continue
+		}
+		if addr, err := utils.ToIPDesc(p.conn.RemoteAddr().String()); err == nil && addr.IP.Equal(ip) {
+			banned = append(banned, p)
+		}
+	}
+	n.stateLock.RUnlock()
+
+	for _, p := range banned {
+		p.discardIP()
+	}
+	return nil
+}
+
 // Close implements the Network interface
 // assumes the stateLock is not held.
 func (n *network) Close() error {
@@ -782,11 +1090,16 @@ func (n *network) close() {
 
 // Track implements the Network interface
 // assumes the stateLock is not held.
-func (n *network) Track(ip utils.IPDesc) {
+func (n *network) Track(ip utils.IPDesc) error {
+	if _, ok := n.staticPeerIPs[ip.String()]; n.staticPeersOnly && !ok {
+		return errNotStaticPeer
+	}
+
 	n.stateLock.Lock()
 	defer n.stateLock.Unlock()
 
 	n.track(ip)
+	return nil
 }
 
 func (n *network) IP() utils.IPDesc {
@@ -816,7 +1129,7 @@ func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte)
 		return err
 	}
 	for _, index := range indices {
-		if allPeers[int(index)].Send(msg) {
+		if allPeers[int(index)].SendGossip(msg) {
 			n.put.numSent.Inc()
 		} else {
 			n.put.numFailed.Inc()
@@ -825,13 +1138,58 @@ func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte)
 	return nil
 }
 
+// assumes the stateLock is not held.
+func (n *network) gossipAppMsg(chainID ids.ID, appGossipBytes []byte) error {
+	msg, err := n.b.AppGossip(chainID, appGossipBytes)
+	if err != nil {
+		return fmt.Errorf("attempted to pack too large of an AppGossip message.\nMessage length: %d", len(appGossipBytes))
+	}
+
+	allPeers := n.getAllPeers()
+	appPeers := make([]*peer, 0, len(allPeers))
+	for _, peer := range allPeers {
+		if peer.connected.GetValue() && peer.appMsgs.GetValue() {
+			appPeers = append(appPeers, peer)
+		}
+	}
+
+	numToGossip := n.gossipSize
+	if numToGossip > len(appPeers) {
+		numToGossip = len(appPeers)
+	}
+
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(appPeers))); err != nil {
+		return err
+	}
+	indices, err := s.Sample(numToGossip)
+	if err != nil {
+		return err
+	}
+	for _, index := range indices {
+		if appPeers[int(index)].SendGossip(msg) {
+			n.appGossip.numSent.Inc()
+		} else {
+			n.appGossip.numFailed.Inc()
+		}
+	}
+	return nil
+}
+
 // assumes the stateLock is held.
 func (n *network) track(ip utils.IPDesc) {
 	if n.closed.GetValue() {
 		return
 	}
 
+	if n.bans.isIPBanned(ip.IP) {
+		return
+	}
+
 	str := ip.String()
+	if _, ok := n.staticPeerIPs[str]; n.staticPeersOnly && !ok {
+		return
+	}
 	if _, ok := n.disconnectedIPs[str]; ok {
 		return
 	}
@@ -846,6 +1204,126 @@ func (n *network) track(ip utils.IPDesc) {
 	go n.connectTo(ip)
 }
 
+// removes the IP claims of nodes that are no longer validators
+// assumes the stateLock is held
+func (n *network) pruneLatestIPs() {
+	for key := range n.latestIPs {
+		if !n.vdrs.Contains(ids.NewShortID(key)) {
+			delete(n.latestIPs, key)
+		}
+	}
+}
+
+// assumes the stateLock is not held. Returns an error if [ipCert] isn't a valid
+// claim.
+func (n *network) trackSignedIP(ipCert utils.IPCertDesc) error {
+	nodeID := certToID(ipCert.Cert)
+	if nodeID.Equals(n.id) {
+		return nil
+	}
+	if !n.vdrs.Contains(nodeID) {
+		return errClaimFromNonStaker
+	}
+
+	maxTime := n.clock.Unix() + uint64(n.maxClockDifference.Seconds())
+	if ipCert.Time > maxTime {
+		return errClaimFromFuture
+	}
+
+	// Avoid verifying the signature if the claim would be dropped anyways
+	key := nodeID.Key()
+	n.stateLock.RLock()
+	latest, ok := n.latestIPs[key]
+	n.stateLock.RUnlock()
+	if ok && latest.Time >= ipCert.Time {
+		return nil
+	}
+
+	if err := verifyIP(ipCert); err != nil {
+		return err
+	}
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	latest, ok = n.latestIPs[key]
+	if ok && latest.Time >= ipCert.Time {
+		return nil
+	}
+	if !ok && len(n.latestIPs) >= maxLatestIPs {
+		n.pruneLatestIPs()
+		if len(n.latestIPs) >= maxLatestIPs {
+			return errTooManyClaims
+		}
+	}
+	n.latestIPs[key] = ipCert
+	n.markValidatorIP(ipCert.IPDesc)
+
+	if ok && !latest.IPDesc.Equal(ipCert.IPDesc) {
+		// The node has moved, so we should stop attempting to connect to its
+		// old IP
+		str := latest.IPDesc.String()
+		delete(n.disconnectedIPs, str)
+		delete(n.retryDelay, str)
+		delete(n.knownValidatorIPs, str)
+	}
+
+	if _, connected := n.peers[key]; connected {
+		return nil
+	}
+
+	ip := ipCert.IPDesc
+	if !ip.Equal(n.ip.IP()) &&
+		!ip.IsZero() &&
+		(n.allowPrivateIPs || !ip.IsPrivate()) {
+		n.track(ip)
+	}
+	return nil
+}
+
+// assumes the stateLock is not held. Returns this node's claim to its current
+// IP, signing a new claim if the IP has changed.
+func (n *network) signedIP() (utils.IPCertDesc, error) {
+	if n.stakingKey == nil || n.stakingCert == nil {
+		return utils.IPCertDesc{}, errNoSigner
+	}
+
+	ip := n.ip.IP()
+	if ip.IsZero() {
+		return utils.IPCertDesc{}, errNoIP
+	}
+
+	n.mySignedIPLock.Lock()
+	defer n.mySignedIPLock.Unlock()
+
+	if n.mySignedIP.Cert != nil && n.mySignedIP.IPDesc.Equal(ip) {
+		return n.mySignedIP, nil
+	}
+
+	timestamp := n.clock.Unix()
+	sig, err := signIP(n.stakingKey, ip, timestamp)
+	if err != nil {
+		return utils.IPCertDesc{}, err
+	}
+	n.mySignedIP = utils.IPCertDesc{
+		Cert:      n.stakingCert,
+		IPDesc:    ip,
+		Time:      timestamp,
+		Signature: sig,
+	}
+	return n.mySignedIP, nil
+}
+
+// assumes the stateLock is not held. Sends the signed peer list to [p] if it is
+// able to parse it, otherwise the unsigned peer list is sent.
+func (n *network) sendPeerList(p *peer, msg, signedMsg Msg) {
+	if p.signedIPs.GetValue() {
+		p.Send(signedMsg)
+	} else {
+		p.Send(msg)
+	}
+}
+
 // assumes the stateLock is not held. Only returns after the network is closed.
 func (n *network) gossip() {
 	t := time.NewTicker(n.peerListGossipSpacing)
@@ -883,6 +1361,15 @@ func (n *network) gossip() {
 			continue
 		}
 
+		signedIPs := n.validatorSignedIPs()
+		signedMsg, err := n.b.SignedPeerList(signedIPs)
+		if err != nil {
+			n.log.Error("failed to build signed peer list to gossip: %s. len(signedIPs): %d",
+				err,
+				len(signedIPs))
+			continue
+		}
+
 		stakers := make([]*peer, 0, len(allPeers))
 		nonStakers := make([]*peer, 0, len(allPeers))
 		for _, peer := range allPeers {
@@ -917,7 +1404,7 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range stakerIndices {
-			stakers[int(index)].Send(msg)
+			n.sendPeerList(stakers[int(index)], msg, signedMsg)
 		}
 
 		if err := s.Initialize(uint64(len(nonStakers))); err != nil {
@@ -934,7 +1421,7 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range nonStakerIndices {
-			nonStakers[int(index)].Send(msg)
+			n.sendPeerList(nonStakers[int(index)], msg, signedMsg)
 		}
 	}
 }
@@ -983,6 +1470,14 @@ func (n *network) connectTo(ip utils.IPDesc) {
 			return
 		}
 		n.retryDelay[str] = delay
+		if err := n.canDial(str); err != nil {
+			// The connection wouldn't be admitted, so dialing would only waste
+			// a handshake
+			n.stateLock.Unlock()
+			n.log.Verbo("not connecting to %s due to: %s. Reattempting in %s",
+				ip, err, delay)
+			continue
+		}
 		n.stateLock.Unlock()
 
 		err := n.attemptConnect(ip)
@@ -1022,6 +1517,12 @@ func (n *network) attemptConnect(ip utils.IPDesc) error {
 // assumes the stateLock is not held. Returns an error if the peer's connection
 // wasn't able to be upgraded.
 func (n *network) upgrade(p *peer, upgrader Upgrader) error {
+	if addr, err := utils.ToIPDesc(p.conn.RemoteAddr().String()); err == nil && n.bans.isIPBanned(addr.IP) {
+		_ = p.conn.Close()
+		n.stopReconnecting(p.getIP())
+		return errIPBanned
+	}
+
 	if err := p.conn.SetReadDeadline(time.Now().Add(n.readHandshakeTimeout)); err != nil {
 		_ = p.conn.Close()
 		n.log.Verbo("failed to set the read deadline with %s", err)
@@ -1035,15 +1536,30 @@ func (n *network) upgrade(p *peer, upgrader Upgrader) error {
 		return err
 	}
 
+	if n.staticPeersOnly && !n.staticPeerIDs.Contains(id) {
+		_ = conn.Close()
+		n.log.Debug("dropping connection from %s because it isn't a static peer",
+			id.PrefixedString(constants.NodeIDPrefix))
+		return errNotStaticPeer
+	}
+
 	if err := conn.SetReadDeadline(time.Time{}); err != nil {
 		_ = p.conn.Close()
 		n.log.Verbo("failed to clear the read deadline with %s", err)
 		return err
 	}
 
-	p.sender = make(chan []byte, n.sendQueueSize)
+	for i := range p.senders {
+		p.senders[i] = make(chan []byte, n.sendQueueSize)
+	}
+	p.bandwidth = newTokenBucket(&n.clock, n.peerBandwidth, n.peerBandwidthBurst)
 	p.id = id
 	p.conn = conn
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if certs := tlsConn.ConnectionState().PeerCertificates; len(certs) > 0 {
+			p.cert = certs[0]
+		}
+	}
 
 	if err := n.tryAddPeer(p); err != nil {
 		_ = p.conn.Close()
@@ -1052,9 +1568,31 @@ func (n *network) upgrade(p *peer, upgrader Upgrader) error {
 	return nil
 }
 
+// assumes the stateLock is not held. Stops attempting to connect to [ip].
+func (n *network) stopReconnecting(ip utils.IPDesc) {
+	if ip.IsZero() {
+		return
+	}
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	str := ip.String()
+	delete(n.disconnectedIPs, str)
+	delete(n.retryDelay, str)
+}
+
 // assumes the stateLock is not held. Returns an error if the peer couldn't be
 // added.
 func (n *network) tryAddPeer(p *peer) error {
+	// [evict] must be closed after the stateLock is released
+	var evict *peer
+	defer func() {
+		if evict != nil {
+			evict.Close()
+		}
+	}()
+
 	n.stateLock.Lock()
 	defer n.stateLock.Unlock()
 
@@ -1081,11 +1619,22 @@ func (n *network) tryAddPeer(p *peer) error {
 			str := ip.String()
 			delete(n.disconnectedIPs, str)
 			delete(n.retryDelay, str)
+			delete(n.knownValidatorIPs, str)
 			n.myIPs[str] = struct{}{}
 		}
 		return errPeerIsMyself
 	}
 
+	if n.bans.isNodeIDBanned(p.id) {
+		if !ip.IsZero() {
+			str := ip.String()
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+			delete(n.knownValidatorIPs, str)
+		}
+		return errNodeIDBanned
+	}
+
 	// If I am already connected to this peer, then I should close this new
 	// connection.
 	if _, ok := n.peers[key]; ok {
@@ -1097,6 +1646,28 @@ func (n *network) tryAddPeer(p *peer) error {
 		return fmt.Errorf("duplicated connection from %s at %s", p.id.PrefixedString(constants.NodeIDPrefix), ip)
 	}
 
+	isValidator := n.vdrs.Contains(p.id)
+	if isValidator {
+		n.markValidatorIP(ip)
+	}
+
+	evict, err := n.admit(p)
+	if err != nil {
+		// Validators and beacons are redialed, as a connection to them may be
+		// admitted once other peers disconnect or can be evicted
+		if !ip.IsZero() && !isValidator && !n.beacons.Contains(p.id) {
+			str := ip.String()
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+		}
+		return err
+	}
+	if evict != nil {
+		n.log.Debug("evicting %s to make room for validator %s",
+			evict.id.PrefixedString(constants.NodeIDPrefix),
+			p.id.PrefixedString(constants.NodeIDPrefix))
+	}
+
 	n.peers[key] = p
 	n.numPeers.Set(float64(len(n.peers)))
 	p.Start()
@@ -1120,6 +1691,33 @@ func (n *network) validatorIPs() []utils.IPDesc {
 	return ips
 }
 
+// assumes the stateLock is not held. Returns the newest signed IPs of
+// connections that are marked as validators, including this node's signed IP if
+// it is a validator.
+func (n *network) validatorSignedIPs() []utils.IPCertDesc {
+	n.stateLock.RLock()
+	ipCerts := make([]utils.IPCertDesc, 0, len(n.peers)+1)
+	for key, peer := range n.peers {
+		if !peer.connected.GetValue() || !n.vdrs.Contains(peer.id) {
+			continue
+		}
+		if ipCert, ok := n.latestIPs[key]; ok {
+			ipCerts = append(ipCerts, ipCert)
+		}
+	}
+	n.stateLock.RUnlock()
+
+	if n.vdrs.Contains(n.id) {
+		ipCert, err := n.signedIP()
+		if err == nil {
+			ipCerts = append(ipCerts, ipCert)
+		} else {
+			n.log.Debug("not including my IP in the signed peer list due to: %s", err)
+		}
+	}
+	return ipCerts
+}
+
 // should only be called after the peer is marked as connected. Should not be
 // called after disconnected is called with this peer.
 // assumes the stateLock is not held.
@@ -1264,3 +1862,33 @@ func (n *network) restartOnDisconnect() {
 		}
 	}
 }
+
+// supportsCompression returns true if a peer running [peerVersion] is able to
+// parse the compression algorithms advertised in a version message.
+func supportsCompression(peerVersion version.Version) bool {
+	return atLeast(peerVersion, minCompressionVersion)
+}
+
+// supportsAppMsgs returns true if a peer running [peerVersion] is able to parse
+// application level messages.
+func supportsAppMsgs(peerVersion version.Version) bool {
+	return atLeast(peerVersion, minAppMsgsVersion)
+}
+
+// supportsSignedIPs returns true if a peer running [peerVersion] is able to
+// parse signed peer lists.
+func supportsSignedIPs(peerVersion version.Version) bool {
+	return atLeast(peerVersion, minSignedIPsVersion)
+}
+
+// supportsUptimePong returns true if a peer running [peerVersion] is able to
+// parse pongs that report an uptime.
+func supportsUptimePong(peerVersion version.Version) bool {
+	return atLeast(peerVersion, minUptimePongVersion)
+}
+
+// atLeast returns true if [peerVersion] is the same application as
+// [minVersion] and isn't before it.
+func atLeast(peerVersion, minVersion version.Version) bool {
+	return peerVersion.App() == minVersion.App() && !peerVersion.Before(minVersion)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -210,6 +211,23 @@ func TestNewDefaultNetwork(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net)
 
@@ -322,6 +340,23 @@ func TestEstablishConnection(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net0)
 
@@ -346,6 +381,23 @@ func TestEstablishConnection(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net1)
 
@@ -370,6 +422,210 @@ func TestEstablishConnection(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBan(t *testing.T) {
+	log := logging.NoLog{}
+	networkID := uint32(0)
+	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	versionParser := version.NewDefaultParser()
+
+	ip0 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id0 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip0.IP().String())))
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	id1 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip1.IP().String())))
+
+	listener0 := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller0 := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+	listener1 := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 1,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller1 := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 1,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	caller0.outbounds[ip1.IP().String()] = listener1
+	caller1.outbounds[ip0.IP().String()] = listener0
+
+	serverUpgrader := NewIPUpgrader()
+	clientUpgrader := NewIPUpgrader()
+
+	vdrs := validators.NewSet()
+
+	var (
+		wg0 sync.WaitGroup
+		wg1 sync.WaitGroup
+
+		disconnected sync.WaitGroup
+	)
+	wg0.Add(1)
+	wg1.Add(1)
+	disconnected.Add(1)
+
+	handler0 := &testHandler{
+		connected: func(id ids.ShortID) {
+			if !id.Equals(id0) {
+				wg0.Done()
+			}
+		},
+		disconnected: func(id ids.ShortID) {
+			if id.Equals(id1) {
+				disconnected.Done()
+			}
+		},
+	}
+
+	handler1 := &testHandler{
+		connected: func(id ids.ShortID) {
+			if !id.Equals(id1) {
+				wg1.Done()
+			}
+		},
+	}
+
+	net0 := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id0,
+		ip0,
+		networkID,
+		appVersion,
+		versionParser,
+		listener0,
+		caller0,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler0,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
+	)
+	assert.NotNil(t, net0)
+
+	net1 := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id1,
+		ip1,
+		networkID,
+		appVersion,
+		versionParser,
+		listener1,
+		caller1,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler1,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
+	)
+	assert.NotNil(t, net1)
+
+	go func() {
+		err := net0.Dispatch()
+		assert.Error(t, err)
+	}()
+	go func() {
+		err := net1.Dispatch()
+		assert.Error(t, err)
+	}()
+
+	net0.Track(ip1.IP())
+
+	wg0.Wait()
+	wg1.Wait()
+
+	err := net0.Ban(id1, time.Hour)
+	assert.NoError(t, err)
+
+	disconnected.Wait()
+
+	// The banned node should no longer be connected, and shouldn't be able to
+	// reconnect
+	assert.Empty(t, net0.Peers())
+	assert.Equal(t, errPeerNotConnected, net0.Disconnect(id1))
+
+	n0 := net0.(*network)
+	assert.True(t, n0.bans.isNodeIDBanned(id1))
+	assert.Equal(t, errNodeIDBanned, n0.tryAddPeer(&peer{net: n0, id: id1}))
+
+	err = net0.Close()
+	assert.NoError(t, err)
+
+	err = net1.Close()
+	assert.NoError(t, err)
+}
+
 func TestDoubleTrack(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
@@ -470,6 +726,23 @@ func TestDoubleTrack(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net0)
 
@@ -494,6 +767,23 @@ func TestDoubleTrack(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net1)
 
@@ -619,6 +909,23 @@ func TestDoubleClose(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net0)
 
@@ -643,6 +950,23 @@ func TestDoubleClose(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net1)
 
@@ -773,6 +1097,23 @@ func TestTrackConnected(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net0)
 
@@ -797,6 +1138,23 @@ func TestTrackConnected(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net1)
 
@@ -901,6 +1259,23 @@ func TestTrackConnectedRace(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net0)
 
@@ -925,6 +1300,23 @@ func TestTrackConnectedRace(t *testing.T) {
 		false,
 		0,
 		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
 	)
 	assert.NotNil(t, net1)
 
@@ -945,3 +1337,135 @@ func TestTrackConnectedRace(t *testing.T) {
 	err = net1.Close()
 	assert.NoError(t, err)
 }
+
+func TestStaticPeersOnly(t *testing.T) {
+	log := logging.NoLog{}
+	networkID := uint32(0)
+	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	versionParser := version.NewDefaultParser()
+
+	ip0 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id0 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip0.IP().String())))
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	id1 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip1.IP().String())))
+	ip2 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		2,
+	)
+
+	listener0 := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller0 := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	serverUpgrader := NewIPUpgrader()
+	clientUpgrader := NewIPUpgrader()
+
+	vdrs := validators.NewSet()
+	handler := &testHandler{}
+
+	staticPeerIDs := ids.ShortSet{}
+	staticPeerIDs.Add(id1)
+
+	net0 := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id0,
+		ip0,
+		networkID,
+		appVersion,
+		versionParser,
+		listener0,
+		caller0,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		true,
+		staticPeerIDs,
+		[]utils.IPDesc{ip1.IP()},
+		ConnLimits{},
+	)
+	assert.NotNil(t, net0)
+	n0 := net0.(*network)
+
+	// Only the static IPs should be dialed
+	err := net0.Track(ip2.IP())
+	assert.Equal(t, errNotStaticPeer, err)
+	err = net0.Track(ip1.IP())
+	assert.NoError(t, err)
+
+	n0.stateLock.RLock()
+	_, tracked1 := n0.disconnectedIPs[ip1.IP().String()]
+	_, tracked2 := n0.disconnectedIPs[ip2.IP().String()]
+	n0.stateLock.RUnlock()
+	assert.True(t, tracked1)
+	assert.False(t, tracked2)
+
+	// Connections from unknown node IDs should be rejected
+	newConn := func(ip utils.IPDesc) *testConn {
+		return &testConn{
+			pendingReads:  make(chan []byte, 1<<10),
+			pendingWrites: make(chan []byte, 1<<10),
+			closed:        make(chan struct{}),
+			local:         listener0.addr,
+			remote: &net.TCPAddr{
+				IP:   ip.IP,
+				Port: int(ip.Port),
+			},
+		}
+	}
+	err = n0.upgrade(&peer{
+		net:          n0,
+		conn:         newConn(ip2.IP()),
+		tickerCloser: make(chan struct{}),
+	}, serverUpgrader)
+	assert.Equal(t, errNotStaticPeer, err)
+
+	err = n0.upgrade(&peer{
+		net:          n0,
+		conn:         newConn(ip1.IP()),
+		tickerCloser: make(chan struct{}),
+	}, serverUpgrader)
+	assert.NoError(t, err)
+
+	err = net0.Close()
+	assert.NoError(t, err)
+}
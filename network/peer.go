@@ -4,6 +4,7 @@
 package network
 
 import (
+	"crypto/x509"
 	"encoding/binary"
 	"math"
 	"net"
@@ -14,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
@@ -41,11 +43,14 @@ type peer struct {
 	// number of bytes currently in the send queue.
 	pendingBytes int64
 
-	// lock to ensure that closing of the sender queue is handled safely
+	// lock to ensure that closing of the sender queues is handled safely
 	senderLock sync.Mutex
-	// queue of messages this connection is attempting to send the peer. Is
-	// closed when the connection is closed.
-	sender chan []byte
+	// queues of messages this connection is attempting to send the peer,
+	// indexed by priority. Are closed when the connection is closed.
+	senders [numPriorities]chan []byte
+
+	// limits the rate that bytes are written to this peer
+	bandwidth *tokenBucket
 
 	// ip may or may not be set when the peer is first started. is only modified
 	// on the connection's reader routine.
@@ -58,12 +63,63 @@ type peer struct {
 	// the connection object that is used to read/write messages from
 	conn net.Conn
 
+	// inbound is true if the peer initiated the connection
+	inbound bool
+
 	// version that the peer reported during the handshake
 	versionStr utils.AtomicInterface
 
+	// if the peer reported a version during the handshake that is able to
+	// parse the Compression field of a Version message. is only modified on
+	// the connection's reader routine.
+	advertiseCompression utils.AtomicBool
+
+	// algorithm used to compress messages sent to the peer, negotiated from
+	// the algorithms the peer advertised in its Version message. 0 if messages
+	// shouldn't be compressed. must only be accessed atomically.
+	compression uint32
+
+	// if the peer reported a version during the handshake that is able to
+	// parse application level messages. is only modified on the connection's
+	// reader routine.
+	appMsgs utils.AtomicBool
+
+	// if the peer reported a version during the handshake that is able to
+	// parse signed peer lists, and this node is able to sign its IP. is only
+	// modified on the connection's reader routine.
+	signedIPs utils.AtomicBool
+
+	// if the peer reported a version during the handshake that is able to
+	// parse pongs that report an uptime. is only modified on the connection's
+	// reader routine.
+	reportUptime utils.AtomicBool
+
+	// unix nano time that the outstanding ping was sent. 0 if there isn't an
+	// outstanding ping.
+	pingSent int64
+
+	// round trip time, in nanoseconds, of the most recently answered ping. 0
+	// if no ping has been answered.
+	pingRTT int64
+
+	// uptime percentage, in [0, 100], that the peer most recently reported
+	// observing for this node. nil if the peer hasn't reported an uptime.
+	uptime utils.AtomicInterface
+
 	// unix time of the last message sent and received respectively
 	lastSent, lastReceived int64
 
+	// unix time that the peer was marked as connected
+	connectedTime int64
+
+	// staking certificate the peer authenticated with. nil if the connection
+	// wasn't upgraded with TLS. is set before the peer is started.
+	cert *x509.Certificate
+
+	// traffic with this peer, indexed by op
+	statsLock sync.Mutex
+	stats     map[Op]*opStats
+
 	tickerCloser chan struct{}
 
 	// ticker processes
@@ -94,6 +150,19 @@ func (p *peer) sendPings() {
 				return
 			}
 
+			if sent := atomic.LoadInt64(&p.pingSent); sent != 0 {
+				if p.net.clock.Time().Sub(time.Unix(0, sent)) > p.net.pingPongTimeout {
+					p.net.log.Debug("closing connection to %s because a ping wasn't answered within %s",
+						p.id,
+						p.net.pingPongTimeout)
+					p.Close()
+					return
+				}
+				// wait for the outstanding ping to be answered before sending
+				// another
+				continue
+			}
+
 			p.Ping()
 		case <-p.tickerCloser:
 			return
@@ -197,6 +266,12 @@ func (p *peer) ReadMessages() {
 			return
 		}
 
+		if msgBytes[0]&compressedFlag != 0 {
+			p.net.compressedBytesReceived.Add(float64(len(msgBytes)))
+			p.net.compressedRawBytesReceived.Add(float64(len(msg.Bytes())))
+		}
+		p.received(msg.Op(), len(msgBytes))
+
 		p.handle(msg)
 	}
 }
@@ -207,7 +282,12 @@ func (p *peer) WriteMessages() {
 
 	p.Version()
 
-	for msg := range p.sender {
+	for {
+		msg, msgPriority, ok := p.nextMessage()
+		if !ok {
+			return
+		}
+
 		p.net.log.Verbo("sending new message to %s:\n%s",
 			p.id,
 			formatting.DumpBytes{Bytes: msg})
@@ -215,6 +295,10 @@ func (p *peer) WriteMessages() {
 		atomic.AddInt64(&p.pendingBytes, -int64(len(msg)))
 		atomic.AddInt64(&p.net.pendingBytes, -int64(len(msg)))
 
+		if !p.throttle(len(msg)+wrappers.IntLen, msgPriority) {
+			return
+		}
+
 		msgb := [wrappers.IntLen]byte{}
 		binary.BigEndian.PutUint32(msgb[:], uint32(len(msg)))
 		for _, byteSlice := range [][]byte{msgb[:], msg} {
@@ -229,11 +313,66 @@ func (p *peer) WriteMessages() {
 			}
 		}
 		atomic.StoreInt64(&p.lastSent, p.net.clock.Time().Unix())
+		p.sent(Op(msg[0]&^compressedFlag), len(msg))
 	}
 }
 
+// nextMessage returns the oldest queued message of the highest priority,
+// blocking until a message is queued. Returns false if the peer was closed.
+func (p *peer) nextMessage() ([]byte, priority, bool) {
+	for msgPriority, sender := range p.senders {
+		select {
+		case msg, ok := <-sender:
+			return msg, priority(msgPriority), ok
+		default:
+		}
+	}
+
+	// Nothing is queued, so send the first message that is queued
+	select {
+	case msg, ok := <-p.senders[responsePriority]:
+		return msg, responsePriority, ok
+	case msg, ok := <-p.senders[defaultPriority]:
+		return msg, defaultPriority, ok
+	case msg, ok := <-p.senders[gossipPriority]:
+		return msg, gossipPriority, ok
+	}
+}
+
+// throttle blocks until both this peer's and the node's bandwidth limits allow
+// [numBytes] to be written. Returns false if the peer was closed while waiting.
+func (p *peer) throttle(numBytes int, msgPriority priority) bool {
+	delay := p.bandwidth.reserve(numBytes)
+	if networkDelay := p.net.bandwidth.reserve(numBytes); networkDelay > delay {
+		delay = networkDelay
+	}
+	if delay <= 0 {
+		return true
+	}
+
+	p.net.priorities[msgPriority].numThrottled.Inc()
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-p.tickerCloser:
+		return false
+	}
+}
+
+// Send queues [msg] to be sent with the priority of its opcode. Assumes that
+// the stateLock is not held.
+func (p *peer) Send(msg Msg) bool { return p.send(msg, msg.Op().sendPriority()) }
+
+// SendGossip queues [msg] to be sent with gossip priority. Assumes that the
+// stateLock is not held.
+func (p *peer) SendGossip(msg Msg) bool { return p.send(msg, gossipPriority) }
+
 // send assumes that the stateLock is not held.
-func (p *peer) Send(msg Msg) bool {
+func (p *peer) send(msg Msg, msgPriority priority) bool {
 	p.senderLock.Lock()
 	defer p.senderLock.Unlock()
 
@@ -245,12 +384,21 @@ func (p *peer) Send(msg Msg) bool {
 	}
 
 	// is it possible to send?
-	if dropMsg := p.dropMessagePeer(); dropMsg {
+	if dropMsg := p.dropMessagePeer(msgPriority); dropMsg {
+		p.net.priorities[msgPriority].numDropped.Inc()
 		p.net.log.Debug("dropping message to %s due to a send queue with too many bytes", p.id)
 		return false
 	}
 
 	msgBytes := msg.Bytes()
+	rawBytesLen := len(msgBytes)
+	compressed := false
+	if algorithm := p.compressionFor(msg); algorithm != 0 {
+		if compressedBytes := msg.CompressedBytes(algorithm); compressedBytes != nil {
+			msgBytes = compressedBytes
+			compressed = true
+		}
+	}
 	msgBytesLen := int64(len(msgBytes))
 
 	// lets assume send will be successful, we add to the network pending bytes
@@ -258,20 +406,26 @@ func (p *peer) Send(msg Msg) bool {
 	newPendingBytes := atomic.AddInt64(&p.net.pendingBytes, msgBytesLen)
 
 	newConnPendingBytes := atomic.LoadInt64(&p.pendingBytes) + msgBytesLen
-	if dropMsg := p.dropMessage(newConnPendingBytes, newPendingBytes); dropMsg {
+	if dropMsg := p.dropMessage(newConnPendingBytes, newPendingBytes, msgPriority); dropMsg {
 		// we never sent the message, remove from pending totals
 		atomic.AddInt64(&p.net.pendingBytes, -msgBytesLen)
+		p.net.priorities[msgPriority].numDropped.Inc()
 		p.net.log.Debug("dropping message to %s due to a send queue with too many bytes", p.id)
 		return false
 	}
 
 	select {
-	case p.sender <- msgBytes:
+	case p.senders[msgPriority] <- msgBytes:
 		atomic.AddInt64(&p.pendingBytes, msgBytesLen)
+		if compressed {
+			p.net.compressedBytesSent.Add(float64(msgBytesLen))
+			p.net.compressedRawBytesSent.Add(float64(rawBytesLen))
+		}
 		return true
 	default:
 		// we never sent the message, remove from pending totals
 		atomic.AddInt64(&p.net.pendingBytes, -msgBytesLen)
+		p.net.priorities[msgPriority].numDropped.Inc()
 		p.net.log.Debug("dropping message to %s due to a full send queue", p.id)
 		return false
 	}
@@ -311,12 +465,18 @@ func (p *peer) handle(msg Msg) {
 	case Pong:
 		p.pong(msg)
 		return
+	case UptimePong:
+		p.uptimePong(msg)
+		return
 	case GetPeerList:
 		p.getPeerList(msg)
 		return
 	case PeerList:
 		p.peerList(msg)
 		return
+	case SignedPeerList:
+		p.signedPeerList(msg)
+		return
 	}
 	if !p.connected.GetValue() {
 		p.net.log.Debug("dropping message from %s because the connection hasn't been established yet", p.id)
@@ -353,20 +513,37 @@ func (p *peer) handle(msg Msg) {
 		p.pullQuery(msg)
 	case Chits:
 		p.chits(msg)
+	case AppRequest:
+		p.appRequest(msg)
+	case AppResponse:
+		p.appResponse(msg)
+	case AppGossip:
+		p.appGossip(msg)
 	default:
 		p.net.log.Debug("dropping an unknown message from %s with op %s", p.id, op.String())
 	}
 }
 
-func (p *peer) dropMessagePeer() bool {
-	return atomic.LoadInt64(&p.pendingBytes) > p.net.maxMessageSize
+// compressionFor returns the algorithm [msg] should be compressed with when
+// sent to this peer. Returns 0 if [msg] should be sent uncompressed.
+func (p *peer) compressionFor(msg Msg) byte {
+	if !p.net.compressionEnabled ||
+		!msg.Op().Compressible() ||
+		len(msg.Bytes()) < p.net.compressionThreshold {
+		return 0
+	}
+	return byte(atomic.LoadUint32(&p.compression))
 }
 
-func (p *peer) dropMessage(connPendingLen, networkPendingLen int64) bool {
-	return networkPendingLen > p.net.networkPendingSendBytesToRateLimit && // Check to see if we should be enforcing any rate limiting
-		p.dropMessagePeer() && // this connection should have a minimum allowed bandwidth
-		(networkPendingLen > p.net.maxNetworkPendingSendBytes || // Check to see if this message would put too much memory into the network
-			connPendingLen > p.net.maxNetworkPendingSendBytes/20) // Check to see if this connection is using too much memory
+func (p *peer) dropMessagePeer(msgPriority priority) bool {
+	return atomic.LoadInt64(&p.pendingBytes) > msgPriority.budget(p.net.maxMessageSize)
+}
+
+func (p *peer) dropMessage(connPendingLen, networkPendingLen int64, msgPriority priority) bool {
+	return networkPendingLen > msgPriority.budget(p.net.networkPendingSendBytesToRateLimit) && // Check to see if we should be enforcing any rate limiting
+		p.dropMessagePeer(msgPriority) && // this connection should have a minimum allowed bandwidth
+		(networkPendingLen > msgPriority.budget(p.net.maxNetworkPendingSendBytes) || // Check to see if this message would put too much memory into the network
+			connPendingLen > msgPriority.budget(p.net.maxNetworkPendingSendBytes/20)) // Check to see if this connection is using too much memory
 }
 
 // assumes the stateLock is not held
@@ -386,8 +563,11 @@ func (p *peer) close() {
 
 	p.senderLock.Lock()
 	// The locks guarantee here that the sender routine will read that the peer
-	// has been closed and will therefore not attempt to write on this channel.
-	close(p.sender)
+	// has been closed and will therefore not attempt to write on these
+	// channels.
+	for _, sender := range p.senders {
+		close(sender)
+	}
 	p.senderLock.Unlock()
 
 	p.net.disconnected(p)
@@ -402,14 +582,29 @@ func (p *peer) GetVersion() {
 
 // assumes the stateLock is not held
 func (p *peer) Version() {
-	p.net.stateLock.RLock()
-	msg, err := p.net.b.Version(
-		p.net.networkID,
-		p.net.nodeID,
-		p.net.clock.Unix(),
-		p.net.ip.IP(),
-		p.net.version.String(),
+	var (
+		msg Msg
+		err error
 	)
+	p.net.stateLock.RLock()
+	if p.advertiseCompression.GetValue() {
+		msg, err = p.net.b.VersionWithCompression(
+			p.net.networkID,
+			p.net.nodeID,
+			p.net.clock.Unix(),
+			p.net.ip.IP(),
+			p.net.version.String(),
+			supportedCompression,
+		)
+	} else {
+		msg, err = p.net.b.Version(
+			p.net.networkID,
+			p.net.nodeID,
+			p.net.clock.Unix(),
+			p.net.ip.IP(),
+			p.net.version.String(),
+		)
+	}
 	p.net.stateLock.RUnlock()
 	p.net.log.AssertNoError(err)
 	p.Send(msg)
@@ -424,6 +619,11 @@ func (p *peer) GetPeerList() {
 
 // assumes the stateLock is not held
 func (p *peer) SendPeerList() {
+	if p.signedIPs.GetValue() {
+		ipCerts := p.net.validatorSignedIPs()
+		p.SignedPeerList(ipCerts)
+		return
+	}
 	ips := p.net.validatorIPs()
 	p.PeerList(ips)
 }
@@ -438,19 +638,43 @@ func (p *peer) PeerList(peers []utils.IPDesc) {
 	p.Send(msg)
 }
 
+// assumes the stateLock is not held
+func (p *peer) SignedPeerList(ipCerts []utils.IPCertDesc) {
+	msg, err := p.net.b.SignedPeerList(ipCerts)
+	if err != nil {
+		p.net.log.Warn("failed to send SignedPeerList message due to %s", err)
+		return
+	}
+	p.Send(msg)
+}
+
 // assumes the stateLock is not held
 func (p *peer) Ping() {
 	msg, err := p.net.b.Ping()
 	p.net.log.AssertNoError(err)
+
+	// the send time must be recorded before the ping is sent, otherwise the
+	// pong may be handled before the ping is marked as outstanding
+	atomic.StoreInt64(&p.pingSent, p.net.clock.Time().UnixNano())
 	if p.Send(msg) {
 		p.net.ping.numSent.Inc()
 	} else {
+		atomic.StoreInt64(&p.pingSent, 0)
 		p.net.ping.numFailed.Inc()
 	}
 }
 
 // assumes the stateLock is not held
 func (p *peer) Pong() {
+	if p.reportUptime.GetValue() && p.net.uptimes != nil {
+		uptime, err := p.net.uptimes.CalculateUptimePercent(p.id)
+		if err == nil {
+			p.UptimePong(uptime)
+			return
+		}
+		p.net.log.Verbo("not reporting the uptime of %s due to: %s", p.id, err)
+	}
+
 	msg, err := p.net.b.Pong()
 	p.net.log.AssertNoError(err)
 	if p.Send(msg) {
@@ -460,13 +684,33 @@ func (p *peer) Pong() {
 	}
 }
 
+// assumes the stateLock is not held
+func (p *peer) UptimePong(uptime float64) {
+	percentage := uint8(100)
+	if uptime < 1 {
+		percentage = uint8(uptime * 100)
+	}
+
+	msg, err := p.net.b.UptimePong(percentage)
+	p.net.log.AssertNoError(err)
+	if p.Send(msg) {
+		p.net.uptimePong.numSent.Inc()
+	} else {
+		p.net.uptimePong.numFailed.Inc()
+	}
+}
+
 // assumes the stateLock is not held
 func (p *peer) getVersion(_ Msg) { p.Version() }
 
 // assumes the stateLock is not held
 func (p *peer) version(msg Msg) {
 	if p.gotVersion.GetValue() {
-		p.net.log.Verbo("dropping duplicated version message from %s", p.id)
+		// Peers resend their version once they know this node is able to parse
+		// the algorithms they advertise
+		if !p.negotiateCompression(msg) {
+			p.net.log.Verbo("dropping duplicated version message from %s", p.id)
+		}
 		return
 	}
 
@@ -554,14 +798,37 @@ func (p *peer) version(msg Msg) {
 		}
 	}
 
+	p.signedIPs.SetValue(p.net.stakingCert != nil && supportsSignedIPs(peerVersion))
 	p.SendPeerList()
 
+	p.appMsgs.SetValue(supportsAppMsgs(peerVersion))
+	p.reportUptime.SetValue(supportsUptimePong(peerVersion))
 	p.versionStr.SetValue(peerVersion.String())
 	p.gotVersion.SetValue(true)
 
+	p.negotiateCompression(msg)
+	if supportsCompression(peerVersion) {
+		// The version this node sent before learning the peer's version didn't
+		// advertise the compression algorithms, as older peers are unable to
+		// parse them
+		p.advertiseCompression.SetValue(true)
+		p.Version()
+	}
+
 	p.tryMarkConnected()
 }
 
+// negotiateCompression sets the algorithm used to compress messages sent to
+// this peer from the algorithms advertised in [msg]. Returns false if [msg]
+// doesn't advertise any algorithms.
+func (p *peer) negotiateCompression(msg Msg) bool {
+	peerCompression, ok := msg.Get(Compression).(byte)
+	if ok {
+		atomic.StoreUint32(&p.compression, uint32(selectCompression(peerCompression)))
+	}
+	return ok
+}
+
 // assumes the stateLock is not held
 func (p *peer) getPeerList(_ Msg) {
 	if p.gotVersion.GetValue() {
@@ -576,6 +843,15 @@ func (p *peer) peerList(msg Msg) {
 	p.gotPeerList.SetValue(true)
 	p.tryMarkConnected()
 
+	if p.net.staticPeersOnly {
+		// Only the static peers are connected to, so gossiped IPs are ignored
+		return
+	}
+	if !p.acceptsUnsignedPeerList() {
+		p.net.log.Debug("dropping unsigned peer list from %s", p.id)
+		return
+	}
+
 	for _, ip := range ips {
 		p.net.stateLock.Lock()
 		if !ip.Equal(p.net.ip.IP()) &&
@@ -588,11 +864,72 @@ func (p *peer) peerList(msg Msg) {
 	}
 }
 
+// acceptsUnsignedPeerList returns true if the unsigned IPs gossiped by this
+// peer should be tracked. Peers that support signed peer lists must only gossip
+// signed IP claims. The version is reported by the peer itself, so when staking
+// is enabled unsigned IPs are also only accepted from validators and beacons.
+func (p *peer) acceptsUnsignedPeerList() bool {
+	if p.signedIPs.GetValue() {
+		return false
+	}
+	return p.net.stakingKey == nil || p.net.vdrs.Contains(p.id) || p.net.beacons.Contains(p.id)
+}
+
+// assumes the stateLock is not held
+func (p *peer) signedPeerList(msg Msg) {
+	ipCerts := msg.Get(SignedPeers).([]utils.IPCertDesc)
+
+	p.gotPeerList.SetValue(true)
+	p.tryMarkConnected()
+
+	if p.net.staticPeersOnly {
+		// Only the static peers are connected to, so gossiped IPs are ignored
+		return
+	}
+
+	for _, ipCert := range ipCerts {
+		if err := p.net.trackSignedIP(ipCert); err != nil {
+			p.net.log.Debug("dropping invalid IP claim from %s due to: %s", p.id, err)
+		}
+	}
+}
+
 // assumes the stateLock is not held
 func (p *peer) ping(_ Msg) { p.Pong() }
 
 // assumes the stateLock is not held
-func (p *peer) pong(_ Msg) {}
+func (p *peer) pong(_ Msg) { p.answeredPing() }
+
+// assumes the stateLock is not held
+func (p *peer) uptimePong(msg Msg) {
+	p.answeredPing()
+
+	uptime := msg.Get(Uptime).(uint8)
+	if uptime > 100 {
+		p.net.log.Debug("dropping invalid uptime of %d%% reported by %s", uptime, p.id)
+		return
+	}
+	p.uptime.SetValue(uptime)
+}
+
+// answeredPing records the round trip time of the outstanding ping, if there
+// is one
+func (p *peer) answeredPing() {
+	sent := atomic.SwapInt64(&p.pingSent, 0)
+	if sent == 0 {
+		// this pong wasn't requested
+		return
+	}
+	rtt := p.net.clock.Time().Sub(time.Unix(0, sent))
+	atomic.StoreInt64(&p.pingRTT, int64(rtt))
+}
+
+// observedUptime returns the uptime percentage, in [0, 100], that the peer
+// most recently reported observing for this node
+func (p *peer) observedUptime() (uint8, bool) {
+	uptime, ok := p.uptime.GetValue().(uint8)
+	return uptime, ok
+}
 
 // assumes the stateLock is not held
 func (p *peer) getAcceptedFrontier(msg Msg) {
@@ -779,6 +1116,36 @@ func (p *peer) chits(msg Msg) {
 	p.net.router.Chits(p.id, chainID, requestID, containerIDs)
 }
 
+// assumes the stateLock is not held
+func (p *peer) appRequest(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	deadline := p.net.clock.Time().Add(time.Duration(msg.Get(Deadline).(uint64)))
+	appRequestBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppRequest(p.id, chainID, requestID, deadline, appRequestBytes)
+}
+
+// assumes the stateLock is not held
+func (p *peer) appResponse(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	appResponseBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppResponse(p.id, chainID, requestID, appResponseBytes)
+}
+
+// assumes the stateLock is not held
+func (p *peer) appGossip(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	appGossipBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppGossip(p.id, chainID, appGossipBytes)
+}
+
 // assumes the stateLock is held
 func (p *peer) tryMarkConnected() {
 	if !p.connected.GetValue() && // not already connected
@@ -786,11 +1153,78 @@ func (p *peer) tryMarkConnected() {
 		p.gotPeerList.GetValue() && // not waiting for peerlist
 		!p.closed.GetValue() { // and not already disconnected
 
+		atomic.StoreInt64(&p.connectedTime, p.net.clock.Time().Unix())
 		p.connected.SetValue(true)
 		p.net.connected(p)
 	}
 }
 
+// opStats is the traffic with a peer of a single message type
+type opStats struct {
+	msgsSent, bytesSent, msgsReceived, bytesReceived uint64
+}
+
+// sent records that a message of [numBytes] with opcode [op] was written to
+// the peer
+func (p *peer) sent(op Op, numBytes int) {
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+
+	stats := p.opStats(op)
+	stats.msgsSent++
+	stats.bytesSent += uint64(numBytes)
+}
+
+// received records that a message of [numBytes] with opcode [op] was read from
+// the peer
+func (p *peer) received(op Op, numBytes int) {
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+
+	stats := p.opStats(op)
+	stats.msgsReceived++
+	stats.bytesReceived += uint64(numBytes)
+}
+
+// assumes the statsLock is held
+func (p *peer) opStats(op Op) *opStats {
+	if p.stats == nil {
+		p.stats = make(map[Op]*opStats)
+	}
+	stats, ok := p.stats[op]
+	if !ok {
+		stats = &opStats{}
+		p.stats[op] = stats
+	}
+	return stats
+}
+
+// messageStats returns the traffic with this peer, keyed by message type
+func (p *peer) messageStats() map[string]MessageStats {
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+
+	stats := make(map[string]MessageStats, len(p.stats))
+	for op, s := range p.stats {
+		stats[op.String()] = MessageStats{
+			MessagesSent:     json.Uint64(s.msgsSent),
+			BytesSent:        json.Uint64(s.bytesSent),
+			MessagesReceived: json.Uint64(s.msgsReceived),
+			BytesReceived:    json.Uint64(s.bytesReceived),
+		}
+	}
+	return stats
+}
+
+// sendQueueLen returns the number of messages queued to be sent to this peer
+func (p *peer) sendQueueLen() int {
+	numMsgs := 0
+	for _, sender := range p.senders {
+		numMsgs += len(sender)
+	}
+	return numMsgs
+}
+
 func (p *peer) discardIP() {
 	// By clearing the IP, we will not attempt to reconnect to this peer
 	if ip := p.getIP(); !ip.IsZero() {
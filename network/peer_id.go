@@ -5,6 +5,8 @@ package network
 
 import (
 	"time"
+
+	"github.com/ava-labs/avalanchego/utils/json"
 )
 
 // PeerID ...
@@ -15,4 +17,43 @@ type PeerID struct {
 	Version      string    `json:"version"`
 	LastSent     time.Time `json:"lastSent"`
 	LastReceived time.Time `json:"lastReceived"`
+
+	// Inbound is true if the peer initiated the connection
+	Inbound bool `json:"inbound"`
+
+	// Validator is true if the peer is currently a validator of the primary
+	// network
+	Validator bool `json:"validator"`
+
+	// ConnectedSince is the time the handshake with the peer finished
+	ConnectedSince time.Time `json:"connectedSince"`
+	Uptime         string    `json:"uptime"`
+
+	// CertExpiry is the expiration time of the peer's staking certificate. Nil
+	// if the connection isn't authenticated with TLS.
+	CertExpiry *time.Time `json:"certExpiry,omitempty"`
+
+	// Number of messages and bytes that are queued to be sent to the peer
+	SendQueueMessages json.Uint64 `json:"sendQueueMessages"`
+	SendQueueBytes    json.Uint64 `json:"sendQueueBytes"`
+
+	// Messages is the traffic with the peer, keyed by message type
+	Messages map[string]MessageStats `json:"messages"`
+
+	// PingLatency is the round trip time, in nanoseconds, of the most
+	// recently answered ping. 0 if no ping has been answered.
+	PingLatency json.Uint64 `json:"pingLatency"`
+
+	// ObservedUptime is the uptime percentage, in [0, 100], that the peer
+	// reports observing for this node. Nil if the peer hasn't reported one.
+	ObservedUptime *json.Uint8 `json:"observedUptime,omitempty"`
+}
+
+// MessageStats is the number of messages and bytes of a single message type
+// that have been sent to and received from a peer
+type MessageStats struct {
+	MessagesSent     json.Uint64 `json:"messagesSent"`
+	BytesSent        json.Uint64 `json:"bytesSent"`
+	MessagesReceived json.Uint64 `json:"messagesReceived"`
+	BytesReceived    json.Uint64 `json:"bytesReceived"`
 }
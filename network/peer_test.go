@@ -0,0 +1,140 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestPeerMessageStats(t *testing.T) {
+	p := &peer{}
+	assert.Empty(t, p.messageStats())
+
+	p.sent(Put, 10)
+	p.sent(Put, 20)
+	p.received(Put, 5)
+	p.received(Chits, 7)
+
+	stats := p.messageStats()
+	assert.Len(t, stats, 2)
+
+	putStats := stats[Put.String()]
+	assert.EqualValues(t, 2, putStats.MessagesSent)
+	assert.EqualValues(t, 30, putStats.BytesSent)
+	assert.EqualValues(t, 1, putStats.MessagesReceived)
+	assert.EqualValues(t, 5, putStats.BytesReceived)
+
+	chitsStats := stats[Chits.String()]
+	assert.EqualValues(t, 0, chitsStats.MessagesSent)
+	assert.EqualValues(t, 0, chitsStats.BytesSent)
+	assert.EqualValues(t, 1, chitsStats.MessagesReceived)
+	assert.EqualValues(t, 7, chitsStats.BytesReceived)
+}
+
+func TestPeerUptimePong(t *testing.T) {
+	n := &network{log: logging.NoLog{}}
+	n.clock.Set(time.Unix(1000, 0))
+	p := &peer{net: n}
+
+	_, ok := p.observedUptime()
+	assert.False(t, ok)
+
+	p.pingSent = n.clock.Time().UnixNano()
+	n.clock.Set(time.Unix(1001, 0))
+
+	msg, err := n.b.UptimePong(75)
+	assert.NoError(t, err)
+	p.uptimePong(msg)
+
+	assert.EqualValues(t, time.Second, p.pingRTT)
+	assert.EqualValues(t, 0, p.pingSent)
+	uptime, ok := p.observedUptime()
+	assert.True(t, ok)
+	assert.EqualValues(t, 75, uptime)
+
+	// Unrequested pongs shouldn't modify the round trip time and invalid
+	// uptimes should be dropped
+	msg, err = n.b.UptimePong(101)
+	assert.NoError(t, err)
+	p.uptimePong(msg)
+
+	assert.EqualValues(t, time.Second, p.pingRTT)
+	uptime, ok = p.observedUptime()
+	assert.True(t, ok)
+	assert.EqualValues(t, 75, uptime)
+}
+
+func TestPeerAcceptsUnsignedPeerList(t *testing.T) {
+	key, _ := newTestStakingCert(t)
+	vdrs := validators.NewSet()
+	beacons := validators.NewSet()
+	n := &network{
+		vdrs:    vdrs,
+		beacons: beacons,
+	}
+	p := &peer{
+		net: n,
+		id:  ids.GenerateTestShortID(),
+	}
+
+	// Without staking, peer IDs aren't authenticated
+	assert.True(t, p.acceptsUnsignedPeerList())
+
+	// With staking, only validators and beacons may gossip unsigned IPs
+	n.stakingKey = key
+	assert.False(t, p.acceptsUnsignedPeerList())
+	assert.NoError(t, beacons.AddWeight(p.id, 1))
+	assert.True(t, p.acceptsUnsignedPeerList())
+	assert.NoError(t, beacons.RemoveWeight(p.id, 1))
+	assert.NoError(t, vdrs.AddWeight(p.id, 1))
+	assert.True(t, p.acceptsUnsignedPeerList())
+
+	// Peers that support signed peer lists must only gossip signed IPs
+	p.signedIPs.SetValue(true)
+	assert.False(t, p.acceptsUnsignedPeerList())
+}
+
+func TestPeerNegotiateCompression(t *testing.T) {
+	n := &network{
+		log:                  logging.NoLog{},
+		compressionEnabled:   true,
+		compressionThreshold: DefaultCompressionThreshold,
+	}
+	p := &peer{net: n}
+	p.gotVersion.SetValue(true)
+
+	largeMsg, err := n.b.Put(ids.Empty, 1, ids.Empty, make([]byte, DefaultCompressionThreshold))
+	assert.NoError(t, err)
+	smallMsg, err := n.b.Put(ids.Empty, 1, ids.Empty, nil)
+	assert.NoError(t, err)
+
+	// A version that doesn't advertise any algorithms shouldn't enable
+	// compression
+	versionMsg, err := n.b.Version(0, 0, 0, utils.IPDesc{}, "")
+	assert.NoError(t, err)
+	p.version(versionMsg)
+	assert.Zero(t, p.compressionFor(largeMsg))
+
+	versionMsg, err = n.b.VersionWithCompression(0, 0, 0, utils.IPDesc{}, "", gzipCompression)
+	assert.NoError(t, err)
+	p.version(versionMsg)
+	assert.Equal(t, gzipCompression, p.compressionFor(largeMsg))
+	assert.Zero(t, p.compressionFor(smallMsg))
+
+	versionMsg, err = n.b.VersionWithCompression(0, 0, 0, utils.IPDesc{}, "", gzipCompression|zstdCompression)
+	assert.NoError(t, err)
+	p.version(versionMsg)
+	assert.Equal(t, zstdCompression, p.compressionFor(largeMsg))
+
+	n.compressionEnabled = false
+	assert.Zero(t, p.compressionFor(largeMsg))
+}
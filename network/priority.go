@@ -0,0 +1,61 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+// priority is the class a message is queued in when it is sent to a peer.
+// Queued messages of a lower priority are only written to a peer when there
+// are no queued messages of a higher priority.
+type priority int
+
+// Priorities, from highest to lowest
+const (
+	// responsePriority is used for replies that consensus is waiting on
+	responsePriority priority = iota
+	// defaultPriority is used for requests and handshake messages
+	defaultPriority
+	// gossipPriority is used for unrequested messages
+	gossipPriority
+
+	numPriorities
+
+	// reservedBudgetDivisor reserves 1/reservedBudgetDivisor of each pending
+	// send byte budget for requests and responses
+	reservedBudgetDivisor = 4
+)
+
+func (p priority) String() string {
+	switch p {
+	case responsePriority:
+		return "response"
+	case defaultPriority:
+		return "default"
+	case gossipPriority:
+		return "gossip"
+	default:
+		return "unknown"
+	}
+}
+
+// budget returns the number of the [limit] pending send bytes that messages of
+// this class may fill. Gossip can't use the reserved part of the budget, so
+// that bursts of gossip are dropped before they crowd out consensus messages.
+func (p priority) budget(limit int64) int64 {
+	if p == gossipPriority {
+		return limit - limit/reservedBudgetDivisor
+	}
+	return limit
+}
+
+// sendPriority returns the class that messages with this opcode are sent in,
+// unless the message is being gossiped.
+func (op Op) sendPriority() priority {
+	switch op {
+	case AcceptedFrontier, Accepted, MultiPut, Put, Chits, AppResponse:
+		return responsePriority
+	case PeerList, SignedPeerList, AppGossip:
+		return gossipPriority
+	default:
+		return defaultPriority
+	}
+}
@@ -0,0 +1,87 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpSendPriority(t *testing.T) {
+	assert.Equal(t, responsePriority, Chits.sendPriority())
+	assert.Equal(t, responsePriority, Put.sendPriority())
+	assert.Equal(t, responsePriority, MultiPut.sendPriority())
+	assert.Equal(t, defaultPriority, PushQuery.sendPriority())
+	assert.Equal(t, defaultPriority, Version.sendPriority())
+	assert.Equal(t, gossipPriority, PeerList.sendPriority())
+	assert.Equal(t, gossipPriority, SignedPeerList.sendPriority())
+	assert.Equal(t, gossipPriority, AppGossip.sendPriority())
+}
+
+func TestPeerNextMessagePriority(t *testing.T) {
+	p := &peer{}
+	for i := range p.senders {
+		p.senders[i] = make(chan []byte, 2)
+	}
+
+	p.senders[gossipPriority] <- []byte{0}
+	p.senders[defaultPriority] <- []byte{1}
+	p.senders[responsePriority] <- []byte{2}
+	p.senders[gossipPriority] <- []byte{3}
+
+	expected := []struct {
+		msg         []byte
+		msgPriority priority
+	}{
+		{msg: []byte{2}, msgPriority: responsePriority},
+		{msg: []byte{1}, msgPriority: defaultPriority},
+		{msg: []byte{0}, msgPriority: gossipPriority},
+		{msg: []byte{3}, msgPriority: gossipPriority},
+	}
+	for _, e := range expected {
+		msg, msgPriority, ok := p.nextMessage()
+		assert.True(t, ok)
+		assert.Equal(t, e.msg, msg)
+		assert.Equal(t, e.msgPriority, msgPriority)
+	}
+
+	for _, sender := range p.senders {
+		close(sender)
+	}
+	_, _, ok := p.nextMessage()
+	assert.False(t, ok)
+}
+
+func TestPriorityBudget(t *testing.T) {
+	assert.Equal(t, int64(100), responsePriority.budget(100))
+	assert.Equal(t, int64(100), defaultPriority.budget(100))
+	assert.Equal(t, int64(75), gossipPriority.budget(100))
+}
+
+func TestPeerDropsGossipFirst(t *testing.T) {
+	n := &network{
+		maxMessageSize:                     100,
+		networkPendingSendBytesToRateLimit: 1000,
+		maxNetworkPendingSendBytes:         2000,
+	}
+	p := &peer{
+		net:          n,
+		pendingBytes: 90,
+	}
+
+	// Only gossip is dropped once the reserved part of the budgets is reached
+	assert.True(t, p.dropMessagePeer(gossipPriority))
+	assert.False(t, p.dropMessagePeer(defaultPriority))
+	assert.False(t, p.dropMessagePeer(responsePriority))
+
+	assert.True(t, p.dropMessage(90, 1600, gossipPriority))
+	assert.False(t, p.dropMessage(90, 1600, defaultPriority))
+	assert.False(t, p.dropMessage(90, 1600, responsePriority))
+
+	// Every message is dropped once the whole budget is used
+	p.pendingBytes = 110
+	assert.True(t, p.dropMessage(110, 2100, defaultPriority))
+	assert.True(t, p.dropMessage(110, 2100, responsePriority))
+}
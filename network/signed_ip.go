@@ -0,0 +1,70 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"net"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	errUnsupportedKeyType = errors.New("unsupported staking key type")
+	errNoSigner           = errors.New("no staking key to sign with")
+	errNoIP               = errors.New("no ip to sign")
+	errClaimFromFuture    = errors.New("ip claim is too far in the future")
+	errClaimFromNonStaker = errors.New("ip claim isn't from a validator")
+	errTooManyClaims      = errors.New("too many ip claims are tracked")
+)
+
+// certToID returns the node ID that is derived from the staking certificate
+func certToID(cert *x509.Certificate) ids.ShortID {
+	return ids.NewShortID(
+		hashing.ComputeHash160Array(
+			hashing.ComputeHash256(cert.Raw)))
+}
+
+// ipAndTimeBytes returns the bytes that are signed to claim [ip] at
+// [timestamp]
+func ipAndTimeBytes(ip utils.IPDesc, timestamp uint64) []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, net.IPv6len+wrappers.ShortLen+wrappers.LongLen),
+	}
+	p.PackIP(ip)
+	p.PackLong(timestamp)
+	return p.Bytes
+}
+
+// signIP signs a claim to be reachable at [ip] as of [timestamp]
+func signIP(key crypto.Signer, ip utils.IPDesc, timestamp uint64) ([]byte, error) {
+	hash := hashing.ComputeHash256(ipAndTimeBytes(ip, timestamp))
+	return key.Sign(rand.Reader, hash, crypto.SHA256)
+}
+
+// verifyIP returns nil if [ipCert] was signed by the key in its certificate
+func verifyIP(ipCert utils.IPCertDesc) error {
+	var algorithm x509.SignatureAlgorithm
+	switch ipCert.Cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algorithm = x509.SHA256WithRSA
+	case *ecdsa.PublicKey:
+		algorithm = x509.ECDSAWithSHA256
+	default:
+		return errUnsupportedKeyType
+	}
+	return ipCert.Cert.CheckSignature(
+		algorithm,
+		ipAndTimeBytes(ipCert.IPDesc, ipCert.Time),
+		ipCert.Signature,
+	)
+}
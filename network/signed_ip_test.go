@@ -0,0 +1,257 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func newTestStakingCert(t *testing.T) (crypto.Signer, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	certTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(0),
+		NotBefore:             time.Date(2000, time.January, 0, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Now().AddDate(100, 0, 0),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageDataEncipherment,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certBytes)
+	assert.NoError(t, err)
+	return key, cert
+}
+
+func newTestSignedIP(t *testing.T, key crypto.Signer, cert *x509.Certificate, ip utils.IPDesc, timestamp uint64) utils.IPCertDesc {
+	sig, err := signIP(key, ip, timestamp)
+	assert.NoError(t, err)
+	return utils.IPCertDesc{
+		Cert:      cert,
+		IPDesc:    ip,
+		Time:      timestamp,
+		Signature: sig,
+	}
+}
+
+func TestSignIP(t *testing.T) {
+	key, cert := newTestStakingCert(t)
+	ip := utils.IPDesc{
+		IP:   net.IPv4(1, 2, 3, 4),
+		Port: 9651,
+	}
+
+	ipCert := newTestSignedIP(t, key, cert, ip, 5)
+	assert.NoError(t, verifyIP(ipCert))
+
+	ipCert.Time++
+	assert.Error(t, verifyIP(ipCert))
+
+	ipCert.Time--
+	ipCert.IPDesc.Port++
+	assert.Error(t, verifyIP(ipCert))
+
+	_, otherCert := newTestStakingCert(t)
+	ipCert.IPDesc.Port--
+	ipCert.Cert = otherCert
+	assert.Error(t, verifyIP(ipCert))
+}
+
+func TestTrackSignedIP(t *testing.T) {
+	log := logging.NoLog{}
+	ip := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip.IP().String())))
+	networkID := uint32(0)
+	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	versionParser := version.NewDefaultParser()
+
+	listener := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+	serverUpgrader := NewIPUpgrader()
+	clientUpgrader := NewIPUpgrader()
+
+	vdrs := validators.NewSet()
+	handler := &testHandler{}
+
+	netIntf := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id,
+		ip,
+		networkID,
+		appVersion,
+		versionParser,
+		listener,
+		caller,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		true,
+		DefaultCompressionThreshold,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+		0,
+		memdb.New(),
+		DefaultPingPongTimeout,
+		DefaultPingFrequency,
+		nil,
+		0,
+		false,
+		ids.ShortSet{},
+		nil,
+		ConnLimits{},
+	)
+	assert.NotNil(t, netIntf)
+	defer func() {
+		err := netIntf.Close()
+		assert.NoError(t, err)
+	}()
+
+	n := netIntf.(*network)
+
+	key, cert := newTestStakingCert(t)
+	nodeKey := certToID(cert).Key()
+	now := n.clock.Unix()
+
+	// A claim from a node that isn't a validator should be dropped
+	err := n.trackSignedIP(newTestSignedIP(t, key, cert, utils.IPDesc{IP: net.IPv6loopback, Port: 1}, now))
+	assert.Equal(t, errClaimFromNonStaker, err)
+	assert.NoError(t, vdrs.AddWeight(certToID(cert), 1))
+
+	ip0 := utils.IPDesc{
+		IP:   net.IPv6loopback,
+		Port: 1,
+	}
+	ip1 := utils.IPDesc{
+		IP:   net.IPv6loopback,
+		Port: 2,
+	}
+	ip2 := utils.IPDesc{
+		IP:   net.IPv6loopback,
+		Port: 3,
+	}
+
+	err = n.trackSignedIP(newTestSignedIP(t, key, cert, ip0, now-1))
+	assert.NoError(t, err)
+
+	n.stateLock.RLock()
+	assert.True(t, n.latestIPs[nodeKey].IPDesc.Equal(ip0))
+	assert.Contains(t, n.disconnectedIPs, ip0.String())
+	n.stateLock.RUnlock()
+
+	// A newer claim should replace the older claim
+	err = n.trackSignedIP(newTestSignedIP(t, key, cert, ip1, now))
+	assert.NoError(t, err)
+
+	n.stateLock.RLock()
+	assert.True(t, n.latestIPs[nodeKey].IPDesc.Equal(ip1))
+	assert.NotContains(t, n.disconnectedIPs, ip0.String())
+	assert.Contains(t, n.disconnectedIPs, ip1.String())
+	n.stateLock.RUnlock()
+
+	// A stale claim should be dropped
+	err = n.trackSignedIP(newTestSignedIP(t, key, cert, ip2, now-2))
+	assert.NoError(t, err)
+
+	n.stateLock.RLock()
+	assert.True(t, n.latestIPs[nodeKey].IPDesc.Equal(ip1))
+	assert.NotContains(t, n.disconnectedIPs, ip2.String())
+	n.stateLock.RUnlock()
+
+	// A claim from the future should be dropped
+	future := now + uint64(2*defaultMaxClockDifference.Seconds())
+	err = n.trackSignedIP(newTestSignedIP(t, key, cert, ip2, future))
+	assert.Equal(t, errClaimFromFuture, err)
+
+	// A forged claim should be dropped
+	forged := newTestSignedIP(t, key, cert, ip2, now)
+	forged.Time++
+	err = n.trackSignedIP(forged)
+	assert.Error(t, err)
+
+	n.stateLock.RLock()
+	assert.True(t, n.latestIPs[nodeKey].IPDesc.Equal(ip1))
+	assert.NotContains(t, n.disconnectedIPs, ip2.String())
+	n.stateLock.RUnlock()
+
+	// When too many claims are tracked, the claims of nodes that are no longer
+	// validators are dropped to make room for new claims
+	otherKey, otherCert := newTestStakingCert(t)
+	assert.NoError(t, vdrs.AddWeight(certToID(otherCert), 1))
+
+	n.stateLock.Lock()
+	for len(n.latestIPs) < maxLatestIPs {
+		n.latestIPs[ids.GenerateTestShortID().Key()] = utils.IPCertDesc{}
+	}
+	n.stateLock.Unlock()
+
+	err = n.trackSignedIP(newTestSignedIP(t, otherKey, otherCert, ip2, now))
+	assert.NoError(t, err)
+
+	n.stateLock.RLock()
+	assert.Len(t, n.latestIPs, 2)
+	n.stateLock.RUnlock()
+
+	// If every tracked claim is from a validator, new claims are dropped
+	n.stateLock.Lock()
+	for len(n.latestIPs) < maxLatestIPs {
+		vdrID := ids.GenerateTestShortID()
+		assert.NoError(t, vdrs.AddWeight(vdrID, 1))
+		n.latestIPs[vdrID.Key()] = utils.IPCertDesc{}
+	}
+	n.stateLock.Unlock()
+
+	thirdKey, thirdCert := newTestStakingCert(t)
+	assert.NoError(t, vdrs.AddWeight(certToID(thirdCert), 1))
+	err = n.trackSignedIP(newTestSignedIP(t, thirdKey, thirdCert, ip0, now))
+	assert.Equal(t, errTooManyClaims, err)
+}
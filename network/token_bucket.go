@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// tokenBucket limits the rate that bytes are sent. Tokens are added to the
+// bucket at [rate] tokens per second, up to a total of [size] tokens. Sending a
+// message consumes a token for each byte of the message.
+//
+// A bucket with a rate of 0 never limits sending.
+type tokenBucket struct {
+	lock  sync.Mutex
+	clock *timer.Clock
+
+	rate, size float64
+
+	// tokens may be negative if bytes have been reserved that haven't been
+	// paid for yet
+	tokens     float64
+	lastUpdate time.Time
+}
+
+func newTokenBucket(clock *timer.Clock, rate, size uint64) *tokenBucket {
+	if size < rate {
+		size = rate
+	}
+	return &tokenBucket{
+		clock:      clock,
+		rate:       float64(rate),
+		size:       float64(size),
+		tokens:     float64(size),
+		lastUpdate: clock.Time(),
+	}
+}
+
+// reserve consumes [n] tokens and returns the amount of time the caller must
+// wait until the tokens have been paid for. A reservation is always granted, so
+// messages larger than the bucket are still able to be sent.
+func (b *tokenBucket) reserve(n int) time.Duration {
+	if b.rate == 0 {
+		return 0
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	if elapsed := now.Sub(b.lastUpdate); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.size {
+			b.tokens = b.size
+		}
+	}
+	b.lastUpdate = now
+
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,64 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func TestTokenBucketUnlimited(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(0, 0))
+
+	b := newTokenBucket(&clock, 0, 0)
+	assert.Equal(t, time.Duration(0), b.reserve(1<<30))
+	assert.Equal(t, time.Duration(0), b.reserve(1<<30))
+}
+
+func TestTokenBucketBurst(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(0, 0))
+
+	b := newTokenBucket(&clock, 100, 200)
+
+	// The bucket starts full, so a burst can be sent immediately
+	assert.Equal(t, time.Duration(0), b.reserve(200))
+
+	// The bucket is empty, so the next byte must wait
+	assert.Equal(t, time.Second, b.reserve(100))
+
+	// After the debt is paid, the bucket should refill at [rate]
+	clock.Set(time.Unix(2, 0))
+	assert.Equal(t, time.Duration(0), b.reserve(100))
+	assert.Equal(t, time.Second, b.reserve(100))
+}
+
+func TestTokenBucketRefillCapped(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(0, 0))
+
+	b := newTokenBucket(&clock, 100, 0)
+	assert.Equal(t, time.Duration(0), b.reserve(100))
+
+	// The bucket shouldn't hold more than [size] tokens, which defaults to
+	// [rate]
+	clock.Set(time.Unix(10, 0))
+	assert.Equal(t, time.Duration(0), b.reserve(100))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(50))
+}
+
+func TestTokenBucketLargeMessage(t *testing.T) {
+	clock := timer.Clock{}
+	clock.Set(time.Unix(0, 0))
+
+	b := newTokenBucket(&clock, 100, 100)
+
+	// Messages larger than the bucket must still be able to be sent
+	assert.Equal(t, 4*time.Second, b.reserve(500))
+}
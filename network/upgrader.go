@@ -56,10 +56,7 @@ func (t tlsServerUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error)
 		return ids.ShortID{}, nil, errNoCert
 	}
 	peerCert := connState.PeerCertificates[0]
-	id := ids.NewShortID(
-		hashing.ComputeHash160Array(
-			hashing.ComputeHash256(peerCert.Raw)))
-	return id, encConn, nil
+	return certToID(peerCert), encConn, nil
 }
 
 type tlsClientUpgrader struct {
@@ -84,8 +81,5 @@ func (t tlsClientUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error)
 		return ids.ShortID{}, nil, errNoCert
 	}
 	peerCert := connState.PeerCertificates[0]
-	id := ids.NewShortID(
-		hashing.ComputeHash160Array(
-			hashing.ComputeHash256(peerCert.Raw)))
-	return id, encConn, nil
+	return certToID(peerCert), encConn, nil
 }
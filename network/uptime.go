@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// UptimeCalculator reports how long other nodes have been observed as online
+type UptimeCalculator interface {
+	// CalculateUptimePercent returns the portion, in [0, 1], of [nodeID]'s
+	// current staking period that this node has observed it as online. Returns
+	// an error if the uptime of [nodeID] isn't known.
+	CalculateUptimePercent(nodeID ids.ShortID) (float64, error)
+}
+
+// UptimeResult describes how the validators this node is connected to
+// perceive its uptime
+type UptimeResult struct {
+	// WeightedAveragePercentage is the average of the uptimes, in [0, 100],
+	// reported by the validators, weighted by their stake
+	WeightedAveragePercentage float64
+
+	// RewardingStakePercentage is the percentage of stake, in [0, 100], that
+	// reported an uptime that meets the uptime requirement
+	RewardingStakePercentage float64
+}
+
+// NodeUptime implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) NodeUptime() (UptimeResult, bool) {
+	myWeight, isValidator := n.vdrs.GetWeight(n.id)
+	if !isValidator {
+		return UptimeResult{}, false
+	}
+
+	// This node considers itself to have been online for its entire staking
+	// period
+	totalWeight := float64(myWeight)
+	rewardingWeight := float64(myWeight)
+	weightedUptime := float64(myWeight) * 100
+
+	requiredPercentage := n.uptimeRequirement * 100
+
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	for _, peer := range n.peers {
+		if !peer.connected.GetValue() {
+			continue
+		}
+		weight, ok := n.vdrs.GetWeight(peer.id)
+		if !ok {
+			continue
+		}
+		uptime, ok := peer.observedUptime()
+		if !ok {
+			continue
+		}
+
+		floatWeight := float64(weight)
+		percentage := float64(uptime)
+
+		totalWeight += floatWeight
+		weightedUptime += floatWeight * percentage
+		if percentage >= requiredPercentage {
+			rewardingWeight += floatWeight
+		}
+	}
+
+	return UptimeResult{
+		WeightedAveragePercentage: weightedUptime / totalWeight,
+		RewardingStakePercentage:  100 * rewardingWeight / totalWeight,
+	}, true
+}
@@ -0,0 +1,57 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+func TestNodeUptime(t *testing.T) {
+	myID := ids.GenerateTestShortID()
+	goodID := ids.GenerateTestShortID()
+	badID := ids.GenerateTestShortID()
+	unknownID := ids.GenerateTestShortID()
+
+	vdrs := validators.NewSet()
+	assert.NoError(t, vdrs.AddWeight(goodID, 1))
+	assert.NoError(t, vdrs.AddWeight(badID, 1))
+	assert.NoError(t, vdrs.AddWeight(unknownID, 1))
+
+	n := &network{
+		id:                myID,
+		vdrs:              vdrs,
+		uptimeRequirement: .6,
+		peers:             make(map[[20]byte]*peer),
+	}
+
+	_, isValidator := n.NodeUptime()
+	assert.False(t, isValidator)
+
+	assert.NoError(t, vdrs.AddWeight(myID, 2))
+
+	good := &peer{net: n, id: goodID}
+	good.connected.SetValue(true)
+	good.uptime.SetValue(uint8(80))
+	n.peers[goodID.Key()] = good
+
+	bad := &peer{net: n, id: badID}
+	bad.connected.SetValue(true)
+	bad.uptime.SetValue(uint8(40))
+	n.peers[badID.Key()] = bad
+
+	// Peers that haven't reported an uptime shouldn't be counted
+	unknown := &peer{net: n, id: unknownID}
+	unknown.connected.SetValue(true)
+	n.peers[unknownID.Key()] = unknown
+
+	result, isValidator := n.NodeUptime()
+	assert.True(t, isValidator)
+	assert.Equal(t, 80., result.WeightedAveragePercentage)
+	assert.Equal(t, 75., result.RewardingStakePercentage)
+}
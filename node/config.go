@@ -10,8 +10,10 @@ import (
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/nat"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/faults"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
@@ -87,6 +89,9 @@ type Config struct {
 	// Consensus configuration
 	ConsensusParams avalanche.Parameters
 
+	// If true, polls skip validators that aren't connected or are benched
+	SkipUnavailableValidators bool
+
 	// Throughput configuration
 	ThroughputPort          uint16
 	ThroughputServerEnabled bool
@@ -110,6 +115,29 @@ type Config struct {
 	ConnMeterResetDuration time.Duration
 	ConnMeterMaxConns      int
 
+	// Compression of large network messages
+	NetworkCompressionEnabled   bool
+	NetworkCompressionThreshold int
+
+	// Throttling of outbound network bandwidth, in bytes per second
+	NetworkPeerBandwidth      uint64
+	NetworkPeerBandwidthBurst uint64
+	NetworkBandwidth          uint64
+	NetworkBandwidthBurst     uint64
+
+	// Liveness checks of peers
+	NetworkPingPongTimeout time.Duration
+	NetworkPingFrequency   time.Duration
+
+	// Limits on the number of peers
+	NetworkConnLimits network.ConnLimits
+
+	// If StaticPeersOnly is true, the node only dials StaticPeerIPs and only
+	// accepts connections from StaticPeerIDs
+	StaticPeersOnly bool
+	StaticPeerIPs   []utils.IPDesc
+	StaticPeerIDs   ids.ShortSet
+
 	// Subnet Whitelist
 	WhitelistedSubnets ids.Set
 
@@ -120,4 +148,11 @@ type Config struct {
 
 	// Coreth
 	CorethConfig string
+
+	// Directory of the subnet configs
+	SubnetConfigDir string
+
+	// If non-nil, faults are injected into the messages that chains send.
+	// Only for local test networks.
+	FaultInjection *faults.Config
 }
@@ -4,6 +4,7 @@
 package node
 
 import (
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
@@ -67,9 +68,11 @@ var (
 	genesisHashKey = []byte("genesisID")
 
 	// Version is the version of this code
-	Version                 = version.NewDefaultVersion(constants.PlatformName, 1, 0, 6)
+	Version                 = version.NewDefaultVersion(constants.PlatformName, 1, 0, 7)
 	versionParser           = version.NewDefaultParser()
 	beaconConnectionTimeout = 1 * time.Minute
+
+	errInvalidStakingKey = errors.New("staking key can't be used to sign")
 )
 
 // Node is an instance of an Avalanche node.
@@ -97,6 +100,15 @@ type Node struct {
 	// Manages creation of blockchains and routing messages to them
 	chainManager chains.Manager
 
+	// Benches validators that consistently fail to respond to queries
+	benchlistManager benchlist.Manager
+
+	// Manages network timeouts
+	timeoutManager timeout.Manager
+
+	// Reports the uptimes of validators as observed by the platform chain
+	uptimes *platformvm.Uptimes
+
 	// Manages Virtual Machines
 	vmManager vms.Manager
 
@@ -151,13 +163,29 @@ func (n *Node) initNetworking() error {
 	}
 	dialer := network.NewDialer(TCP)
 
-	var serverUpgrader, clientUpgrader network.Upgrader
+	var (
+		serverUpgrader, clientUpgrader network.Upgrader
+		stakingKey                     crypto.Signer
+		stakingCert                    *x509.Certificate
+	)
 	if n.Config.EnableP2PTLS {
 		cert, err := tls.LoadX509KeyPair(n.Config.StakingCertFile, n.Config.StakingKeyFile)
 		if err != nil {
 			return err
 		}
 
+		// The staking key is also used to sign this node's IP when it is
+		// gossiped to other nodes
+		key, ok := cert.PrivateKey.(crypto.Signer)
+		if !ok {
+			return errInvalidStakingKey
+		}
+		stakingKey = key
+		stakingCert, err = x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return err
+		}
+
 		// #nosec G402
 		tlsConfig := &tls.Config{
 			Certificates: []tls.Certificate{cert},
@@ -244,6 +272,23 @@ func (n *Node) initNetworking() error {
 		n.Config.RestartOnDisconnected,
 		n.Config.DisconnectedCheckFreq,
 		n.Config.DisconnectedRestartTimeout,
+		n.Config.NetworkCompressionEnabled,
+		n.Config.NetworkCompressionThreshold,
+		stakingKey,
+		stakingCert,
+		n.Config.NetworkPeerBandwidth,
+		n.Config.NetworkPeerBandwidthBurst,
+		n.Config.NetworkBandwidth,
+		n.Config.NetworkBandwidthBurst,
+		prefixdb.New([]byte("network"), n.DB),
+		n.Config.NetworkPingPongTimeout,
+		n.Config.NetworkPingFrequency,
+		n.uptimes,
+		n.Config.UptimeRequirement,
+		n.Config.StaticPeersOnly,
+		n.Config.StaticPeerIDs,
+		n.Config.StaticPeerIPs,
+		n.Config.NetworkConnLimits,
 	)
 
 	n.nodeCloser = utils.HandleSignals(func(os.Signal) {
@@ -341,12 +386,21 @@ func (n *Node) Dispatch() error {
 	// Add bootstrap nodes to the peer network
 	for _, peer := range n.Config.BootstrapPeers {
 		if !peer.IP.Equal(n.Config.StakingIP.IP()) {
-			n.Net.Track(peer.IP)
+			if err := n.Net.Track(peer.IP); err != nil {
+				n.Log.Error("couldn't add %s as a bootstrapper: %s", peer.IP, err)
+			}
 		} else {
 			n.Log.Error("can't add self as a bootstrapper")
 		}
 	}
 
+	// Add static peers to the peer network
+	for _, ip := range n.Config.StaticPeerIPs {
+		if !ip.Equal(n.Config.StakingIP.IP()) {
+			_ = n.Net.Track(ip) // static peers are always accepted
+		}
+	}
+
 	// Start P2P connections
 	err := n.Net.Dispatch()
 
@@ -519,20 +573,23 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
-	benchlistManager := benchlist.NewManager(&n.Config.BenchlistConfig)
+	n.Config.BenchlistConfig.DB = prefixdb.New([]byte("benchlist"), n.DB)
+	n.benchlistManager, err = benchlist.NewManager(&n.Config.BenchlistConfig)
+	if err != nil {
+		return err
+	}
 
 	// Manages network timeouts
-	timeoutManager := timeout.Manager{}
-	if err := timeoutManager.Initialize(&n.Config.NetworkConfig, benchlistManager); err != nil {
+	if err := n.timeoutManager.Initialize(&n.Config.NetworkConfig, n.benchlistManager); err != nil {
 		return err
 	}
-	go n.Log.RecoverAndPanic(timeoutManager.Dispatch)
+	go n.Log.RecoverAndPanic(n.timeoutManager.Dispatch)
 
 	// Routes incoming messages from peers to the appropriate chain
 	n.Config.ConsensusRouter.Initialize(
 		n.ID,
 		n.Log,
-		&timeoutManager,
+		&n.timeoutManager,
 		n.Config.ConsensusGossipFrequency,
 		n.Config.ConsensusShutdownTimeout,
 		criticalChains,
@@ -562,9 +619,13 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		AVAXAssetID:             avaxAssetID,
 		XChainID:                xChainID,
 		CriticalChains:          criticalChains,
-		TimeoutManager:          &timeoutManager,
+		TimeoutManager:          &n.timeoutManager,
 		HealthService:           n.healthService,
 		WhitelistedSubnets:      n.Config.WhitelistedSubnets,
+
+		SkipUnavailableValidators: n.Config.SkipUnavailableValidators,
+		SubnetConfigDir:           n.Config.SubnetConfigDir,
+		FaultInjection:            n.Config.FaultInjection,
 	})
 
 	vdrs := n.vdrs
@@ -593,6 +654,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			MinStakeDuration:   n.Config.MinStakeDuration,
 			MaxStakeDuration:   n.Config.MaxStakeDuration,
 			StakeMintingPeriod: n.Config.StakeMintingPeriod,
+			Uptimes:            n.uptimes,
 		}),
 		n.vmManager.RegisterVMFactory(avm.ID, &avm.Factory{
 			CreationFee: n.Config.CreationTxFee,
@@ -675,7 +737,7 @@ func (n *Node) initAdminAPI() error {
 		return nil
 	}
 	n.Log.Info("initializing admin API")
-	service, err := admin.NewService(n.Log, n.chainManager, &n.APIServer)
+	service, err := admin.NewService(n.Log, n.chainManager, n.Net, n.benchlistManager, &n.APIServer)
 	if err != nil {
 		return err
 	}
@@ -695,6 +757,8 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkID,
 		n.chainManager,
 		n.Net,
+		n.benchlistManager,
+		&n.timeoutManager,
 		n.Config.CreationTxFee,
 		n.Config.TxFee,
 	)
@@ -835,6 +899,7 @@ func (n *Node) Initialize(
 		return fmt.Errorf("problem initializing shared memory: %w", err)
 	}
 
+	n.uptimes = &platformvm.Uptimes{}
 	if err = n.initNetworking(); err != nil { // Set up all networking
 		return fmt.Errorf("problem initializing networking: %w", err)
 	}
@@ -67,4 +67,48 @@ type Consensus interface {
 	// finalized. Note, it is possible that after returning finalized, a new
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
+
+	// Inspect returns a description of the vertices and transactions that are
+	// being decided. It is only intended to be used to debug chains that
+	// aren't making progress. Returns if a critical error has occurred.
+	Inspect() (Inspection, error)
+}
+
+// Inspection describes the vertices and transactions that an avalanche
+// instance is deciding
+type Inspection struct {
+	// Frontier are the vertices that have no children, ordered by ID
+	Frontier []ids.ID `json:"frontier"`
+	// Preferred are the vertices of the frontier that are strongly preferred,
+	// ordered by ID
+	Preferred []ids.ID `json:"preferred"`
+	// Virtuous are the vertices of the frontier that are strongly virtuous,
+	// ordered by ID
+	Virtuous []ids.ID `json:"virtuous"`
+	// Processing are the vertices that have been added but not decided,
+	// ordered by ID
+	Processing []VertexInspection `json:"processing"`
+	// Conflicts are the processing transactions that conflict with other
+	// transactions, ordered by ID
+	Conflicts []TxInspection `json:"conflicts"`
+	// ConflictGraph describes the snowball instances of the transactions in
+	// the conflict graph
+	ConflictGraph string `json:"conflictGraph"`
+}
+
+// VertexInspection describes a vertex that is being decided
+type VertexInspection struct {
+	ID        ids.ID   `json:"id"`
+	ParentIDs []ids.ID `json:"parentIDs"`
+	// TxIDs are the transactions of the vertex that haven't been decided
+	TxIDs []ids.ID `json:"txIDs"`
+}
+
+// TxInspection describes a transaction that conflicts with other transactions
+type TxInspection struct {
+	ID        ids.ID `json:"id"`
+	Preferred bool   `json:"preferred"`
+	// Conflicts are the transactions this transaction conflicts with, ordered
+	// by ID
+	Conflicts []ids.ID `json:"conflicts"`
 }
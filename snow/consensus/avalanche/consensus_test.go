@@ -34,6 +34,7 @@ var (
 		SplitVotingTest,
 		TransitiveRejectionTest,
 		IsVirtuousTest,
+		InspectTest,
 		QuiesceTest,
 		OrphansTest,
 		ErrorOnVacuousAcceptTest,
@@ -1267,6 +1268,131 @@ func IsVirtuousTest(t *testing.T, factory Factory) {
 	}
 }
 
+func InspectTest(t *testing.T, factory Factory) {
+	avl := factory.New()
+
+	params := Parameters{
+		Parameters: snowball.Parameters{
+			Metrics:           prometheus.NewRegistry(),
+			K:                 2,
+			Alpha:             2,
+			BetaVirtuous:      1,
+			BetaRogue:         2,
+			ConcurrentRepolls: 1,
+		},
+		Parents:   2,
+		BatchSize: 1,
+	}
+	vts := []Vertex{
+		&TestVertex{TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		}},
+		&TestVertex{TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		}},
+	}
+	utxos := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+
+	if err := avl.Initialize(snow.DefaultContextTest(), params, vts); err != nil {
+		t.Fatal(err)
+	}
+
+	tx0 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx0.InputIDsV = append(tx0.InputIDsV, utxos[0])
+
+	tx1 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx1.InputIDsV = append(tx1.InputIDsV, utxos[0])
+
+	tx2 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx2.InputIDsV = append(tx2.InputIDsV, utxos[1])
+
+	vtx0 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: vts,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0, tx2},
+	}
+	vtx1 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: vts,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx1},
+	}
+
+	if err := avl.Add(vtx0); err != nil {
+		t.Fatal(err)
+	} else if err := avl.Add(vtx1); err != nil {
+		t.Fatal(err)
+	}
+
+	inspection, err := avl.Inspect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFrontier := []ids.ID{vtx0.IDV, vtx1.IDV}
+	ids.SortIDs(expectedFrontier)
+	switch {
+	case len(inspection.Frontier) != 2:
+		t.Fatalf("Wrong frontier size %d", len(inspection.Frontier))
+	case inspection.Frontier[0] != expectedFrontier[0] || inspection.Frontier[1] != expectedFrontier[1]:
+		t.Fatalf("Wrong frontier %s", inspection.Frontier)
+	case len(inspection.Preferred) != 1 || inspection.Preferred[0] != vtx0.IDV:
+		t.Fatalf("Wrong preferred frontier %s", inspection.Preferred)
+	case len(inspection.Processing) != 2:
+		t.Fatalf("Wrong number of processing vertices %d", len(inspection.Processing))
+	case inspection.ConflictGraph == "":
+		t.Fatalf("Should have described the conflict graph")
+	}
+
+	for _, vtx := range inspection.Processing {
+		switch {
+		case len(vtx.ParentIDs) != 2:
+			t.Fatalf("Wrong number of parents %d", len(vtx.ParentIDs))
+		case vtx.ID == vtx0.IDV && len(vtx.TxIDs) != 2:
+			t.Fatalf("Wrong number of txs %d in vtx0", len(vtx.TxIDs))
+		case vtx.ID == vtx1.IDV && (len(vtx.TxIDs) != 1 || vtx.TxIDs[0] != tx1.IDV):
+			t.Fatalf("Wrong txs %s in vtx1", vtx.TxIDs)
+		}
+	}
+
+	// tx2 doesn't conflict with any transaction
+	if len(inspection.Conflicts) != 2 {
+		t.Fatalf("Expected 2 conflicting txs but got %d", len(inspection.Conflicts))
+	}
+	for _, tx := range inspection.Conflicts {
+		switch tx.ID {
+		case tx0.IDV:
+			if !tx.Preferred || len(tx.Conflicts) != 1 || tx.Conflicts[0] != tx1.IDV {
+				t.Fatalf("Wrong inspection of tx0: %+v", tx)
+			}
+		case tx1.IDV:
+			if tx.Preferred || len(tx.Conflicts) != 1 || tx.Conflicts[0] != tx0.IDV {
+				t.Fatalf("Wrong inspection of tx1: %+v", tx)
+			}
+		default:
+			t.Fatalf("Unexpected conflicting tx %s", tx.ID)
+		}
+	}
+}
+
 func QuiesceTest(t *testing.T, factory Factory) {
 	avl := factory.New()
 
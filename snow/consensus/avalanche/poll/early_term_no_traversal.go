@@ -74,6 +74,9 @@ func (p *earlyTermNoTraversalPoll) Finished() bool {
 	return partialVotes.Len()+numPending < p.alpha
 }
 
+// Waiting returns the validators that haven't responded to this poll
+func (p *earlyTermNoTraversalPoll) Waiting() []ids.ShortID { return p.polled.List() }
+
 // Result returns the result of this poll
 func (p *earlyTermNoTraversalPoll) Result() ids.UniqueBag { return p.votes }
 
@@ -5,6 +5,7 @@ package poll
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 )
@@ -16,6 +17,7 @@ type Set interface {
 	Add(requestID uint32, vdrs ids.ShortBag) bool
 	Vote(requestID uint32, vdr ids.ShortID, votes []ids.ID) (ids.UniqueBag, bool)
 	Len() int
+	Outstanding() []Info
 }
 
 // Poll is an outstanding poll
@@ -25,9 +27,18 @@ type Poll interface {
 
 	Vote(vdr ids.ShortID, votes []ids.ID)
 	Finished() bool
+	Waiting() []ids.ShortID
 	Result() ids.UniqueBag
 }
 
+// Info describes an outstanding poll
+type Info struct {
+	RequestID uint32
+	Start     time.Time
+	// Validators that haven't responded to the poll
+	Waiting []ids.ShortID
+}
+
 // Factory creates a new Poll
 type Factory interface {
 	New(vdrs ids.ShortBag) Poll
@@ -41,6 +41,9 @@ func (p *noEarlyTermPoll) Vote(vdr ids.ShortID, votes []ids.ID) {
 // Finished returns true when all validators have voted
 func (p *noEarlyTermPoll) Finished() bool { return p.polled.Len() == 0 }
 
+// Waiting returns the validators that haven't responded to this poll
+func (p *noEarlyTermPoll) Waiting() []ids.ShortID { return p.polled.List() }
+
 // Result returns the result of this poll
 func (p *noEarlyTermPoll) Result() ids.UniqueBag { return p.votes }
 
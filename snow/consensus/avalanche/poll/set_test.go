@@ -106,3 +106,48 @@ func TestSetString(t *testing.T) {
 			str)
 	}
 }
+
+func TestSetOutstanding(t *testing.T) {
+	factory := NewNoEarlyTermFactory()
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s := NewSet(factory, log, namespace, registerer)
+
+	vtxID := ids.ID{1}
+
+	vdr1 := ids.NewShortID([20]byte{1})
+	vdr2 := ids.NewShortID([20]byte{2}) // k = 2
+
+	// The polls can't share a bag, as polls remove validators from it
+	vdrs1 := ids.ShortBag{}
+	vdrs1.Add(
+		vdr1,
+		vdr2,
+	)
+	vdrs2 := ids.ShortBag{}
+	vdrs2.Add(
+		vdr1,
+		vdr2,
+	)
+
+	if !s.Add(2, vdrs2) {
+		t.Fatalf("Should have been able to add a new poll")
+	} else if !s.Add(1, vdrs1) {
+		t.Fatalf("Should have been able to add a new poll")
+	} else if _, finished := s.Vote(2, vdr1, []ids.ID{vtxID}); finished {
+		t.Fatalf("Poll shouldn't have finished yet")
+	}
+
+	outstanding := s.Outstanding()
+	switch {
+	case len(outstanding) != 2:
+		t.Fatalf("Expected 2 outstanding polls but got %d", len(outstanding))
+	case outstanding[0].RequestID != 1 || outstanding[1].RequestID != 2:
+		t.Fatalf("Outstanding polls should be ordered by requestID")
+	case len(outstanding[0].Waiting) != 2:
+		t.Fatalf("Poll 1 should be waiting on 2 validators but is waiting on %d", len(outstanding[0].Waiting))
+	case len(outstanding[1].Waiting) != 1 || !outstanding[1].Waiting[0].Equals(vdr2):
+		t.Fatalf("Poll 2 should only be waiting on %s", vdr2)
+	}
+}
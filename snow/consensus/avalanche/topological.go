@@ -193,6 +193,85 @@ func (ta *Topological) Quiesce() bool { return ta.cg.Quiesce() }
 // Finalized implements the Avalanche interface
 func (ta *Topological) Finalized() bool { return ta.cg.Finalized() }
 
+// Inspect implements the Avalanche interface
+func (ta *Topological) Inspect() (Inspection, error) {
+	inspection := Inspection{
+		Frontier:      make([]ids.ID, 0, len(ta.frontier)),
+		Preferred:     ta.preferred.List(),
+		Virtuous:      ta.virtuous.List(),
+		Processing:    make([]VertexInspection, 0, len(ta.nodes)),
+		ConflictGraph: ta.cg.String(),
+	}
+	for vtxID := range ta.frontier {
+		inspection.Frontier = append(inspection.Frontier, vtxID)
+	}
+	ids.SortIDs(inspection.Frontier)
+	ids.SortIDs(inspection.Preferred)
+	ids.SortIDs(inspection.Virtuous)
+
+	vtxIDs := make([]ids.ID, 0, len(ta.nodes))
+	for vtxID := range ta.nodes {
+		vtxIDs = append(vtxIDs, vtxID)
+	}
+	ids.SortIDs(vtxIDs)
+
+	preferences := ta.cg.Preferences()
+	conflicts := make(map[ids.ID]TxInspection)
+	for _, vtxID := range vtxIDs {
+		vtx := ta.nodes[vtxID]
+		parents, err := vtx.Parents()
+		if err != nil {
+			return Inspection{}, err
+		}
+		txs, err := vtx.Txs()
+		if err != nil {
+			return Inspection{}, err
+		}
+
+		vtxInspection := VertexInspection{
+			ID:        vtxID,
+			ParentIDs: make([]ids.ID, len(parents)),
+		}
+		for i, parent := range parents {
+			vtxInspection.ParentIDs[i] = parent.ID()
+		}
+		for _, tx := range txs {
+			if tx.Status().Decided() {
+				continue
+			}
+			txID := tx.ID()
+			vtxInspection.TxIDs = append(vtxInspection.TxIDs, txID)
+
+			if _, ok := conflicts[txID]; ok {
+				continue
+			}
+			conflictIDs := ta.cg.Conflicts(tx)
+			if conflictIDs.Len() == 0 {
+				continue
+			}
+			txInspection := TxInspection{
+				ID:        txID,
+				Preferred: preferences.Contains(txID),
+				Conflicts: conflictIDs.List(),
+			}
+			ids.SortIDs(txInspection.Conflicts)
+			conflicts[txID] = txInspection
+		}
+		inspection.Processing = append(inspection.Processing, vtxInspection)
+	}
+
+	txIDs := make([]ids.ID, 0, len(conflicts))
+	for txID := range conflicts {
+		txIDs = append(txIDs, txID)
+	}
+	ids.SortIDs(txIDs)
+	inspection.Conflicts = make([]TxInspection, len(txIDs))
+	for i, txID := range txIDs {
+		inspection.Conflicts[i] = conflicts[txID]
+	}
+	return inspection, nil
+}
+
 // Takes in a list of votes and sets up the topological ordering. Returns the
 // reachable section of the graph annotated with the number of inbound edges and
 // the non-transitively applied votes. Also returns the list of leaf nodes.
@@ -37,4 +37,32 @@ type Consensus interface {
 	// finalized. Note, it is possible that after returning finalized, a new
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
+
+	// Inspect returns a description of the blocks that are being decided. It
+	// is only intended to be used to debug chains that aren't making progress.
+	Inspect() Inspection
+}
+
+// Inspection describes the blocks that a snowman instance is deciding
+type Inspection struct {
+	// LastAccepted is the ID of the last accepted block
+	LastAccepted ids.ID `json:"lastAccepted"`
+	// Snowball describes the snowball instance that is deciding between the
+	// children of the last accepted block. Empty if there are no children.
+	Snowball string `json:"snowball"`
+	// Preferred is the strongly preferred sequence of processing blocks, from
+	// a child of the last accepted block to the preferred block
+	Preferred []ids.ID `json:"preferred"`
+	// Processing are the blocks that have been added but not decided, ordered
+	// by ID
+	Processing []BlockInspection `json:"processing"`
+}
+
+// BlockInspection describes a block that is being decided
+type BlockInspection struct {
+	ID       ids.ID `json:"id"`
+	ParentID ids.ID `json:"parentID"`
+	// Snowball describes the snowball instance that is deciding between the
+	// children of this block. Empty if there are no children.
+	Snowball string `json:"snowball"`
 }
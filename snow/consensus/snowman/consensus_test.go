@@ -40,6 +40,7 @@ var (
 		RecordPollInvalidVoteTest,
 		RecordPollTransitiveVotingTest,
 		RecordPollDivergedVotingTest,
+		InspectTest,
 		MetricsProcessingErrorTest,
 		MetricsAcceptedErrorTest,
 		MetricsRejectedErrorTest,
@@ -913,6 +914,95 @@ func RecordPollDivergedVotingTest(t *testing.T, factory Factory) {
 	}
 }
 
+// Make sure that inspecting describes the processing blocks and the preferred
+// branch
+func InspectTest(t *testing.T, factory Factory) {
+	sm := factory.New()
+
+	ctx := snow.DefaultContextTest()
+	params := snowball.Parameters{
+		Metrics:           prometheus.NewRegistry(),
+		K:                 1,
+		Alpha:             1,
+		BetaVirtuous:      3,
+		BetaRogue:         5,
+		ConcurrentRepolls: 1,
+	}
+	if err := sm.Initialize(ctx, params, GenesisID); err != nil {
+		t.Fatal(err)
+	}
+
+	if inspection := sm.Inspect(); inspection.LastAccepted != GenesisID ||
+		inspection.Snowball != "" ||
+		len(inspection.Preferred) != 0 ||
+		len(inspection.Processing) != 0 {
+		t.Fatalf("Wrong inspection of an empty instance: %+v", inspection)
+	}
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block0,
+	}
+	for _, block := range []*TestBlock{block0, block1, block2} {
+		if err := sm.Add(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	inspection := sm.Inspect()
+	if inspection.LastAccepted != GenesisID {
+		t.Fatalf("Wrong last accepted block %s", inspection.LastAccepted)
+	} else if inspection.Snowball == "" {
+		t.Fatalf("Should have described the snowball instance of the last accepted block")
+	} else if len(inspection.Preferred) != 2 ||
+		inspection.Preferred[0] != block0.IDV ||
+		inspection.Preferred[1] != block2.IDV {
+		t.Fatalf("Wrong preferred branch %s", inspection.Preferred)
+	}
+
+	expectedIDs := []ids.ID{block0.IDV, block1.IDV, block2.IDV}
+	ids.SortIDs(expectedIDs)
+	if len(inspection.Processing) != len(expectedIDs) {
+		t.Fatalf("Expected %d processing blocks but got %d", len(expectedIDs), len(inspection.Processing))
+	}
+	for i, block := range inspection.Processing {
+		if block.ID != expectedIDs[i] {
+			t.Fatalf("Processing blocks should be ordered by ID")
+		}
+		switch block.ID {
+		case block0.IDV:
+			if block.ParentID != GenesisID || block.Snowball == "" {
+				t.Fatalf("Wrong inspection of block0: %+v", block)
+			}
+		case block1.IDV:
+			if block.ParentID != GenesisID || block.Snowball != "" {
+				t.Fatalf("Wrong inspection of block1: %+v", block)
+			}
+		case block2.IDV:
+			if block.ParentID != block0.IDV || block.Snowball != "" {
+				t.Fatalf("Wrong inspection of block2: %+v", block)
+			}
+		}
+	}
+}
+
 func MetricsProcessingErrorTest(t *testing.T, factory Factory) {
 	sm := factory.New()
 
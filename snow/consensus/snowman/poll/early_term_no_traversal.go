@@ -60,6 +60,9 @@ func (p *earlyTermNoTraversalPoll) Finished() bool {
 		received+remaining < p.alpha // An alpha majority can never return
 }
 
+// Waiting returns the validators that haven't responded to this poll
+func (p *earlyTermNoTraversalPoll) Waiting() []ids.ShortID { return p.polled.List() }
+
 // Result returns the result of this poll
 func (p *earlyTermNoTraversalPoll) Result() ids.Bag { return p.votes }
 
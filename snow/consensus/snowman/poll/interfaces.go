@@ -5,6 +5,7 @@ package poll
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 )
@@ -17,6 +18,7 @@ type Set interface {
 	Vote(requestID uint32, vdr ids.ShortID, vote ids.ID) (ids.Bag, bool)
 	Drop(requestID uint32, vdr ids.ShortID) (ids.Bag, bool)
 	Len() int
+	Outstanding() []Info
 }
 
 // Poll is an outstanding poll
@@ -27,9 +29,18 @@ type Poll interface {
 	Vote(vdr ids.ShortID, vote ids.ID)
 	Drop(vdr ids.ShortID)
 	Finished() bool
+	Waiting() []ids.ShortID
 	Result() ids.Bag
 }
 
+// Info describes an outstanding poll
+type Info struct {
+	RequestID uint32
+	Start     time.Time
+	// Validators that haven't responded to the poll
+	Waiting []ids.ShortID
+}
+
 // Factory creates a new Poll
 type Factory interface {
 	New(vdrs ids.ShortBag) Poll
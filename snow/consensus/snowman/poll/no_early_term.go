@@ -42,6 +42,9 @@ func (p *noEarlyTermPoll) Drop(vdr ids.ShortID) { p.polled.Remove(vdr) }
 // Finished returns true when all validators have voted
 func (p *noEarlyTermPoll) Finished() bool { return p.polled.Len() == 0 }
 
+// Waiting returns the validators that haven't responded to this poll
+func (p *noEarlyTermPoll) Waiting() []ids.ShortID { return p.polled.List() }
+
 // Result returns the result of this poll
 func (p *noEarlyTermPoll) Result() ids.Bag { return p.votes }
 
@@ -5,6 +5,7 @@ package poll
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -148,6 +149,20 @@ func (s *set) Drop(requestID uint32, vdr ids.ShortID) (ids.Bag, bool) {
 // Len returns the number of outstanding polls
 func (s *set) Len() int { return len(s.polls) }
 
+// Outstanding returns the outstanding polls, ordered by requestID
+func (s *set) Outstanding() []Info {
+	infos := make([]Info, 0, len(s.polls))
+	for requestID, poll := range s.polls {
+		infos = append(infos, Info{
+			RequestID: requestID,
+			Start:     poll.start,
+			Waiting:   poll.Waiting(),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].RequestID < infos[j].RequestID })
+	return infos
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", len(s.polls)))
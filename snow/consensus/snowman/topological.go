@@ -186,6 +186,44 @@ func (ts *Topological) RecordPoll(voteBag ids.Bag) error {
 // Finalized implements the Snowman interface
 func (ts *Topological) Finalized() bool { return len(ts.blocks) == 1 }
 
+// Inspect implements the Snowman interface
+func (ts *Topological) Inspect() Inspection {
+	inspection := Inspection{
+		LastAccepted: ts.head,
+		Processing:   make([]BlockInspection, 0, len(ts.blocks)-1),
+	}
+	if head := ts.blocks[ts.head]; head.sb != nil {
+		inspection.Snowball = head.sb.String()
+	}
+
+	// Follow the preferred children from the last accepted block
+	for block := ts.blocks[ts.head]; block.sb != nil; {
+		blkID := block.sb.Preference()
+		inspection.Preferred = append(inspection.Preferred, blkID)
+		block = ts.blocks[blkID]
+	}
+
+	blkIDs := make([]ids.ID, 0, len(ts.blocks)-1)
+	for blkID := range ts.blocks {
+		if blkID != ts.head {
+			blkIDs = append(blkIDs, blkID)
+		}
+	}
+	ids.SortIDs(blkIDs)
+	for _, blkID := range blkIDs {
+		block := ts.blocks[blkID]
+		blockInspection := BlockInspection{
+			ID:       blkID,
+			ParentID: block.blk.Parent().ID(),
+		}
+		if block.sb != nil {
+			blockInspection.Snowball = block.sb.String()
+		}
+		inspection.Processing = append(inspection.Processing, blockInspection)
+	}
+	return inspection
+}
+
 // takes in a list of votes and sets up the topological ordering. Returns the
 // reachable section of the graph annotated with the number of inbound edges and
 // the non-transitively applied votes. Also returns the list of leaf blocks.
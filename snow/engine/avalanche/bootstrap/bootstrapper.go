@@ -409,3 +409,39 @@ func (b *Bootstrapper) Disconnected(validatorID ids.ShortID) error {
 	}
 	return b.Bootstrapper.Disconnected(validatorID)
 }
+
+// AppRequest implements the Engine interface.
+func (b *Bootstrapper) AppRequest(nodeID ids.ShortID, requestID uint32, appRequestBytes []byte) error {
+	if appVM, ok := b.VM.(common.AppVM); ok {
+		return appVM.AppRequest(nodeID, requestID, appRequestBytes)
+	}
+	b.Ctx.Log.Debug("dropping AppRequest(%s, %d) due to the VM not handling application level messages", nodeID, requestID)
+	return nil
+}
+
+// AppResponse implements the Engine interface.
+func (b *Bootstrapper) AppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte) error {
+	if appVM, ok := b.VM.(common.AppVM); ok {
+		return appVM.AppResponse(nodeID, requestID, appResponseBytes)
+	}
+	b.Ctx.Log.Debug("dropping AppResponse(%s, %d) due to the VM not handling application level messages", nodeID, requestID)
+	return nil
+}
+
+// AppRequestFailed implements the Engine interface.
+func (b *Bootstrapper) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
+	if appVM, ok := b.VM.(common.AppVM); ok {
+		return appVM.AppRequestFailed(nodeID, requestID)
+	}
+	b.Ctx.Log.Debug("dropping AppRequestFailed(%s, %d) due to the VM not handling application level messages", nodeID, requestID)
+	return nil
+}
+
+// AppGossip implements the Engine interface.
+func (b *Bootstrapper) AppGossip(nodeID ids.ShortID, appGossipBytes []byte) error {
+	if appVM, ok := b.VM.(common.AppVM); ok {
+		return appVM.AppGossip(nodeID, appGossipBytes)
+	}
+	b.Ctx.Log.Verbo("dropping AppGossip from %s due to the VM not handling application level messages", nodeID)
+	return nil
+}
@@ -617,3 +617,51 @@ func (t *Transitive) Health() (interface{}, error) {
 	// TODO add more health checks
 	return t.VM.Health()
 }
+
+// Inspection describes the state of an avalanche engine
+type Inspection struct {
+	Bootstrapped bool `json:"bootstrapped"`
+	// Consensus is omitted while the chain is bootstrapping
+	Consensus *avalanche.Inspection   `json:"consensus,omitempty"`
+	Polls     []common.PollInspection `json:"polls"`
+	// Pending are the vertices that are waiting on missing dependencies before
+	// they can be issued into consensus, ordered by ID
+	Pending []ids.ID `json:"pending"`
+	// MissingTxs are the transactions that vertices are waiting on, ordered
+	// by ID
+	MissingTxs      []ids.ID                   `json:"missingTxs"`
+	BlockedVertices []common.BlockedInspection `json:"blockedVertices"`
+	BlockedTxs      []common.BlockedInspection `json:"blockedTxs"`
+}
+
+// Inspect implements the common.Engine interface
+func (t *Transitive) Inspect() (interface{}, error) {
+	inspection := Inspection{
+		Bootstrapped: t.Ctx.IsBootstrapped(),
+		Polls:        []common.PollInspection{},
+		Pending:      t.pending.List(),
+		MissingTxs:   t.missingTxs.List(),
+	}
+	if inspection.Bootstrapped {
+		consensus, err := t.Consensus.Inspect()
+		if err != nil {
+			return nil, err
+		}
+		inspection.Consensus = &consensus
+	}
+	for _, poll := range t.polls.Outstanding() {
+		inspection.Polls = append(inspection.Polls, common.NewPollInspection(poll.RequestID, poll.Start, poll.Waiting))
+	}
+	ids.SortIDs(inspection.Pending)
+	ids.SortIDs(inspection.MissingTxs)
+
+	query := func(blockable events.Blockable) (ids.ShortID, bool) {
+		if c, ok := blockable.(*convincer); ok && !c.abandoned {
+			return c.vdr, true
+		}
+		return ids.ShortID{}, false
+	}
+	inspection.BlockedVertices = common.InspectBlocked(t.vtxBlocked, query)
+	inspection.BlockedTxs = common.InspectBlocked(t.txBlocked, query)
+	return inspection, nil
+}
@@ -3501,3 +3501,76 @@ func TestEngineDoubleChit(t *testing.T) {
 		t.Fatalf("Wrong tx status: %s ; expected: %s", status, choices.Accepted)
 	}
 }
+
+func TestEngineInspect(t *testing.T) {
+	config := DefaultConfig()
+
+	vals := validators.NewSet()
+	config.Validators = vals
+
+	vdr := ids.GenerateTestShortID()
+	if err := vals.AddWeight(vdr, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &common.SenderTest{}
+	sender.T = t
+	config.Sender = sender
+
+	sender.Default(true)
+	sender.CantGetAcceptedFrontier = false
+
+	manager := &vertex.TestManager{T: t}
+	config.Manager = manager
+
+	manager.Default(true)
+
+	manager.CantEdge = false
+
+	te := &Transitive{}
+	if err := te.Initialize(config); err != nil {
+		t.Fatal(err)
+	}
+
+	vtx := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: []avalanche.Vertex{
+			&avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Unknown,
+			}},
+		},
+		BytesV: []byte{1},
+	}
+
+	sender.GetF = func(ids.ShortID, uint32, ids.ID) {}
+	manager.ParseVertexF = func([]byte) (avalanche.Vertex, error) { return vtx, nil }
+	if err := te.Put(vdr, 0, vtx.ID(), vtx.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := te.Inspect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inspection := state.(Inspection)
+	switch {
+	case !inspection.Bootstrapped:
+		t.Fatalf("Should have finished bootstrapping")
+	case inspection.Consensus == nil:
+		t.Fatalf("Should have described consensus")
+	case len(inspection.Consensus.Processing) != 0:
+		t.Fatalf("The vertex shouldn't have been issued")
+	case len(inspection.Pending) != 1 || inspection.Pending[0] != vtx.ID():
+		t.Fatalf("The vertex should be pending")
+	case len(inspection.BlockedVertices) != 1 || inspection.BlockedVertices[0].ID != vtx.ParentsV[0].ID():
+		t.Fatalf("The vertex should be blocked on its parent")
+	case len(inspection.BlockedVertices[0].Queries) != 0:
+		t.Fatalf("No queries should be blocked")
+	case len(inspection.Polls) != 0:
+		t.Fatalf("Shouldn't have any outstanding polls")
+	}
+}
@@ -21,6 +21,11 @@ type Engine interface {
 	// Returns nil if the engine is healthy.
 	// Periodically called and reported through the health API
 	Health() (interface{}, error)
+
+	// Returns a description of the engine's consensus state, such as the
+	// decisions being voted on and the outstanding polls. Reported through
+	// the admin API to debug chains that aren't making progress.
+	Inspect() (interface{}, error)
 }
 
 // Handler defines the functions that are acted on the node
@@ -36,6 +41,7 @@ type ExternalHandler interface {
 	AcceptedHandler
 	FetchHandler
 	QueryHandler
+	AppHandler
 }
 
 // FrontierHandler defines how a consensus engine reacts to frontier messages
@@ -276,6 +282,45 @@ type QueryHandler interface {
 	QueryFailed(validatorID ids.ShortID, requestID uint32) error
 }
 
+// AppHandler defines how a consensus engine, or the VM it is running, reacts
+// to application level messages from other nodes. Functions only return fatal
+// errors if they occur.
+type AppHandler interface {
+	// Notify this engine of an application level request.
+	//
+	// This function can be called by any node. It is not safe to assume this
+	// message is utilizing a unique requestID. However, the nodeID is assumed
+	// to be authenticated.
+	//
+	// The VM may respond by sending an AppResponse message with the same
+	// requestID.
+	AppRequest(nodeID ids.ShortID, requestID uint32, appRequestBytes []byte) error
+
+	// Notify this engine of a response to an AppRequest it issued.
+	//
+	// This function can be called by any node. It is not safe to assume this
+	// message is in response to an AppRequest message. However, the nodeID is
+	// assumed to be authenticated.
+	AppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte) error
+
+	// Notify this engine that an AppRequest it issued has failed.
+	//
+	// This function will be called if the VM sent an AppRequest message that
+	// is not anticipated to be responded to. This could be because the
+	// recipient of the message is unknown or if the message request has timed
+	// out.
+	//
+	// The nodeID and requestID are assumed to be the same as those sent in the
+	// AppRequest message.
+	AppRequestFailed(nodeID ids.ShortID, requestID uint32) error
+
+	// Notify this engine of an application level gossip message.
+	//
+	// This function can be called by any node. The nodeID is assumed to be
+	// authenticated.
+	AppGossip(nodeID ids.ShortID, appGossipBytes []byte) error
+}
+
 // InternalHandler defines how this consensus engine reacts to messages from
 // other components of this validator. Functions only return fatal errors if
 // they occur.
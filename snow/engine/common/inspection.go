@@ -0,0 +1,75 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/events"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// PollInspection describes a poll that hasn't finished
+type PollInspection struct {
+	RequestID uint32    `json:"requestID"`
+	Start     time.Time `json:"start"`
+	// Validators that haven't responded to the poll
+	Waiting []string `json:"waiting"`
+}
+
+// NewPollInspection returns a description of the poll [requestID], which
+// started at [start] and is waiting on [waiting]
+func NewPollInspection(requestID uint32, start time.Time, waiting []ids.ShortID) PollInspection {
+	inspection := PollInspection{
+		RequestID: requestID,
+		Start:     start,
+		Waiting:   make([]string, len(waiting)),
+	}
+	ids.SortShortIDs(waiting)
+	for i, vdr := range waiting {
+		inspection.Waiting[i] = vdr.PrefixedString(constants.NodeIDPrefix)
+	}
+	return inspection
+}
+
+// BlockedInspection describes the operations that are blocked on a container
+// being issued
+type BlockedInspection struct {
+	ID ids.ID `json:"id"`
+	// Number of operations, such as issuing a container or applying votes,
+	// that are blocked
+	Operations int `json:"operations"`
+	// Validators whose queries won't be answered until the container is
+	// issued
+	Queries []string `json:"queries"`
+}
+
+// InspectBlocked returns a description of the operations in [blocker],
+// ordered by the ID they're blocked on. [query] returns the validator whose
+// query is answered by an operation, if the operation answers a query.
+func InspectBlocked(blocker events.Blocker, query func(events.Blockable) (ids.ShortID, bool)) []BlockedInspection {
+	containerIDs := make([]ids.ID, 0, len(blocker))
+	for containerID := range blocker {
+		containerIDs = append(containerIDs, containerID)
+	}
+	ids.SortIDs(containerIDs)
+
+	inspections := make([]BlockedInspection, len(containerIDs))
+	for i, containerID := range containerIDs {
+		blocking := blocker[containerID]
+		inspection := BlockedInspection{
+			ID:         containerID,
+			Operations: len(blocking),
+			Queries:    []string{},
+		}
+		for _, blockable := range blocking {
+			if vdr, ok := query(blockable); ok {
+				inspection.Queries = append(inspection.Queries, vdr.PrefixedString(constants.NodeIDPrefix))
+			}
+		}
+		inspections[i] = inspection
+	}
+	return inspections
+}
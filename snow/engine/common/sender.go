@@ -15,6 +15,7 @@ type Sender interface {
 	FetchSender
 	QuerySender
 	Gossiper
+	AppSender
 }
 
 // FrontierSender defines how a consensus engine sends frontier messages to
@@ -86,3 +87,21 @@ type Gossiper interface {
 	// Gossip gossips the provided container throughout the network
 	Gossip(containerID ids.ID, container []byte)
 }
+
+// AppSender defines how a consensus engine, or the VM it is running, sends
+// application level messages to other nodes
+type AppSender interface {
+	// AppRequest sends an application level request to every node in
+	// [nodeIDs]. Each node is expected to respond with an AppResponse with the
+	// same [requestID]. If a node doesn't respond before the request times
+	// out, AppRequestFailed will be called instead.
+	AppRequest(nodeIDs ids.ShortSet, requestID uint32, appRequestBytes []byte)
+
+	// AppResponse responds to an AppRequest from [nodeID] with the same
+	// [requestID].
+	AppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte)
+
+	// AppGossip gossips the provided application level message throughout the
+	// network
+	AppGossip(appGossipBytes []byte)
+}
@@ -44,10 +44,16 @@ type EngineTest struct {
 	CantQueryFailed,
 	CantChits,
 
+	CantAppRequest,
+	CantAppResponse,
+	CantAppRequestFailed,
+	CantAppGossip,
+
 	CantConnected,
 	CantDisconnected,
 
-	CantHealth bool
+	CantHealth,
+	CantInspect bool
 
 	IsBootstrappedF                                    func() bool
 	ContextF                                           func() *snow.Context
@@ -59,8 +65,11 @@ type EngineTest struct {
 	AcceptedFrontierF, GetAcceptedF, AcceptedF, ChitsF func(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) error
 	GetAcceptedFrontierF, GetFailedF, GetAncestorsFailedF,
 	QueryFailedF, GetAcceptedFrontierFailedF, GetAcceptedFailedF func(validatorID ids.ShortID, requestID uint32) error
+	AppRequestF, AppResponseF func(nodeID ids.ShortID, requestID uint32, appBytes []byte) error
+	AppRequestFailedF         func(nodeID ids.ShortID, requestID uint32) error
+	AppGossipF                func(nodeID ids.ShortID, appGossipBytes []byte) error
 	ConnectedF, DisconnectedF func(validatorID ids.ShortID) error
-	HealthF                   func() (interface{}, error)
+	HealthF, InspectF         func() (interface{}, error)
 }
 
 var _ Engine = &EngineTest{}
@@ -97,10 +106,16 @@ func (e *EngineTest) Default(cant bool) {
 	e.CantQueryFailed = cant
 	e.CantChits = cant
 
+	e.CantAppRequest = cant
+	e.CantAppResponse = cant
+	e.CantAppRequestFailed = cant
+	e.CantAppGossip = cant
+
 	e.CantConnected = cant
 	e.CantDisconnected = cant
 
 	e.CantHealth = cant
+	e.CantInspect = cant
 }
 
 // Context ...
@@ -395,6 +410,62 @@ func (e *EngineTest) Chits(validatorID ids.ShortID, requestID uint32, containerI
 	return errors.New("unexpectedly called Chits")
 }
 
+// AppRequest ...
+func (e *EngineTest) AppRequest(nodeID ids.ShortID, requestID uint32, appRequestBytes []byte) error {
+	if e.AppRequestF != nil {
+		return e.AppRequestF(nodeID, requestID, appRequestBytes)
+	}
+	if !e.CantAppRequest {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppRequest")
+	}
+	return errors.New("unexpectedly called AppRequest")
+}
+
+// AppResponse ...
+func (e *EngineTest) AppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte) error {
+	if e.AppResponseF != nil {
+		return e.AppResponseF(nodeID, requestID, appResponseBytes)
+	}
+	if !e.CantAppResponse {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppResponse")
+	}
+	return errors.New("unexpectedly called AppResponse")
+}
+
+// AppRequestFailed ...
+func (e *EngineTest) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
+	if e.AppRequestFailedF != nil {
+		return e.AppRequestFailedF(nodeID, requestID)
+	}
+	if !e.CantAppRequestFailed {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppRequestFailed")
+	}
+	return errors.New("unexpectedly called AppRequestFailed")
+}
+
+// AppGossip ...
+func (e *EngineTest) AppGossip(nodeID ids.ShortID, appGossipBytes []byte) error {
+	if e.AppGossipF != nil {
+		return e.AppGossipF(nodeID, appGossipBytes)
+	}
+	if !e.CantAppGossip {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppGossip")
+	}
+	return errors.New("unexpectedly called AppGossip")
+}
+
 // Connected ...
 func (e *EngineTest) Connected(validatorID ids.ShortID) error {
 	if e.ConnectedF != nil {
@@ -444,3 +515,14 @@ func (e *EngineTest) Health() (interface{}, error) {
 	}
 	return nil, errors.New("unexpectedly called Health")
 }
+
+// Inspect ...
+func (e *EngineTest) Inspect() (interface{}, error) {
+	if e.InspectF != nil {
+		return e.InspectF()
+	}
+	if e.CantInspect && e.T != nil {
+		e.T.Fatalf("Unexpectedly called Inspect")
+	}
+	return nil, errors.New("unexpectedly called Inspect")
+}
@@ -17,7 +17,8 @@ type SenderTest struct {
 	CantGetAccepted, CantAccepted,
 	CantGet, CantGetAncestors, CantPut, CantMultiPut,
 	CantPullQuery, CantPushQuery, CantChits,
-	CantGossip bool
+	CantGossip,
+	CantAppRequest, CantAppResponse, CantAppGossip bool
 
 	GetAcceptedFrontierF func(ids.ShortSet, uint32)
 	AcceptedFrontierF    func(ids.ShortID, uint32, []ids.ID)
@@ -31,6 +32,9 @@ type SenderTest struct {
 	PullQueryF           func(ids.ShortSet, uint32, ids.ID)
 	ChitsF               func(ids.ShortID, uint32, []ids.ID)
 	GossipF              func(ids.ID, []byte)
+	AppRequestF          func(ids.ShortSet, uint32, []byte)
+	AppResponseF         func(ids.ShortID, uint32, []byte)
+	AppGossipF           func([]byte)
 }
 
 // Default set the default callable value to [cant]
@@ -47,6 +51,9 @@ func (s *SenderTest) Default(cant bool) {
 	s.CantPushQuery = cant
 	s.CantChits = cant
 	s.CantGossip = cant
+	s.CantAppRequest = cant
+	s.CantAppResponse = cant
+	s.CantAppGossip = cant
 }
 
 // GetAcceptedFrontier calls GetAcceptedFrontierF if it was initialized. If it
@@ -180,3 +187,36 @@ func (s *SenderTest) Gossip(containerID ids.ID, container []byte) {
 		s.T.Fatalf("Unexpectedly called Gossip")
 	}
 }
+
+// AppRequest calls AppRequestF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *SenderTest) AppRequest(nodeIDs ids.ShortSet, requestID uint32, appRequestBytes []byte) {
+	if s.AppRequestF != nil {
+		s.AppRequestF(nodeIDs, requestID, appRequestBytes)
+	} else if s.CantAppRequest && s.T != nil {
+		s.T.Fatalf("Unexpectedly called AppRequest")
+	}
+}
+
+// AppResponse calls AppResponseF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) AppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte) {
+	if s.AppResponseF != nil {
+		s.AppResponseF(nodeID, requestID, appResponseBytes)
+	} else if s.CantAppResponse && s.T != nil {
+		s.T.Fatalf("Unexpectedly called AppResponse")
+	}
+}
+
+// AppGossip calls AppGossipF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *SenderTest) AppGossip(appGossipBytes []byte) {
+	if s.AppGossipF != nil {
+		s.AppGossipF(appGossipBytes)
+	} else if s.CantAppGossip && s.T != nil {
+		s.T.Fatalf("Unexpectedly called AppGossip")
+	}
+}
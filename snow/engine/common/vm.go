@@ -82,3 +82,14 @@ type StaticVM interface {
 	// genesis bytes this VM can interpret.
 	CreateStaticHandlers() map[string]*HTTPHandler
 }
+
+// AppVM describes the optional functionality that allows a VM to send and
+// receive application level messages to and from other nodes running the same
+// chain.
+type AppVM interface {
+	AppHandler
+
+	// SetAppSender provides the sender this VM should use to send application
+	// level messages. Called once, before Initialize.
+	SetAppSender(appSender AppSender)
+}
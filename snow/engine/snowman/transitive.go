@@ -663,3 +663,39 @@ func (t *Transitive) Health() (interface{}, error) {
 	// TODO add more health checks
 	return t.VM.Health()
 }
+
+// Inspection describes the state of a snowman engine
+type Inspection struct {
+	Bootstrapped bool `json:"bootstrapped"`
+	// Consensus is omitted while the chain is bootstrapping
+	Consensus *snowman.Inspection     `json:"consensus,omitempty"`
+	Polls     []common.PollInspection `json:"polls"`
+	// Pending are the blocks that are waiting on missing ancestors before they
+	// can be issued into consensus, ordered by ID
+	Pending []ids.ID                   `json:"pending"`
+	Blocked []common.BlockedInspection `json:"blocked"`
+}
+
+// Inspect implements the common.Engine interface
+func (t *Transitive) Inspect() (interface{}, error) {
+	inspection := Inspection{
+		Bootstrapped: t.Ctx.IsBootstrapped(),
+		Polls:        []common.PollInspection{},
+		Pending:      t.pending.List(),
+	}
+	if inspection.Bootstrapped {
+		consensus := t.Consensus.Inspect()
+		inspection.Consensus = &consensus
+	}
+	for _, poll := range t.polls.Outstanding() {
+		inspection.Polls = append(inspection.Polls, common.NewPollInspection(poll.RequestID, poll.Start, poll.Waiting))
+	}
+	ids.SortIDs(inspection.Pending)
+	inspection.Blocked = common.InspectBlocked(t.blocked, func(blockable events.Blockable) (ids.ShortID, bool) {
+		if c, ok := blockable.(*convincer); ok && !c.abandoned {
+			return c.vdr, true
+		}
+		return ids.ShortID{}, false
+	})
+	return inspection, nil
+}
@@ -1866,3 +1866,73 @@ func TestEngineDoubleChit(t *testing.T) {
 		t.Fatalf("Wrong status: %s ; expected: %s", status, choices.Accepted)
 	}
 }
+
+func TestEngineInspect(t *testing.T) {
+	vdr, _, sender, vm, te, gBlk := setup(t)
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk,
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) { return nil, errUnknownBlock }
+	getRequestID := new(uint32)
+	sender.GetF = func(_ ids.ShortID, requestID uint32, _ ids.ID) { *getRequestID = requestID }
+
+	// The query can't be answered until the block is fetched
+	if err := te.PullQuery(vdr, 15, blk.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := te.Inspect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inspection := state.(Inspection)
+	switch {
+	case !inspection.Bootstrapped:
+		t.Fatalf("Should have finished bootstrapping")
+	case inspection.Consensus == nil || inspection.Consensus.LastAccepted != gBlk.ID():
+		t.Fatalf("Should have described consensus")
+	case len(inspection.Polls) != 0:
+		t.Fatalf("Shouldn't have any outstanding polls")
+	case len(inspection.Blocked) != 1 || inspection.Blocked[0].ID != blk.ID():
+		t.Fatalf("The query should be blocked on the block")
+	case len(inspection.Blocked[0].Queries) != 1:
+		t.Fatalf("The query of %s should be blocked", vdr)
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == blk.ID() {
+			return blk, nil
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func([]byte) (snowman.Block, error) { return blk, nil }
+	sender.PushQueryF = func(ids.ShortSet, uint32, ids.ID, []byte) {}
+	sender.ChitsF = func(ids.ShortID, uint32, []ids.ID) {}
+	if err := te.Put(vdr, *getRequestID, blk.ID(), blk.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = te.Inspect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inspection = state.(Inspection)
+	switch {
+	case len(inspection.Blocked) != 0:
+		t.Fatalf("Nothing should be blocked once the block is issued")
+	case len(inspection.Consensus.Processing) != 1 || inspection.Consensus.Processing[0].ID != blk.ID():
+		t.Fatalf("The block should be processing")
+	case len(inspection.Consensus.Preferred) != 1 || inspection.Consensus.Preferred[0] != blk.ID():
+		t.Fatalf("The block should be preferred")
+	case len(inspection.Polls) != 1 || len(inspection.Polls[0].Waiting) != 1:
+		t.Fatalf("The poll for the block should be waiting on %s", vdr)
+	}
+}
@@ -33,6 +33,23 @@ type QueryBenchlist interface {
 	RegisterResponse(validatorID ids.ShortID, requstID uint32)
 	// QueryFailed registers that a query did not receive a response within our synchrony bound
 	QueryFailed(validatorID ids.ShortID, requestID uint32)
+	// IsBenched returns true if [validatorID] is currently benched
+	IsBenched(validatorID ids.ShortID) bool
+	// Benched returns the events that benched the validators that are
+	// currently benched, ordered by the time the benches expire
+	Benched() []Event
+	// Unbench removes [validatorID] from the benchlist. Returns false if
+	// [validatorID] wasn't benched.
+	Unbench(validatorID ids.ShortID) bool
+}
+
+// tracker is notified of the validators that are benched on each chain, so
+// that the stake benched across chains can be limited
+type tracker interface {
+	// canBench returns true if [validatorID] can be benched on [chainID]
+	canBench(chainID ids.ID, validatorID ids.ShortID, now time.Time) bool
+	benched(e Event)
+	unbenched(chainID ids.ID, validatorID ids.ShortID)
 }
 
 type queryBenchlist struct {
@@ -45,9 +62,15 @@ type queryBenchlist struct {
 	consecutiveFailures map[[20]byte]failureStreak
 
 	// Maintain benchlist
-	benchlistTimes map[[20]byte]time.Time
-	benchlistOrder *list.List
-	benchlistSet   ids.ShortSet
+	// Validator ID --> event that benched the validator
+	benchlistEvents map[[20]byte]Event
+	benchlistOrder  *list.List
+	benchlistSet    ids.ShortSet
+
+	// If non-nil, benches are persisted to [store]
+	store *store
+	// If non-nil, [tracker] is notified of benches and may prevent them
+	tracker tracker
 
 	threshold              int
 	minimumFailingDuration time.Duration
@@ -69,6 +92,7 @@ type pendingQuery struct {
 
 type failureStreak struct {
 	firstFailure time.Time
+	lastFailure  time.Time
 	consecutive  int
 }
 
@@ -83,11 +107,33 @@ func NewQueryBenchlist(
 	summaryEnabled bool,
 	namespace string,
 ) (QueryBenchlist, error) {
+	return newQueryBenchlist(
+		validators,
+		ctx,
+		threshold,
+		minimumFailingDuration,
+		duration,
+		maxPortion,
+		summaryEnabled,
+		namespace,
+	)
+}
+
+func newQueryBenchlist(
+	validators validators.Set,
+	ctx *snow.Context,
+	threshold int,
+	minimumFailingDuration,
+	duration time.Duration,
+	maxPortion float64,
+	summaryEnabled bool,
+	namespace string,
+) (*queryBenchlist, error) {
 	metrics := &metrics{}
 	return &queryBenchlist{
 		pendingQueries:         make(map[[20]byte]map[uint32]pendingQuery),
 		consecutiveFailures:    make(map[[20]byte]failureStreak),
-		benchlistTimes:         make(map[[20]byte]time.Time),
+		benchlistEvents:        make(map[[20]byte]Event),
 		benchlistOrder:         list.New(),
 		benchlistSet:           ids.ShortSet{},
 		vdrs:                   validators,
@@ -100,6 +146,27 @@ func NewQueryBenchlist(
 	}, metrics.Initialize(ctx, namespace, summaryEnabled)
 }
 
+// restore the benches in [events], which must be ordered by the time the
+// benches expire. Must be called before any validator is benched.
+func (b *queryBenchlist) restore(events []Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, e := range events {
+		if b.benchlistSet.Contains(e.ValidatorID) {
+			continue
+		}
+		b.benchlistEvents[e.ValidatorID.Key()] = e
+		b.benchlistOrder.PushBack(e.ValidatorID)
+		b.benchlistSet.Add(e.ValidatorID)
+		if b.tracker != nil {
+			b.tracker.benched(e)
+		}
+	}
+	b.ctx.Log.Info("restored %d benched validators", b.benchlistSet.Len())
+	b.cleanup()
+}
+
 // RegisterQuery attempts to register a query from [validatorID] and returns true
 // if that request should be made (not subject to benchlisting)
 func (b *queryBenchlist) RegisterQuery(validatorID ids.ShortID, requestID uint32, msgType constants.MsgType) bool {
@@ -154,31 +221,42 @@ func (b *queryBenchlist) QueryFailed(validatorID ids.ShortID, requestID uint32)
 	if failureStreak.consecutive == 0 {
 		failureStreak.firstFailure = currentTime
 	}
+	failureStreak.lastFailure = currentTime
 	failureStreak.consecutive++
 	b.consecutiveFailures[key] = failureStreak
 
 	if failureStreak.consecutive >= b.threshold && !currentTime.Before(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
-		b.bench(validatorID)
+		b.bench(validatorID, failureStreak)
 	}
 }
 
-func (b *queryBenchlist) bench(validatorID ids.ShortID) {
+func (b *queryBenchlist) bench(validatorID ids.ShortID, failureStreak failureStreak) {
 	if b.benchlistSet.Contains(validatorID) {
 		return
 	}
 
 	key := validatorID.Key()
+	currTime := b.clock.Time()
+	if b.tracker != nil && !b.tracker.canBench(b.ctx.ChainID, validatorID, currTime) {
+		// The failure streak is kept, so the validator is benched after its
+		// next failure if enough stake has been unbenched by then
+		b.ctx.Log.Debug(
+			"not benching validator %s after %d consecutive failed queries as too much stake is benched across chains",
+			validatorID,
+			failureStreak.consecutive,
+		)
+		return
+	}
 
 	// Goal:
 	// Random end time in the range:
 	// [max(lastEndTime, (currentTime + (duration/2)): currentTime + duration]
 	// This maintains the invariant that validators in benchlistOrder are
 	// ordered by the time that they should be unbenched
-	currTime := b.clock.Time()
 	minEndTime := currTime.Add(b.duration / 2)
 	if elem := b.benchlistOrder.Back(); elem != nil {
 		lastValidator := elem.Value.(ids.ShortID)
-		lastEndTime := b.benchlistTimes[lastValidator.Key()]
+		lastEndTime := b.benchlistEvents[lastValidator.Key()].End
 		if lastEndTime.After(minEndTime) {
 			minEndTime = lastEndTime
 		}
@@ -191,16 +269,33 @@ func (b *queryBenchlist) bench(validatorID ids.ShortID) {
 	randomizedEndTime := minEndTime.Add(time.Duration(rand.Float64() * float64(diff))) // #nosec G404
 
 	// Add to benchlist times with randomized delay
-	b.benchlistTimes[key] = randomizedEndTime
+	e := Event{
+		ValidatorID:  validatorID,
+		ChainID:      b.ctx.ChainID,
+		Failures:     failureStreak.consecutive,
+		FirstFailure: failureStreak.firstFailure,
+		LastFailure:  failureStreak.lastFailure,
+		Start:        currTime,
+		End:          randomizedEndTime,
+	}
+	b.benchlistEvents[key] = e
 	b.benchlistOrder.PushBack(validatorID)
 	b.benchlistSet.Add(validatorID)
 	delete(b.consecutiveFailures, key)
 	b.ctx.Log.Debug(
 		"benching validator %s after %d consecutive failed queries for %s",
 		validatorID,
-		b.threshold,
+		failureStreak.consecutive,
 		randomizedEndTime.Sub(currTime),
 	)
+	if b.store != nil {
+		if err := b.store.bench(e); err != nil {
+			b.ctx.Log.Error("failed to persist the bench of %s due to: %s", validatorID, err)
+		}
+	}
+	if b.tracker != nil {
+		b.tracker.benched(e)
+	}
 
 	// Note: there could be a memory leak if a large number of
 	// validators were added, sampled, benched, and never sampled
@@ -209,17 +304,25 @@ func (b *queryBenchlist) bench(validatorID ids.ShortID) {
 	b.cleanup()
 }
 
+// IsBenched returns true if [validatorID] is currently benched
+func (b *queryBenchlist) IsBenched(validatorID ids.ShortID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.benched(validatorID)
+}
+
 // benched checks if [validatorID] is currently benched
 // and calls cleanup if its benching period has elapsed
 func (b *queryBenchlist) benched(validatorID ids.ShortID) bool {
 	key := validatorID.Key()
 
-	end, ok := b.benchlistTimes[key]
+	e, ok := b.benchlistEvents[key]
 	if !ok {
 		return false
 	}
 
-	if b.clock.Time().Before(end) {
+	if b.clock.Time().Before(e.End) {
 		return true
 	}
 
@@ -252,7 +355,7 @@ func (b *queryBenchlist) cleanup() {
 
 		validatorID := e.Value.(ids.ShortID)
 		key := validatorID.Key()
-		end := b.benchlistTimes[key]
+		end := b.benchlistEvents[key].End
 		// Remove elements with the next expiration until the next item has not
 		// expired and the bench has less than the maximum weight
 		// Note: this creates an edge case where benching a validator
@@ -275,8 +378,7 @@ func (b *queryBenchlist) cleanup() {
 
 		b.ctx.Log.Debug("Removed Validator: (%s, %d). EndTime: %s. CurrentTime: %s)", validatorID, removeWeight, end, currentTime)
 		b.benchlistOrder.Remove(e)
-		delete(b.benchlistTimes, key)
-		b.benchlistSet.Remove(validatorID)
+		b.remove(validatorID)
 	}
 
 	updatedBenchLen := b.benchlistSet.Len()
@@ -292,10 +394,66 @@ func (b *queryBenchlist) cleanup() {
 	b.metrics.numBenched.Set(float64(updatedBenchLen))
 }
 
+// Benched returns the events that benched the currently benched validators
+func (b *queryBenchlist) Benched() []Event {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	currentTime := b.clock.Time()
+	events := make([]Event, 0, b.benchlistOrder.Len())
+	for e := b.benchlistOrder.Front(); e != nil; e = e.Next() {
+		validatorID := e.Value.(ids.ShortID)
+		if event := b.benchlistEvents[validatorID.Key()]; currentTime.Before(event.End) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Unbench removes [validatorID] from the benchlist
+func (b *queryBenchlist) Unbench(validatorID ids.ShortID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.benched(validatorID) {
+		return false
+	}
+	for e := b.benchlistOrder.Front(); e != nil; e = e.Next() {
+		if e.Value.(ids.ShortID).Equals(validatorID) {
+			b.benchlistOrder.Remove(e)
+			break
+		}
+	}
+	b.remove(validatorID)
+	delete(b.consecutiveFailures, validatorID.Key())
+	b.ctx.Log.Info("unbenched validator %s", validatorID)
+
+	// Update the metrics
+	b.cleanup()
+	return true
+}
+
+// remove [validatorID] from the benchlist, other than from [benchlistOrder]
+func (b *queryBenchlist) remove(validatorID ids.ShortID) {
+	delete(b.benchlistEvents, validatorID.Key())
+	b.benchlistSet.Remove(validatorID)
+	if b.store != nil {
+		if err := b.store.unbench(b.ctx.ChainID, validatorID); err != nil {
+			b.ctx.Log.Error("failed to persist the unbench of %s due to: %s", validatorID, err)
+		}
+	}
+	if b.tracker != nil {
+		b.tracker.unbenched(b.ctx.ChainID, validatorID)
+	}
+}
+
 func (b *queryBenchlist) reset() {
+	for _, validatorID := range b.benchlistSet.List() {
+		b.remove(validatorID)
+	}
 	b.pendingQueries = make(map[[20]byte]map[uint32]pendingQuery)
 	b.consecutiveFailures = make(map[[20]byte]failureStreak)
-	b.benchlistTimes = make(map[[20]byte]time.Time)
+	b.benchlistEvents = make(map[[20]byte]Event)
 	b.benchlistOrder.Init()
 	b.benchlistSet.Clear()
 	b.metrics.weightBenched.Set(0)
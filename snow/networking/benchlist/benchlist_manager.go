@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -28,6 +29,20 @@ type Manager interface {
 	QueryFailed(ids.ID, ids.ShortID, uint32)
 	// RegisterChain registers a new chain with metrics under [namespac]
 	RegisterChain(*snow.Context, string) error
+	// GetBenched returns the IDs of the chains that [validatorID] is currently
+	// benched on
+	GetBenched(validatorID ids.ShortID) []ids.ID
+	// IsBenched returns true if [validatorID] is currently benched on [chainID]
+	IsBenched(validatorID ids.ShortID, chainID ids.ID) bool
+	// Benched returns the events that benched the validators that are
+	// currently benched on each chain, ordered by the time the benches expire
+	Benched() []Event
+	// Unbench removes [validatorID] from the benchlists of [chainIDs], or of
+	// every chain if no chains are given. Returns the IDs of the chains that
+	// [validatorID] was benched on.
+	Unbench(validatorID ids.ShortID, chainIDs ...ids.ID) []ids.ID
+	// History returns the most recent bench events, newest first
+	History() ([]Event, error)
 }
 
 // Config defines the configuration for a benchlist
@@ -38,22 +53,51 @@ type Config struct {
 	Duration               time.Duration
 	MaxPortion             float64
 	PeerSummaryEnabled     bool
+	// Maximum portion of a subnet's stake that can be benched across all of
+	// the subnet's chains. If non-positive, it isn't limited.
+	MaxTotalPortion float64
+	// If non-nil, benches and the bench history are persisted to [DB] so that
+	// they're kept across restarts
+	DB database.Database
+	// Maximum number of bench events kept in the history
+	MaxHistory int
 }
 
 type benchlistManager struct {
 	config *Config
+	store  *store
 	// Chain ID --> benchlist for that chain
-	chainBenchlists map[ids.ID]QueryBenchlist
+	chainBenchlists map[ids.ID]*queryBenchlist
 
 	lock sync.RWMutex
+
+	// Chain benchlists call into the tracker while holding their own lock, so
+	// the tracker's state is guarded by a separate lock
+	trackerLock sync.Mutex
+	// Chain ID --> ID of the chain's subnet
+	chainSubnets map[ids.ID]ids.ID
+	// Chain ID --> Validator ID --> event that benched the validator
+	chainBenched map[ids.ID]map[[20]byte]Event
 }
 
 // NewManager returns a manager for chain-specific query benchlisting
-func NewManager(config *Config) Manager {
-	return &benchlistManager{
+func NewManager(config *Config) (Manager, error) {
+	bm := &benchlistManager{
 		config:          config,
-		chainBenchlists: make(map[ids.ID]QueryBenchlist),
+		chainBenchlists: make(map[ids.ID]*queryBenchlist),
+		chainSubnets:    make(map[ids.ID]ids.ID),
+		chainBenched:    make(map[ids.ID]map[[20]byte]Event),
+	}
+	if config.DB == nil {
+		return bm, nil
+	}
+
+	store, err := newStore(config.DB, config.MaxHistory)
+	if err != nil {
+		return nil, err
 	}
+	bm.store = store
+	return bm, nil
 }
 
 func (bm *benchlistManager) RegisterChain(ctx *snow.Context, namespace string) error {
@@ -69,7 +113,7 @@ func (bm *benchlistManager) RegisterChain(ctx *snow.Context, namespace string) e
 		return errUnknownValidators
 	}
 
-	benchlist, err := NewQueryBenchlist(
+	benchlist, err := newQueryBenchlist(
 		vdrs,
 		ctx,
 		bm.config.Threshold,
@@ -83,6 +127,21 @@ func (bm *benchlistManager) RegisterChain(ctx *snow.Context, namespace string) e
 		return err
 	}
 
+	bm.trackerLock.Lock()
+	bm.chainSubnets[ctx.ChainID] = ctx.SubnetID
+	bm.chainBenched[ctx.ChainID] = make(map[[20]byte]Event)
+	bm.trackerLock.Unlock()
+
+	benchlist.tracker = bm
+	if bm.store != nil {
+		benchlist.store = bm.store
+		events, err := bm.store.benched(ctx.ChainID, benchlist.clock.Time(), ctx.Log)
+		if err != nil {
+			return err
+		}
+		benchlist.restore(events)
+	}
+
 	bm.chainBenchlists[ctx.ChainID] = benchlist
 	return nil
 }
@@ -131,6 +190,131 @@ func (bm *benchlistManager) QueryFailed(chainID ids.ID, validatorID ids.ShortID,
 	chain.QueryFailed(validatorID, requestID)
 }
 
+// GetBenched implements the Manager interface
+func (bm *benchlistManager) GetBenched(validatorID ids.ShortID) []ids.ID {
+	bm.lock.RLock()
+	defer bm.lock.RUnlock()
+
+	benched := []ids.ID{}
+	for chainID, chain := range bm.chainBenchlists {
+		if chain.IsBenched(validatorID) {
+			benched = append(benched, chainID)
+		}
+	}
+	return benched
+}
+
+// IsBenched implements the Manager interface
+func (bm *benchlistManager) IsBenched(validatorID ids.ShortID, chainID ids.ID) bool {
+	bm.lock.RLock()
+	defer bm.lock.RUnlock()
+
+	chain, exists := bm.chainBenchlists[chainID]
+	if !exists {
+		return false
+	}
+	return chain.IsBenched(validatorID)
+}
+
+// Benched implements the Manager interface
+func (bm *benchlistManager) Benched() []Event {
+	bm.lock.RLock()
+	defer bm.lock.RUnlock()
+
+	events := []Event{}
+	for _, chain := range bm.chainBenchlists {
+		events = append(events, chain.Benched()...)
+	}
+	sortEventsByEnd(events)
+	return events
+}
+
+// Unbench implements the Manager interface
+func (bm *benchlistManager) Unbench(validatorID ids.ShortID, chainIDs ...ids.ID) []ids.ID {
+	bm.lock.RLock()
+	defer bm.lock.RUnlock()
+
+	if len(chainIDs) == 0 {
+		for chainID := range bm.chainBenchlists {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+
+	unbenched := []ids.ID{}
+	for _, chainID := range chainIDs {
+		chain, exists := bm.chainBenchlists[chainID]
+		if exists && chain.Unbench(validatorID) {
+			unbenched = append(unbenched, chainID)
+		}
+	}
+	return unbenched
+}
+
+// History implements the Manager interface
+func (bm *benchlistManager) History() ([]Event, error) {
+	if bm.store == nil {
+		return []Event{}, nil
+	}
+	return bm.store.history()
+}
+
+// canBench implements the tracker interface. Returns false if benching
+// [validatorID] would bench more than the maximum portion of the stake of
+// [chainID]'s subnet across the subnet's chains.
+func (bm *benchlistManager) canBench(chainID ids.ID, validatorID ids.ShortID, now time.Time) bool {
+	if bm.config.MaxTotalPortion <= 0 {
+		return true
+	}
+
+	bm.trackerLock.Lock()
+	defer bm.trackerLock.Unlock()
+
+	subnetID := bm.chainSubnets[chainID]
+	vdrs, ok := bm.config.Validators.GetValidators(subnetID)
+	if !ok {
+		return true
+	}
+
+	benched := ids.ShortSet{}
+	benched.Add(validatorID)
+	for otherChainID, events := range bm.chainBenched {
+		if bm.chainSubnets[otherChainID] != subnetID {
+			continue
+		}
+		for _, e := range events {
+			if now.Before(e.End) {
+				benched.Add(e.ValidatorID)
+			}
+		}
+	}
+
+	benchedWeight, err := vdrs.SubsetWeight(benched)
+	if err != nil {
+		return false
+	}
+	return float64(benchedWeight) <= float64(vdrs.Weight())*bm.config.MaxTotalPortion
+}
+
+// benched implements the tracker interface
+func (bm *benchlistManager) benched(e Event) {
+	bm.trackerLock.Lock()
+	defer bm.trackerLock.Unlock()
+
+	if events, ok := bm.chainBenched[e.ChainID]; ok {
+		events[e.ValidatorID.Key()] = e
+	}
+}
+
+// unbenched implements the tracker interface
+func (bm *benchlistManager) unbenched(chainID ids.ID, validatorID ids.ShortID) {
+	bm.trackerLock.Lock()
+	defer bm.trackerLock.Unlock()
+
+	if events, ok := bm.chainBenched[chainID]; ok {
+		delete(events, validatorID.Key())
+	}
+}
+
 type noBenchlist struct{}
 
 // NewNoBenchlist returns an empty benchlist that will never stop any queries
@@ -140,3 +324,8 @@ func (noBenchlist) RegisterChain(*snow.Context, string) error
 func (noBenchlist) RegisterQuery(ids.ID, ids.ShortID, uint32, constants.MsgType) bool { return true }
 func (noBenchlist) RegisterResponse(ids.ID, ids.ShortID, uint32)                      {}
 func (noBenchlist) QueryFailed(ids.ID, ids.ShortID, uint32)                           {}
+func (noBenchlist) GetBenched(ids.ShortID) []ids.ID                                   { return nil }
+func (noBenchlist) IsBenched(ids.ShortID, ids.ID) bool                                { return false }
+func (noBenchlist) Benched() []Event                                                  { return nil }
+func (noBenchlist) Unbench(ids.ShortID, ...ids.ID) []ids.ID                           { return nil }
+func (noBenchlist) History() ([]Event, error)                                         { return nil, nil }
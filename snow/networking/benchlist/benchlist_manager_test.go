@@ -0,0 +1,146 @@
+package benchlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+func newTestManager(t *testing.T, config *Config) *benchlistManager {
+	bm, err := NewManager(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bm.(*benchlistManager)
+}
+
+// registerTestChain registers a chain of [subnetID] and returns its benchlist
+func registerTestChain(t *testing.T, bm *benchlistManager, subnetID, chainID ids.ID) *queryBenchlist {
+	ctx := snow.DefaultContextTest()
+	ctx.SubnetID = subnetID
+	ctx.ChainID = chainID
+	if err := bm.RegisterChain(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	return bm.chainBenchlists[chainID]
+}
+
+func newTestValidators(t *testing.T, subnetID ids.ID, count int) (validators.Manager, []ids.ShortID) {
+	vdrs := validators.NewManager()
+	vdrIDs := make([]ids.ShortID, count)
+	for i := range vdrIDs {
+		vdrIDs[i] = ids.GenerateTestShortID()
+		if err := vdrs.AddWeight(subnetID, vdrIDs[i], 50); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return vdrs, vdrIDs
+}
+
+func TestManagerRestoresBenchlist(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	vdrs, vdrIDs := newTestValidators(t, subnetID, 5)
+	config := &Config{
+		Validators:             vdrs,
+		Threshold:              3,
+		MinimumFailingDuration: minimumFailingDuration,
+		Duration:               time.Hour,
+		MaxPortion:             0.5,
+		DB:                     memdb.New(),
+		MaxHistory:             10,
+	}
+
+	bm := newTestManager(t, config)
+	b := registerTestChain(t, bm, subnetID, chainID)
+	b.clock.Set(time.Now())
+	if ok := bench(b, vdrIDs[:2]); !ok {
+		t.Fatal("RegisterQuery failed early")
+	}
+	if unbenched := bm.Unbench(vdrIDs[1]); len(unbenched) != 1 || unbenched[0] != chainID {
+		t.Fatal("expected vdr1 to have been unbenched on the chain")
+	}
+
+	// After a restart, the validators that were benched are still benched
+	bm = newTestManager(t, config)
+	registerTestChain(t, bm, subnetID, chainID)
+	if !bm.IsBenched(vdrIDs[0], chainID) {
+		t.Fatal("vdr0 should still be benched after the restart")
+	}
+	if bm.IsBenched(vdrIDs[1], chainID) {
+		t.Fatal("vdr1 shouldn't be benched after the restart as it was unbenched")
+	}
+	benched := bm.Benched()
+	if len(benched) != 1 || !benched[0].ValidatorID.Equals(vdrIDs[0]) {
+		t.Fatal("expected only vdr0 to be benched")
+	}
+
+	// Both benches are kept in the history, even though vdr1 was unbenched
+	history, err := bm.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 events in the history but got %d", len(history))
+	}
+}
+
+func TestManagerMaxTotalPortion(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	vdrs, vdrIDs := newTestValidators(t, subnetID, 4)
+	bm := newTestManager(t, &Config{
+		Validators:             vdrs,
+		Threshold:              3,
+		MinimumFailingDuration: minimumFailingDuration,
+		Duration:               time.Hour,
+		MaxPortion:             0.5,
+		MaxTotalPortion:        0.3,
+	})
+
+	b0 := registerTestChain(t, bm, subnetID, chainID0)
+	b1 := registerTestChain(t, bm, subnetID, chainID1)
+	now := time.Now()
+	b0.clock.Set(now)
+	b1.clock.Set(now)
+
+	if ok := bench(b0, vdrIDs[:1]); !ok {
+		t.Fatal("RegisterQuery failed early")
+	}
+	if !bm.IsBenched(vdrIDs[0], chainID0) {
+		t.Fatal("vdr0 should have been benched on chain0")
+	}
+
+	// Benching vdr1 on chain1 would bench half of the subnet's stake across
+	// the subnet's chains
+	if ok := bench(b1, vdrIDs[1:2]); !ok {
+		t.Fatal("RegisterQuery failed early")
+	}
+	if bm.IsBenched(vdrIDs[1], chainID1) {
+		t.Fatal("vdr1 shouldn't have been benched as too much stake would be benched")
+	}
+
+	// vdr0 is already benched on chain0, so it can be benched on chain1
+	if ok := bench(b1, vdrIDs[:1]); !ok {
+		t.Fatal("RegisterQuery failed early")
+	}
+	if !bm.IsBenched(vdrIDs[0], chainID1) {
+		t.Fatal("vdr0 should have been benched on chain1")
+	}
+
+	// Once vdr0 is unbenched everywhere, vdr1 can be benched
+	if unbenched := bm.Unbench(vdrIDs[0]); len(unbenched) != 2 {
+		t.Fatalf("expected vdr0 to have been unbenched on 2 chains but was on %d", len(unbenched))
+	}
+	if ok := failMessage(b1, vdrIDs[1]); !ok {
+		t.Fatal("RegisterQuery failed early")
+	}
+	if !bm.IsBenched(vdrIDs[1], chainID1) {
+		t.Fatal("vdr1 should have been benched on chain1")
+	}
+}
@@ -68,6 +68,12 @@ func TestBenchlist(t *testing.T) {
 		t.Fatal("RegisterQuery failed early")
 	}
 
+	if !b.IsBenched(vdr0.ID()) {
+		t.Fatal("vdr0 should have been reported as benched")
+	}
+	if b.IsBenched(vdr1.ID()) {
+		t.Fatal("vdr1 shouldn't have been reported as benched")
+	}
 	if ok := b.RegisterQuery(vdr0.ID(), requestID, constants.PullQueryMsg); ok {
 		t.Fatal("RegisterQuery should have benchlisted query from unresponsive peer: vdr0")
 	}
@@ -80,6 +86,9 @@ func TestBenchlist(t *testing.T) {
 	}
 
 	b.clock.Set(b.clock.Time().Add(duration))
+	if b.IsBenched(vdr0.ID()) {
+		t.Fatal("vdr0 shouldn't be reported as benched after benchlisting time elapsed")
+	}
 	if ok := b.RegisterQuery(vdr0.ID(), requestID, constants.PullQueryMsg); !ok {
 		t.Fatal("RegisterQuery should have succeeded after benchlisting time elapsed for vdr0")
 	}
@@ -234,6 +243,81 @@ func TestBenchlistOverUnbench(t *testing.T) {
 	}
 }
 
+func TestBenchlistUnbench(t *testing.T) {
+	vdrs := validators.NewSet()
+	vdr0 := validators.GenerateRandomValidator(50)
+	vdr1 := validators.GenerateRandomValidator(50)
+	vdr2 := validators.GenerateRandomValidator(50)
+	vdr3 := validators.GenerateRandomValidator(50)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		vdrs.AddWeight(vdr0.ID(), vdr0.Weight()),
+		vdrs.AddWeight(vdr1.ID(), vdr1.Weight()),
+		vdrs.AddWeight(vdr2.ID(), vdr2.Weight()),
+		vdrs.AddWeight(vdr3.ID(), vdr3.Weight()),
+	)
+	if errs.Errored() {
+		t.Fatal(errs.Err)
+	}
+
+	threshold := 3
+	duration := time.Minute
+	maxPortion := 0.5
+	benchIntf, err := NewQueryBenchlist(
+		vdrs,
+		snow.DefaultContextTest(),
+		threshold,
+		minimumFailingDuration,
+		duration,
+		maxPortion,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := benchIntf.(*queryBenchlist)
+
+	currentTime := time.Now()
+	b.clock.Set(currentTime)
+
+	if ok := bench(b, []ids.ShortID{vdr0.ID()}); !ok {
+		t.Fatal("RegisterQuery failed early")
+	}
+
+	benched := b.Benched()
+	if len(benched) != 1 {
+		t.Fatalf("expected 1 benched validator but got %d", len(benched))
+	}
+	if e := benched[0]; !e.ValidatorID.Equals(vdr0.ID()) || e.Failures != threshold || !e.FirstFailure.Equal(currentTime) {
+		t.Fatal("expected the event to describe the failures that benched vdr0")
+	}
+
+	if b.Unbench(vdr1.ID()) {
+		t.Fatal("vdr1 shouldn't have been reported as unbenched as it wasn't benched")
+	}
+	if !b.Unbench(vdr0.ID()) {
+		t.Fatal("vdr0 should have been reported as unbenched")
+	}
+	if b.IsBenched(vdr0.ID()) {
+		t.Fatal("vdr0 shouldn't be benched after being unbenched")
+	}
+	if len(b.Benched()) != 0 {
+		t.Fatal("expected no benched validators after unbenching vdr0")
+	}
+
+	// The failures that benched vdr0 were cleared, so a single failure
+	// doesn't bench it again
+	b.clock.Set(b.clock.Time().Add(minimumFailingDuration + time.Second))
+	if ok := failMessage(b, vdr0.ID()); !ok {
+		t.Fatal("RegisterQuery should have succeeded after vdr0 was unbenched")
+	}
+	if b.IsBenched(vdr0.ID()) {
+		t.Fatal("vdr0 shouldn't be benched after failing once")
+	}
+}
+
 // failMessage registers a query and failure for [validatorID]
 // returns false if the message cannot be regisered in the first place
 func failMessage(benchlist *queryBenchlist, validatorID ids.ShortID) bool {
@@ -0,0 +1,267 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Prefixes of the keys that the benchlist is persisted under
+const (
+	benchPrefix byte = iota
+	historyPrefix
+)
+
+const (
+	eventLen      = hashing.AddrLen + hashing.HashLen + wrappers.IntLen + 4*wrappers.LongLen
+	historyKeyLen = 1 + wrappers.LongLen
+)
+
+var errInvalidHistoryKey = errors.New("invalid history key")
+
+// Event records that a validator was benched on a chain
+type Event struct {
+	ValidatorID ids.ShortID
+	ChainID     ids.ID
+	// Number of consecutive queries to the validator that failed
+	Failures int
+	// Times of the first and the last of the failed queries
+	FirstFailure, LastFailure time.Time
+	// Times the validator was benched and the bench expires
+	Start, End time.Time
+}
+
+func (e *Event) bytes() []byte {
+	p := wrappers.Packer{Bytes: make([]byte, eventLen)}
+	p.PackFixedBytes(e.ValidatorID.Bytes())
+	p.PackFixedBytes(e.ChainID[:])
+	p.PackInt(uint32(e.Failures))
+	p.PackLong(uint64(e.FirstFailure.Unix()))
+	p.PackLong(uint64(e.LastFailure.Unix()))
+	p.PackLong(uint64(e.Start.Unix()))
+	p.PackLong(uint64(e.End.Unix()))
+	return p.Bytes
+}
+
+func parseEvent(b []byte) (Event, error) {
+	p := wrappers.Packer{Bytes: b}
+	validatorID, _ := ids.ToShortID(p.UnpackFixedBytes(hashing.AddrLen))
+	chainID, _ := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
+	e := Event{
+		ValidatorID:  validatorID,
+		ChainID:      chainID,
+		Failures:     int(p.UnpackInt()),
+		FirstFailure: time.Unix(int64(p.UnpackLong()), 0),
+		LastFailure:  time.Unix(int64(p.UnpackLong()), 0),
+		Start:        time.Unix(int64(p.UnpackLong()), 0),
+		End:          time.Unix(int64(p.UnpackLong()), 0),
+	}
+	return e, p.Err
+}
+
+// store persists the validators that are benched on each chain, and a
+// bounded history of bench events, so that they're kept across restarts
+type store struct {
+	lock sync.Mutex
+	db   database.Database
+
+	// Maximum number of events kept in the history
+	maxHistory int
+	// Sequence numbers of the oldest event in the history and of the next
+	// event to be added. The history is empty if they're equal.
+	first, next uint64
+}
+
+// newStore returns a store that persists to [db] and keeps up to
+// [maxHistory] events in its history
+func newStore(db database.Database, maxHistory int) (*store, error) {
+	s := &store{
+		db:         db,
+		maxHistory: maxHistory,
+	}
+
+	prefix := []byte{historyPrefix}
+	it := db.NewIteratorWithPrefix(prefix)
+	if it.Next() {
+		seq, err := parseHistoryKey(it.Key())
+		if err != nil {
+			it.Release()
+			return nil, err
+		}
+		s.first = seq
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	it = db.NewReverseIteratorWithPrefix(prefix)
+	if it.Next() {
+		seq, err := parseHistoryKey(it.Key())
+		if err != nil {
+			it.Release()
+			return nil, err
+		}
+		s.next = seq + 1
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	// The history is trimmed in case [maxHistory] was lowered
+	batch := db.NewBatch()
+	first, err := s.trim(batch, s.next)
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	s.first = first
+	return s, nil
+}
+
+// benched returns the unexpired benches on [chainID], ordered by the time
+// they expire. Expired benches, and benches that can't be parsed, are removed.
+// Benches that can't be parsed are logged to [log].
+func (s *store) benched(chainID ids.ID, now time.Time, log logging.Logger) ([]Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	events := []Event(nil)
+	batch := s.db.NewBatch()
+
+	it := s.db.NewIteratorWithPrefix(benchPrefixOf(chainID))
+	defer it.Release()
+
+	for it.Next() {
+		e, err := parseEvent(it.Value())
+		if err != nil {
+			log.Warn("removing the bench at key %x that couldn't be parsed due to: %s", it.Key(), err)
+		}
+		if err != nil || !now.Before(e.End) {
+			if err := batch.Delete(it.Key()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		events = append(events, e)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	sortEventsByEnd(events)
+	return events, nil
+}
+
+// bench persists that [e.ValidatorID] is benched on [e.ChainID] and adds [e]
+// to the history. Both are written in one batch, so the bench and its event
+// are persisted together.
+func (s *store) bench(e Event) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b := e.bytes()
+	batch := s.db.NewBatch()
+	if err := batch.Put(benchKey(e.ChainID, e.ValidatorID), b); err != nil {
+		return err
+	}
+	if err := batch.Put(historyKey(s.next), b); err != nil {
+		return err
+	}
+	first, err := s.trim(batch, s.next+1)
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	s.first = first
+	s.next++
+	return nil
+}
+
+// unbench removes the bench of [validatorID] on [chainID]
+func (s *store) unbench(chainID ids.ID, validatorID ids.ShortID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(benchKey(chainID, validatorID))
+}
+
+// history returns the events in the history, newest first. Returns an error
+// if an event can't be parsed.
+func (s *store) history() ([]Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	events := make([]Event, 0, s.next-s.first)
+	it := s.db.NewReverseIteratorWithPrefix([]byte{historyPrefix})
+	defer it.Release()
+
+	for it.Next() {
+		e, err := parseEvent(it.Value())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse the event at key %x: %w", it.Key(), err)
+		}
+		events = append(events, e)
+	}
+	return events, it.Error()
+}
+
+// trim deletes the oldest events with [w] until the history, whose next event
+// is [next], fits in [maxHistory]. Returns the sequence number of the oldest
+// event that's kept. Assumes the lock is held.
+func (s *store) trim(w database.KeyValueWriter, next uint64) (uint64, error) {
+	first := s.first
+	for next-first > uint64(s.maxHistory) {
+		if err := w.Delete(historyKey(first)); err != nil {
+			return 0, err
+		}
+		first++
+	}
+	return first, nil
+}
+
+// sortEventsByEnd orders [events] by the time their benches expire
+func sortEventsByEnd(events []Event) {
+	sort.Slice(events, func(i, j int) bool { return events[i].End.Before(events[j].End) })
+}
+
+func benchPrefixOf(chainID ids.ID) []byte {
+	return append([]byte{benchPrefix}, chainID[:]...)
+}
+
+func benchKey(chainID ids.ID, validatorID ids.ShortID) []byte {
+	return append(benchPrefixOf(chainID), validatorID.Bytes()...)
+}
+
+func historyKey(seq uint64) []byte {
+	key := make([]byte, historyKeyLen)
+	key[0] = historyPrefix
+	binary.BigEndian.PutUint64(key[1:], seq)
+	return key
+}
+
+func parseHistoryKey(key []byte) (uint64, error) {
+	if len(key) != historyKeyLen {
+		return 0, fmt.Errorf("%w: %x", errInvalidHistoryKey, key)
+	}
+	return binary.BigEndian.Uint64(key[1:]), nil
+}
@@ -0,0 +1,207 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func testEvent(chainID ids.ID, start time.Time, duration time.Duration) Event {
+	return Event{
+		ValidatorID:  ids.GenerateTestShortID(),
+		ChainID:      chainID,
+		Failures:     3,
+		FirstFailure: start.Add(-time.Minute),
+		LastFailure:  start,
+		Start:        start,
+		End:          start.Add(duration),
+	}
+}
+
+func eventsEqual(a, b Event) bool {
+	return a.ValidatorID.Equals(b.ValidatorID) &&
+		a.ChainID == b.ChainID &&
+		a.Failures == b.Failures &&
+		a.FirstFailure.Equal(b.FirstFailure) &&
+		a.LastFailure.Equal(b.LastFailure) &&
+		a.Start.Equal(b.Start) &&
+		a.End.Equal(b.End)
+}
+
+func TestStoreBenched(t *testing.T) {
+	db := memdb.New()
+	s, err := newStore(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := ids.GenerateTestID()
+	now := time.Unix(time.Now().Unix(), 0)
+	e0 := testEvent(chainID, now, 2*time.Minute)
+	e1 := testEvent(chainID, now, time.Minute)
+	e2 := testEvent(chainID, now, 3*time.Minute)
+	other := testEvent(ids.GenerateTestID(), now, time.Minute)
+	for _, e := range []Event{e0, e1, e2, other} {
+		if err := s.bench(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.unbench(chainID, e2.ValidatorID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The benches are kept after the store is reopened
+	s, err = newStore(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	benched, err := s.benched(chainID, now, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(benched) != 2 {
+		t.Fatalf("expected 2 benched validators but got %d", len(benched))
+	}
+	if !eventsEqual(benched[0], e1) || !eventsEqual(benched[1], e0) {
+		t.Fatal("expected the benches to be ordered by the time they expire")
+	}
+
+	// Expired benches are removed
+	benched, err = s.benched(chainID, now.Add(time.Minute), logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(benched) != 1 || !eventsEqual(benched[0], e0) {
+		t.Fatal("expected only the unexpired bench to be returned")
+	}
+	benched, err = s.benched(chainID, now, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(benched) != 1 {
+		t.Fatal("expected the expired bench to have been removed")
+	}
+}
+
+func TestStoreHistory(t *testing.T) {
+	db := memdb.New()
+	s, err := newStore(db, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := ids.GenerateTestID()
+	now := time.Unix(time.Now().Unix(), 0)
+	events := []Event(nil)
+	for i := 0; i < 5; i++ {
+		e := testEvent(chainID, now.Add(time.Duration(i)*time.Second), time.Minute)
+		if err := s.bench(e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+
+	history, err := s.history()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected the history to be bounded to 3 events but got %d", len(history))
+	}
+	for i, e := range history {
+		if !eventsEqual(e, events[4-i]) {
+			t.Fatalf("expected event %d of the history to be the newest events in reverse order", i)
+		}
+	}
+
+	// Reopening the store with a smaller bound drops the oldest events, and
+	// new events are added after the persisted ones
+	s, err = newStore(db, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := testEvent(chainID, now.Add(time.Hour), time.Minute)
+	if err := s.bench(e); err != nil {
+		t.Fatal(err)
+	}
+	history, err = s.history()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || !eventsEqual(history[0], e) || !eventsEqual(history[1], events[4]) {
+		t.Fatal("expected the history to hold the two newest events")
+	}
+}
+
+func TestStoreCorruptRecords(t *testing.T) {
+	db := memdb.New()
+	s, err := newStore(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := ids.GenerateTestID()
+	now := time.Unix(time.Now().Unix(), 0)
+	e := testEvent(chainID, now, time.Minute)
+	if err := s.bench(e); err != nil {
+		t.Fatal(err)
+	}
+	corruptKey := benchKey(chainID, ids.GenerateTestShortID())
+	if err := db.Put(corruptKey, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Benches that can't be parsed are removed
+	benched, err := s.benched(chainID, now, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(benched) != 1 || !eventsEqual(benched[0], e) {
+		t.Fatal("expected only the valid bench to be returned")
+	}
+	if has, err := db.Has(corruptKey); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("expected the bench that couldn't be parsed to be removed")
+	}
+
+	// Events that can't be parsed are reported
+	if err := db.Put(historyKey(0), []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.history(); err == nil {
+		t.Fatal("expected an error for the event that couldn't be parsed")
+	}
+
+	// History keys that can't be parsed are reported when the store is opened
+	if err := db.Put([]byte{historyPrefix}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newStore(db, 10); err == nil {
+		t.Fatal("expected an error for the history key that couldn't be parsed")
+	}
+}
+
+func TestStoreBenchFailure(t *testing.T) {
+	db := memdb.New()
+	s, err := newStore(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.bench(testEvent(ids.GenerateTestID(), time.Now(), time.Minute)); err == nil {
+		t.Fatal("expected writing to a closed database to fail")
+	}
+	if s.next != 0 || s.first != 0 {
+		t.Fatal("expected the history to be unchanged after a failed write")
+	}
+}
@@ -0,0 +1,109 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faults
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	errInvalidProbability = errors.New("probability must be in [0, 1]")
+	errNoLateReplyDelay   = errors.New("late replies require a positive lateReplyDelay")
+)
+
+// Config describes the faults that are injected into the messages that a node
+// sends. Each fault is injected into a message with its probability, so a
+// zero Config injects no faults.
+type Config struct {
+	// Seed of the source of randomness that decides which messages are faulty
+	Seed int64 `json:"seed"`
+	// ChainIDs are the chains whose messages are faulty. If empty, faults are
+	// injected into the messages of every chain.
+	ChainIDs []ids.ID `json:"chainIDs"`
+
+	// RandomChits is the probability that a Chits message votes for random
+	// IDs instead of the node's preferences
+	RandomChits float64 `json:"randomChits"`
+	// WithholdPut is the probability that a Put or MultiPut message isn't
+	// sent
+	WithholdPut float64 `json:"withholdPut"`
+	// LateReply is the probability that a response is sent [LateReplyDelay]
+	// after it was meant to be sent, which should be after the requester's
+	// deadline
+	LateReply      float64       `json:"lateReply"`
+	LateReplyDelay time.Duration `json:"-"`
+	// Equivocate is the probability that a PushQuery sent to more than one
+	// validator sends half of them the container of the previous PushQuery
+	// instead
+	Equivocate float64 `json:"equivocate"`
+}
+
+// Load the config from the JSON file at [path]. Durations are formatted as
+// strings, such as "10s".
+func Load(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		Config
+		LateReplyDelay string `json:"lateReplyDelay"`
+	}{}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("couldn't parse fault injection config %s: %w", path, err)
+	}
+	if config.LateReplyDelay != "" {
+		config.Config.LateReplyDelay, err = time.ParseDuration(config.LateReplyDelay)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse lateReplyDelay: %w", err)
+		}
+	}
+	if err := config.Config.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid fault injection config %s: %w", path, err)
+	}
+	return &config.Config, nil
+}
+
+// Valid returns nil if the config can be used to inject faults
+func (c *Config) Valid() error {
+	probabilities := []struct {
+		name        string
+		probability float64
+	}{
+		{name: "randomChits", probability: c.RandomChits},
+		{name: "withholdPut", probability: c.WithholdPut},
+		{name: "lateReply", probability: c.LateReply},
+		{name: "equivocate", probability: c.Equivocate},
+	}
+	for _, p := range probabilities {
+		if p.probability < 0 || p.probability > 1 {
+			return fmt.Errorf("%w: %s is %f", errInvalidProbability, p.name, p.probability)
+		}
+	}
+	if c.LateReply > 0 && c.LateReplyDelay <= 0 {
+		return errNoLateReplyDelay
+	}
+	return nil
+}
+
+// Applies returns true if faults are injected into the messages of the chain
+// [chainID]
+func (c *Config) Applies(chainID ids.ID) bool {
+	if len(c.ChainIDs) == 0 {
+		return true
+	}
+	for _, faultyChainID := range c.ChainIDs {
+		if faultyChainID == chainID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faults
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestLoad(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	path := filepath.Join(t.TempDir(), "faults.json")
+	config := `{
+		"seed": 5,
+		"chainIDs": ["` + chainID.String() + `"],
+		"randomChits": 0.5,
+		"withholdPut": 0.25,
+		"lateReply": 0.1,
+		"lateReplyDelay": "15s",
+		"equivocate": 1
+	}`
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case c.Seed != 5:
+		t.Fatalf("wrong seed %d", c.Seed)
+	case c.RandomChits != 0.5 || c.WithholdPut != 0.25 || c.LateReply != 0.1 || c.Equivocate != 1:
+		t.Fatalf("wrong probabilities %+v", c)
+	case c.LateReplyDelay != 15*time.Second:
+		t.Fatalf("wrong late reply delay %s", c.LateReplyDelay)
+	case !c.Applies(chainID):
+		t.Fatalf("config should apply to %s", chainID)
+	case c.Applies(ids.GenerateTestID()):
+		t.Fatalf("config shouldn't apply to other chains")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":           `{`,
+		"invalid probability": `{"randomChits": 1.5}`,
+		"invalid delay":       `{"lateReply": 0.5, "lateReplyDelay": "soon"}`,
+		"no delay":            `{"lateReply": 0.5}`,
+	}
+	for name, config := range tests {
+		path := filepath.Join(t.TempDir(), "faults.json")
+		if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Fatalf("%s config should have failed to load", name)
+		}
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatalf("missing config should have failed to load")
+	}
+}
+
+func TestConfigValid(t *testing.T) {
+	c := Config{}
+	if err := c.Valid(); err != nil {
+		t.Fatalf("zero config should be valid: %s", err)
+	}
+	if !c.Applies(ids.GenerateTestID()) {
+		t.Fatalf("config without chain IDs should apply to every chain")
+	}
+
+	c.Equivocate = -0.1
+	if err := c.Valid(); !errors.Is(err, errInvalidProbability) {
+		t.Fatalf("expected %s but got %v", errInvalidProbability, err)
+	}
+
+	c = Config{LateReply: 0.5}
+	if err := c.Valid(); err != errNoLateReplyDelay {
+		t.Fatalf("expected %s but got %v", errNoLateReplyDelay, err)
+	}
+}
@@ -0,0 +1,196 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package faults injects Byzantine behavior into the consensus messages that
+// a node sends, so that local test networks can exercise how honest nodes
+// handle misbehaving peers. It must never be enabled on a production node.
+package faults
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Names of the faults, which label the injected faults metric
+const (
+	RandomChitsFault  = "random_chits"
+	WithheldPutFault  = "withheld_put"
+	LateReplyFault    = "late_reply"
+	EquivocationFault = "equivocation"
+)
+
+var _ sender.ExternalSender = &Sender{}
+
+// Sender is an ExternalSender that injects faults into the messages it sends
+// on behalf of a chain. Messages that the chain sends to itself don't pass
+// through the ExternalSender, so faults are never injected into them.
+type Sender struct {
+	sender.ExternalSender
+
+	config Config
+	log    logging.Logger
+
+	lock sync.Mutex
+	rng  *rand.Rand
+	// The container of the most recent PushQuery
+	lastContainerID ids.ID
+	lastContainer   []byte
+
+	// Number of times each fault was injected
+	counts   map[string]uint64
+	injected *prometheus.CounterVec
+}
+
+// New returns a sender that sends messages over [externalSender] after
+// injecting the faults described by [config]
+func New(
+	config Config,
+	externalSender sender.ExternalSender,
+	log logging.Logger,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*Sender, error) {
+	if err := config.Valid(); err != nil {
+		return nil, err
+	}
+	s := &Sender{
+		ExternalSender: externalSender,
+		config:         config,
+		log:            log,
+		rng:            rand.New(rand.NewSource(config.Seed)), // #nosec G404
+		counts:         make(map[string]uint64),
+		injected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "injected_faults",
+			Help:      "Number of faults injected into sent messages",
+		}, []string{"fault"}),
+	}
+	return s, registerer.Register(s.injected)
+}
+
+// Injected returns the number of times that [fault] was injected
+func (s *Sender) Injected(fault string) uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.counts[fault]
+}
+
+// AcceptedFrontier may be sent late
+func (s *Sender) AcceptedFrontier(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs []ids.ID) {
+	s.reply(func() {
+		s.ExternalSender.AcceptedFrontier(validatorID, chainID, requestID, containerIDs)
+	})
+}
+
+// Accepted may be sent late
+func (s *Sender) Accepted(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs []ids.ID) {
+	s.reply(func() {
+		s.ExternalSender.Accepted(validatorID, chainID, requestID, containerIDs)
+	})
+}
+
+// MultiPut may be withheld or sent late
+func (s *Sender) MultiPut(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containers [][]byte) {
+	if s.inject(WithheldPutFault, s.config.WithholdPut) {
+		s.log.Debug("withholding MultiPut from %s. RequestID: %d", validatorID, requestID)
+		return
+	}
+	s.reply(func() {
+		s.ExternalSender.MultiPut(validatorID, chainID, requestID, containers)
+	})
+}
+
+// Put may be withheld or sent late
+func (s *Sender) Put(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerID ids.ID, container []byte) {
+	if s.inject(WithheldPutFault, s.config.WithholdPut) {
+		s.log.Debug("withholding Put of %s from %s. RequestID: %d", containerID, validatorID, requestID)
+		return
+	}
+	s.reply(func() {
+		s.ExternalSender.Put(validatorID, chainID, requestID, containerID, container)
+	})
+}
+
+// PushQuery may send some of the validators the container of the previous
+// PushQuery
+func (s *Sender) PushQuery(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte) {
+	s.lock.Lock()
+	lastContainerID, lastContainer := s.lastContainerID, s.lastContainer
+	s.lastContainerID, s.lastContainer = containerID, container
+	s.lock.Unlock()
+
+	if validatorIDs.Len() < 2 || lastContainer == nil || lastContainerID == containerID ||
+		!s.inject(EquivocationFault, s.config.Equivocate) {
+		s.ExternalSender.PushQuery(validatorIDs, chainID, requestID, deadline, containerID, container)
+		return
+	}
+
+	vdrList := validatorIDs.List()
+	ids.SortShortIDs(vdrList)
+	honest, equivocated := ids.ShortSet{}, ids.ShortSet{}
+	honest.Add(vdrList[:len(vdrList)/2]...)
+	equivocated.Add(vdrList[len(vdrList)/2:]...)
+
+	s.log.Debug("equivocating between %s and %s. RequestID: %d", containerID, lastContainerID, requestID)
+	s.ExternalSender.PushQuery(honest, chainID, requestID, deadline, containerID, container)
+	s.ExternalSender.PushQuery(equivocated, chainID, requestID, deadline, lastContainerID, lastContainer)
+}
+
+// Chits may vote for random IDs or be sent late
+func (s *Sender) Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID) {
+	if s.inject(RandomChitsFault, s.config.RandomChits) {
+		randomVotes := make([]ids.ID, len(votes))
+		s.lock.Lock()
+		for i := range randomVotes {
+			_, _ = s.rng.Read(randomVotes[i][:])
+		}
+		s.lock.Unlock()
+
+		s.log.Debug("replacing votes %s to %s with %s. RequestID: %d", votes, validatorID, randomVotes, requestID)
+		votes = randomVotes
+	}
+	s.reply(func() {
+		s.ExternalSender.Chits(validatorID, chainID, requestID, votes)
+	})
+}
+
+// AppResponse may be sent late
+func (s *Sender) AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte) {
+	s.reply(func() {
+		s.ExternalSender.AppResponse(validatorID, chainID, requestID, appResponseBytes)
+	})
+}
+
+// reply calls [send] now, or after the late reply delay
+func (s *Sender) reply(send func()) {
+	if !s.inject(LateReplyFault, s.config.LateReply) {
+		send()
+		return
+	}
+	time.AfterFunc(s.config.LateReplyDelay, send)
+}
+
+// inject returns true, and counts the fault, with [probability]
+func (s *Sender) inject(fault string, probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.rng.Float64() >= probability {
+		return false
+	}
+	s.counts[fault]++
+	s.injected.WithLabelValues(fault).Inc()
+	return true
+}
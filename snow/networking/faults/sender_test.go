@@ -0,0 +1,180 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faults
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestSender(t *testing.T, config Config) (*Sender, *sender.ExternalSenderTest) {
+	external := &sender.ExternalSenderTest{T: t}
+	external.Default(true)
+	s, err := New(config, external, logging.NoLog{}, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, external
+}
+
+func TestSenderNoFaults(t *testing.T) {
+	s, external := newTestSender(t, Config{})
+
+	vdr := ids.GenerateTestShortID()
+	chainID := ids.GenerateTestID()
+	votes := []ids.ID{ids.GenerateTestID()}
+
+	sent := 0
+	external.CantChits = false
+	external.ChitsF = func(validatorID ids.ShortID, _ ids.ID, requestID uint32, sentVotes []ids.ID) {
+		sent++
+		if !validatorID.Equals(vdr) || requestID != 1 || len(sentVotes) != 1 || sentVotes[0] != votes[0] {
+			t.Fatalf("wrong chits sent")
+		}
+	}
+	external.CantPut = false
+	external.PutF = func(ids.ShortID, ids.ID, uint32, ids.ID, []byte) { sent++ }
+	external.CantGossip = false
+	external.GossipF = func(ids.ID, ids.ID, []byte) { sent++ }
+
+	s.Chits(vdr, chainID, 1, votes)
+	s.Put(vdr, chainID, 1, ids.GenerateTestID(), []byte{1})
+	s.Gossip(chainID, ids.GenerateTestID(), []byte{1})
+	if sent != 3 {
+		t.Fatalf("expected 3 messages to be sent but %d were", sent)
+	}
+	for _, fault := range []string{RandomChitsFault, WithheldPutFault, LateReplyFault, EquivocationFault} {
+		if injected := s.Injected(fault); injected != 0 {
+			t.Fatalf("%s was injected %d times", fault, injected)
+		}
+	}
+}
+
+func TestSenderRandomChits(t *testing.T) {
+	s, external := newTestSender(t, Config{RandomChits: 1})
+
+	votes := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	sent := false
+	external.CantChits = false
+	external.ChitsF = func(_ ids.ShortID, _ ids.ID, _ uint32, sentVotes []ids.ID) {
+		sent = true
+		if len(sentVotes) != len(votes) {
+			t.Fatalf("sent %d votes but expected %d", len(sentVotes), len(votes))
+		}
+		for i, vote := range sentVotes {
+			if vote == votes[i] {
+				t.Fatalf("vote %d wasn't replaced", i)
+			}
+		}
+	}
+
+	s.Chits(ids.GenerateTestShortID(), ids.GenerateTestID(), 1, votes)
+	if !sent {
+		t.Fatalf("chits weren't sent")
+	}
+	if injected := s.Injected(RandomChitsFault); injected != 1 {
+		t.Fatalf("random chits were injected %d times but expected 1", injected)
+	}
+	if count := testutil.ToFloat64(s.injected.WithLabelValues(RandomChitsFault)); count != 1 {
+		t.Fatalf("metric counted %f random chits but expected 1", count)
+	}
+}
+
+func TestSenderWithholdPut(t *testing.T) {
+	s, _ := newTestSender(t, Config{WithholdPut: 1})
+
+	// The test sender fails the test if a Put or MultiPut is sent
+	s.Put(ids.GenerateTestShortID(), ids.GenerateTestID(), 1, ids.GenerateTestID(), []byte{1})
+	s.MultiPut(ids.GenerateTestShortID(), ids.GenerateTestID(), 1, [][]byte{{1}})
+	if injected := s.Injected(WithheldPutFault); injected != 2 {
+		t.Fatalf("puts were withheld %d times but expected 2", injected)
+	}
+}
+
+func TestSenderLateReply(t *testing.T) {
+	delay := 50 * time.Millisecond
+	s, external := newTestSender(t, Config{
+		LateReply:      1,
+		LateReplyDelay: delay,
+	})
+
+	sent := make(chan time.Time, 1)
+	external.CantAccepted = false
+	external.AcceptedF = func(ids.ShortID, ids.ID, uint32, []ids.ID) { sent <- time.Now() }
+
+	start := time.Now()
+	s.Accepted(ids.GenerateTestShortID(), ids.GenerateTestID(), 1, nil)
+	select {
+	case sentAt := <-sent:
+		if sentAt.Sub(start) < delay {
+			t.Fatalf("reply was sent after %s but should have been delayed by %s", sentAt.Sub(start), delay)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("reply was never sent")
+	}
+	if injected := s.Injected(LateReplyFault); injected != 1 {
+		t.Fatalf("late replies were injected %d times but expected 1", injected)
+	}
+}
+
+func TestSenderEquivocate(t *testing.T) {
+	s, external := newTestSender(t, Config{Equivocate: 1})
+
+	chainID := ids.GenerateTestID()
+	firstID, firstContainer := ids.GenerateTestID(), []byte{1}
+	secondID, secondContainer := ids.GenerateTestID(), []byte{2}
+	vdrs := ids.ShortSet{}
+	vdrs.Add(ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID())
+
+	queried := map[ids.ID]ids.ShortSet{}
+	external.CantPushQuery = false
+	external.PushQueryF = func(validatorIDs ids.ShortSet, _ ids.ID, _ uint32, _ time.Time, containerID ids.ID, _ []byte) {
+		set := queried[containerID]
+		set.Union(validatorIDs)
+		queried[containerID] = set
+	}
+
+	// There's no previous container to equivocate with
+	s.PushQuery(vdrs, chainID, 1, time.Time{}, firstID, firstContainer)
+	if queried[firstID].Len() != 4 {
+		t.Fatalf("first container was sent to %d validators but expected 4", queried[firstID].Len())
+	}
+
+	queried = map[ids.ID]ids.ShortSet{}
+	s.PushQuery(vdrs, chainID, 2, time.Time{}, secondID, secondContainer)
+	if queried[firstID].Len() != 2 || queried[secondID].Len() != 2 {
+		t.Fatalf("expected each container to be sent to half of the validators")
+	}
+	secondQueried := queried[secondID]
+	for _, vdr := range queried[firstID].List() {
+		if secondQueried.Contains(vdr) {
+			t.Fatalf("%s was sent both containers", vdr)
+		}
+	}
+	if injected := s.Injected(EquivocationFault); injected != 1 {
+		t.Fatalf("equivocations were injected %d times but expected 1", injected)
+	}
+
+	// A query to a single validator can't equivocate
+	queried = map[ids.ID]ids.ShortSet{}
+	vdr := ids.ShortSet{}
+	vdr.Add(ids.GenerateTestShortID())
+	s.PushQuery(vdr, chainID, 3, time.Time{}, firstID, firstContainer)
+	if queried[firstID].Len() != 1 || queried[secondID].Len() != 0 {
+		t.Fatalf("query to a single validator shouldn't equivocate")
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	if _, err := New(Config{WithholdPut: 2}, &sender.ExternalSenderTest{}, logging.NoLog{}, "", prometheus.NewRegistry()); err == nil {
+		t.Fatalf("should have failed with an invalid probability")
+	}
+}
@@ -389,6 +389,65 @@ func (sr *ChainRouter) QueryFailed(validatorID ids.ShortID, chainID ids.ID, requ
 	}
 }
 
+// AppRequest routes an incoming AppRequest from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) AppRequest(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, appRequestBytes []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.AppRequest(validatorID, requestID, deadline, appRequestBytes)
+	} else {
+		sr.log.Debug("AppRequest(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// AppResponse routes an incoming AppResponse from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	// This message came in response to an AppRequest message from this node,
+	// and when we sent that message we set a timeout. Since we got a response,
+	// cancel the timeout.
+	if chain, exists := sr.chains[chainID]; exists {
+		if chain.AppResponse(validatorID, requestID, appResponseBytes) {
+			sr.timeouts.CancelAppRequest(validatorID, chainID, requestID)
+		}
+	} else {
+		sr.log.Debug("AppResponse(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// AppRequestFailed routes an incoming AppRequestFailed message from the
+// validator with ID [validatorID] to the consensus engine working on the chain
+// with ID [chainID]
+func (sr *ChainRouter) AppRequestFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	sr.timeouts.CancelAppRequest(validatorID, chainID, requestID)
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.AppRequestFailed(validatorID, requestID)
+	} else {
+		sr.log.Error("AppRequestFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// AppGossip routes an incoming AppGossip message from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) AppGossip(validatorID ids.ShortID, chainID ids.ID, appGossipBytes []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.AppGossip(validatorID, appGossipBytes)
+	} else {
+		sr.log.Verbo("AppGossip(%s, %s) dropped due to unknown chain", validatorID, chainID)
+	}
+}
+
 // Connected routes an incoming notification that a validator was just connected
 func (sr *ChainRouter) Connected(validatorID ids.ShortID) {
 	sr.lock.Lock()
@@ -454,6 +454,49 @@ func (h *Handler) QueryFailed(validatorID ids.ShortID, requestID uint32) {
 	})
 }
 
+// AppRequest passes an AppRequest message received from the network to the consensus engine.
+func (h *Handler) AppRequest(validatorID ids.ShortID, requestID uint32, deadline time.Time, appRequestBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppRequestMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		deadline:    deadline,
+		appBytes:    appRequestBytes,
+		received:    h.clock.Time(),
+	})
+}
+
+// AppResponse passes an AppResponse message received from the network to the consensus engine.
+func (h *Handler) AppResponse(validatorID ids.ShortID, requestID uint32, appResponseBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppResponseMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		appBytes:    appResponseBytes,
+		received:    h.clock.Time(),
+	})
+}
+
+// AppRequestFailed passes an AppRequestFailed message to the consensus engine.
+func (h *Handler) AppRequestFailed(validatorID ids.ShortID, requestID uint32) {
+	h.sendReliableMsg(message{
+		messageType: constants.AppRequestFailedMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
+// AppGossip passes an AppGossip message received from the network to the consensus engine.
+func (h *Handler) AppGossip(validatorID ids.ShortID, appGossipBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppGossipMsg,
+		validatorID: validatorID,
+		requestID:   constants.GossipMsgRequestID,
+		appBytes:    appGossipBytes,
+		received:    h.clock.Time(),
+	})
+}
+
 // Connected passes a new connection notification to the consensus engine
 func (h *Handler) Connected(validatorID ids.ShortID) {
 	h.sendReliableMsg(message{
@@ -550,6 +593,14 @@ func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
 		err = h.engine.QueryFailed(msg.validatorID, msg.requestID)
 	case constants.ChitsMsg:
 		err = h.engine.Chits(msg.validatorID, msg.requestID, msg.containerIDs)
+	case constants.AppRequestMsg:
+		err = h.appMsgErr(msg, h.engine.AppRequest(msg.validatorID, msg.requestID, msg.appBytes))
+	case constants.AppResponseMsg:
+		err = h.appMsgErr(msg, h.engine.AppResponse(msg.validatorID, msg.requestID, msg.appBytes))
+	case constants.AppRequestFailedMsg:
+		err = h.appMsgErr(msg, h.engine.AppRequestFailed(msg.validatorID, msg.requestID))
+	case constants.AppGossipMsg:
+		err = h.appMsgErr(msg, h.engine.AppGossip(msg.validatorID, msg.appBytes))
 	case constants.ConnectedMsg:
 		err = h.engine.Connected(msg.validatorID)
 	case constants.DisconnectedMsg:
@@ -566,6 +617,16 @@ func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
 	return err
 }
 
+// appMsgErr logs the error the VM returned while handling the app message
+// [msg]. App messages are sent by peers, so the VM failing to handle one
+// mustn't shut down the chain.
+func (h *Handler) appMsgErr(msg message, err error) error {
+	if err != nil {
+		h.ctx.Log.Debug("dropping %s from %s due to: %s", msg.messageType, msg.validatorID, err)
+	}
+	return nil
+}
+
 func (h *Handler) sendReliableMsg(msg message) {
 	h.reliableMsgsLock.Lock()
 	defer h.reliableMsgsLock.Unlock()
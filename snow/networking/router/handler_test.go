@@ -145,6 +145,55 @@ func TestHandlerClosesOnError(t *testing.T) {
 	}
 }
 
+func TestHandlerDoesntCloseOnAppError(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+
+	closed := make(chan struct{}, 1)
+	called := make(chan struct{}, 1)
+
+	engine.ContextF = snow.DefaultContextTest
+	engine.AppGossipF = func(nodeID ids.ShortID, appGossipBytes []byte) error {
+		return errors.New("VM error shouldn't cause handler to close")
+	}
+	engine.GetAcceptedFrontierF = func(validatorID ids.ShortID, requestID uint32) error {
+		called <- struct{}{}
+		return nil
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	handler.clock.Set(time.Now())
+
+	handler.toClose = func() {
+		closed <- struct{}{}
+	}
+	go handler.Dispatch()
+
+	handler.AppGossip(ids.NewShortID([20]byte{}), []byte{1})
+	handler.GetAcceptedFrontier(ids.NewShortID([20]byte{}), 1, time.Now().Add(time.Second))
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C:
+		t.Fatalf("Calling engine function timed out")
+	case <-closed:
+		t.Fatalf("Handler shouldn't have been closed")
+	case <-called:
+	}
+}
+
 func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	engine := common.EngineTest{T: t}
 	engine.Default(false)
@@ -21,6 +21,7 @@ type message struct {
 	container    []byte
 	containers   [][]byte
 	containerIDs []ids.ID
+	appBytes     []byte
 	notification common.Message
 	received     time.Time // Time this message was received
 	deadline     time.Time // Time this message must be responded to
@@ -45,6 +46,8 @@ func (m message) String() string {
 		sb.WriteString(fmt.Sprintf("\n    containerID: %s", m.containerID))
 	case constants.MultiPutMsg:
 		sb.WriteString(fmt.Sprintf("\n    numContainers: %d", len(m.containers)))
+	case constants.AppRequestMsg, constants.AppResponseMsg, constants.AppGossipMsg:
+		sb.WriteString(fmt.Sprintf("\n    len(appBytes): %d", len(m.appBytes)))
 	case constants.NotifyMsg:
 		sb.WriteString(fmt.Sprintf("\n    notification: %s", m.notification))
 	}
@@ -42,6 +42,7 @@ type metrics struct {
 	getAncestors, multiPut, getAncestorsFailed,
 	get, put, getFailed,
 	pushQuery, pullQuery, chits, queryFailed,
+	appRequest, appResponse, appRequestFailed, appGossip,
 	connected, disconnected,
 	notify,
 	gossip,
@@ -108,6 +109,10 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 	m.pullQuery = initHistogram(namespace, "pull_query", registerer, &errs)
 	m.chits = initHistogram(namespace, "chits", registerer, &errs)
 	m.queryFailed = initHistogram(namespace, "query_failed", registerer, &errs)
+	m.appRequest = initHistogram(namespace, "app_request", registerer, &errs)
+	m.appResponse = initHistogram(namespace, "app_response", registerer, &errs)
+	m.appRequestFailed = initHistogram(namespace, "app_request_failed", registerer, &errs)
+	m.appGossip = initHistogram(namespace, "app_gossip", registerer, &errs)
 	m.connected = initHistogram(namespace, "connected", registerer, &errs)
 	m.disconnected = initHistogram(namespace, "disconnected", registerer, &errs)
 	m.notify = initHistogram(namespace, "notify", registerer, &errs)
@@ -193,6 +198,14 @@ func (m *metrics) getMSGHistogram(msg constants.MsgType) prometheus.Histogram {
 		return m.queryFailed
 	case constants.ChitsMsg:
 		return m.chits
+	case constants.AppRequestMsg:
+		return m.appRequest
+	case constants.AppResponseMsg:
+		return m.appResponse
+	case constants.AppRequestFailedMsg:
+		return m.appRequestFailed
+	case constants.AppGossipMsg:
+		return m.appGossip
 	case constants.ConnectedMsg:
 		return m.connected
 	case constants.DisconnectedMsg:
@@ -45,6 +45,9 @@ type ExternalRouter interface {
 	PushQuery(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte)
 	PullQuery(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID)
 	Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID)
+	AppRequest(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, appRequestBytes []byte)
+	AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte)
+	AppGossip(validatorID ids.ShortID, chainID ids.ID, appGossipBytes []byte)
 }
 
 // InternalRouter deals with messages internal to this node
@@ -54,6 +57,7 @@ type InternalRouter interface {
 	GetFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 	GetAncestorsFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 	QueryFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
+	AppRequestFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 
 	Connected(validatorID ids.ShortID)
 	Disconnected(validatorID ids.ShortID)
@@ -243,3 +243,50 @@ func (s *Sender) Gossip(containerID ids.ID, container []byte) {
 	s.ctx.Log.Verbo("Gossiping %s", containerID)
 	s.sender.Gossip(s.ctx.ChainID, containerID, container)
 }
+
+// AppRequest sends an AppRequest message to the VMs running on this chain on
+// the specified validators.
+// The AppRequest message signifies that the VM would like each validator to
+// respond with an AppResponse message with the same requestID.
+func (s *Sender) AppRequest(validatorIDs ids.ShortSet, requestID uint32, appRequestBytes []byte) {
+	s.ctx.Log.Verbo("Sending AppRequest to validators %v. RequestID: %d. Len: %d", validatorIDs, requestID, len(appRequestBytes))
+
+	currentDeadline := time.Time{}
+	for validatorIDKey := range validatorIDs {
+		validatorID := ids.NewShortID(validatorIDKey)
+		deadline := s.timeouts.RegisterAppRequest(validatorID, s.ctx.ChainID, requestID, func() {
+			s.router.AppRequestFailed(validatorID, s.ctx.ChainID, requestID)
+		})
+		if deadline.After(currentDeadline) {
+			currentDeadline = deadline
+		}
+	}
+
+	// If one of the validators in [validatorIDs] is myself, send this message directly
+	// to my own router rather than sending it over the network
+	if validatorIDs.Contains(s.ctx.NodeID) {
+		validatorIDs.Remove(s.ctx.NodeID)
+		go s.router.AppRequest(s.ctx.NodeID, s.ctx.ChainID, requestID, currentDeadline, appRequestBytes)
+	}
+
+	s.sender.AppRequest(validatorIDs, s.ctx.ChainID, requestID, currentDeadline, appRequestBytes)
+}
+
+// AppResponse sends an AppResponse message to the VM running on this chain on
+// the specified validator, in response to an AppRequest message.
+func (s *Sender) AppResponse(validatorID ids.ShortID, requestID uint32, appResponseBytes []byte) {
+	s.ctx.Log.Verbo("Sending AppResponse to validator %s. RequestID: %d. Len: %d", validatorID, requestID, len(appResponseBytes))
+	// If [validatorID] is myself, send this message directly
+	// to my own router rather than sending it over the network
+	if validatorID.Equals(s.ctx.NodeID) {
+		go s.router.AppResponse(validatorID, s.ctx.ChainID, requestID, appResponseBytes)
+	} else {
+		s.sender.AppResponse(validatorID, s.ctx.ChainID, requestID, appResponseBytes)
+	}
+}
+
+// AppGossip gossips the provided application level message
+func (s *Sender) AppGossip(appGossipBytes []byte) {
+	s.ctx.Log.Verbo("Gossiping AppGossip. Len: %d", len(appGossipBytes))
+	s.sender.AppGossip(s.ctx.ChainID, appGossipBytes)
+}
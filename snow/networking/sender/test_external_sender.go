@@ -20,7 +20,8 @@ type ExternalSenderTest struct {
 	CantGetAncestors, CantMultiPut,
 	CantGet, CantPut,
 	CantPullQuery, CantPushQuery, CantChits,
-	CantGossip bool
+	CantGossip,
+	CantAppRequest, CantAppResponse, CantAppGossip bool
 
 	GetAcceptedFrontierF func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time)
 	AcceptedFrontierF    func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs []ids.ID)
@@ -39,6 +40,10 @@ type ExternalSenderTest struct {
 	ChitsF     func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID)
 
 	GossipF func(chainID ids.ID, containerID ids.ID, container []byte)
+
+	AppRequestF  func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, appRequestBytes []byte)
+	AppResponseF func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte)
+	AppGossipF   func(chainID ids.ID, appGossipBytes []byte)
 }
 
 // Default set the default callable value to [cant]
@@ -60,6 +65,10 @@ func (s *ExternalSenderTest) Default(cant bool) {
 	s.CantChits = cant
 
 	s.CantGossip = cant
+
+	s.CantAppRequest = cant
+	s.CantAppResponse = cant
+	s.CantAppGossip = cant
 }
 
 // GetAcceptedFrontier calls GetAcceptedFrontierF if it was initialized. If it
@@ -229,3 +238,45 @@ func (s *ExternalSenderTest) Gossip(chainID ids.ID, containerID ids.ID, containe
 		s.B.Fatalf("Unexpectedly called Gossip")
 	}
 }
+
+// AppRequest calls AppRequestF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *ExternalSenderTest) AppRequest(vdrs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, appRequestBytes []byte) {
+	switch {
+	case s.AppRequestF != nil:
+		s.AppRequestF(vdrs, chainID, requestID, deadline, appRequestBytes)
+	case s.CantAppRequest && s.T != nil:
+		s.T.Fatalf("Unexpectedly called AppRequest")
+	case s.CantAppRequest && s.B != nil:
+		s.B.Fatalf("Unexpectedly called AppRequest")
+	}
+}
+
+// AppResponse calls AppResponseF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *ExternalSenderTest) AppResponse(vdr ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte) {
+	switch {
+	case s.AppResponseF != nil:
+		s.AppResponseF(vdr, chainID, requestID, appResponseBytes)
+	case s.CantAppResponse && s.T != nil:
+		s.T.Fatalf("Unexpectedly called AppResponse")
+	case s.CantAppResponse && s.B != nil:
+		s.B.Fatalf("Unexpectedly called AppResponse")
+	}
+}
+
+// AppGossip calls AppGossipF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *ExternalSenderTest) AppGossip(chainID ids.ID, appGossipBytes []byte) {
+	switch {
+	case s.AppGossipF != nil:
+		s.AppGossipF(chainID, appGossipBytes)
+	case s.CantAppGossip && s.T != nil:
+		s.T.Fatalf("Unexpectedly called AppGossip")
+	case s.CantAppGossip && s.B != nil:
+		s.B.Fatalf("Unexpectedly called AppGossip")
+	}
+}
@@ -4,8 +4,10 @@
 package timeout
 
 import (
+	"sync"
 	"time"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -15,16 +17,33 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+const (
+	// latencyWeight is the weight given to a new observation when updating the
+	// moving average of a validator's response latency
+	latencyWeight = .1
+
+	// latencyCacheSize is the maximum number of validators whose latency is
+	// tracked. Validators that haven't responded recently are evicted first.
+	latencyCacheSize = 2048
+)
+
 // Manager registers and fires timeouts for the snow API.
 type Manager struct {
 	tm        timer.AdaptiveTimeoutManager
 	benchlist benchlist.Manager
 	executor  timer.Executor
+
+	// Serializes updates to the moving averages in [latencies]
+	latencyLock sync.Mutex
+	// Hash of the validator ID --> Moving average of the time the validator
+	// has taken to respond to requests
+	latencies cache.LRU
 }
 
 // Initialize this timeout manager.
 func (m *Manager) Initialize(timeoutConfig *timer.AdaptiveTimeoutConfig, benchlist benchlist.Manager) error {
 	m.benchlist = benchlist
+	m.latencies.Size = latencyCacheSize
 	m.executor.Initialize()
 	return m.tm.Initialize(timeoutConfig)
 }
@@ -58,7 +77,50 @@ func (m *Manager) Register(validatorID ids.ShortID, chainID ids.ID, requestID ui
 // Cancel request timeout with the specified parameters.
 func (m *Manager) Cancel(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
 	m.benchlist.RegisterResponse(chainID, validatorID, requestID)
-	m.tm.Remove(createRequestID(validatorID, chainID, requestID))
+	if latency, ok := m.tm.RemoveMeasured(createRequestID(validatorID, chainID, requestID)); ok {
+		m.observeLatency(validatorID, latency)
+	}
+}
+
+// IsBenched returns true if [validatorID] is currently benched on [chainID]
+func (m *Manager) IsBenched(validatorID ids.ShortID, chainID ids.ID) bool {
+	return m.benchlist.IsBenched(validatorID, chainID)
+}
+
+// Latency returns the moving average of the time [validatorID] has taken to
+// respond to requests. Returns false if no response has been received from
+// [validatorID].
+func (m *Manager) Latency(validatorID ids.ShortID) (time.Duration, bool) {
+	latency, ok := m.latencies.Get(hashing.ComputeHash256Array(validatorID.Bytes()))
+	if !ok {
+		return 0, false
+	}
+	return latency.(time.Duration), true
+}
+
+func (m *Manager) observeLatency(validatorID ids.ShortID, latency time.Duration) {
+	m.latencyLock.Lock()
+	defer m.latencyLock.Unlock()
+
+	key := hashing.ComputeHash256Array(validatorID.Bytes())
+	if average, ok := m.latencies.Get(key); ok {
+		latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(average.(time.Duration)))
+	}
+	m.latencies.Put(key, latency)
+}
+
+// RegisterAppRequest registers an application level request to time out unless
+// Manager.CancelAppRequest is called before the timeout duration passes, with
+// the same request parameters. Application level requests are never reported
+// to the benchlist.
+func (m *Manager) RegisterAppRequest(validatorID ids.ShortID, chainID ids.ID, requestID uint32, timeout func()) time.Time {
+	return m.tm.Put(createAppRequestID(validatorID, chainID, requestID), timeout)
+}
+
+// CancelAppRequest cancels the application level request timeout with the
+// specified parameters.
+func (m *Manager) CancelAppRequest(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	m.tm.Remove(createAppRequestID(validatorID, chainID, requestID))
 }
 
 func createRequestID(validatorID ids.ShortID, chainID ids.ID, requestID uint32) ids.ID {
@@ -67,3 +129,13 @@ func createRequestID(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 
 	return hashing.ByteArraysToHash256Array(validatorID.Bytes(), chainID[:], p.Bytes)
 }
+
+// createAppRequestID is separated from createRequestID because the VM chooses
+// its own request IDs, which may overlap with the consensus engine's.
+func createAppRequestID(validatorID ids.ShortID, chainID ids.ID, requestID uint32) ids.ID {
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.IntLen+wrappers.ByteLen)}
+	p.PackInt(requestID)
+	p.PackByte(byte(constants.AppRequestMsg))
+
+	return hashing.ByteArraysToHash256Array(validatorID.Bytes(), chainID[:], p.Bytes)
+}
@@ -74,3 +74,73 @@ func TestManagerCancel(t *testing.T) {
 		t.Fatalf("Should have cancelled the function")
 	}
 }
+
+func TestManagerLatency(t *testing.T) {
+	manager := Manager{}
+	benchlist := benchlist.NewNoBenchlist()
+	err := manager.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: 10 * time.Second,
+		MinimumTimeout: 10 * time.Second,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go manager.Dispatch()
+
+	validatorID := ids.NewShortID([20]byte{1})
+	if _, ok := manager.Latency(validatorID); ok {
+		t.Fatalf("Shouldn't have a latency before a response was received")
+	}
+
+	manager.Register(validatorID, ids.ID{}, 0, true, 0, func() {})
+	time.Sleep(time.Millisecond)
+	manager.Cancel(validatorID, ids.ID{}, 0)
+
+	latency, ok := manager.Latency(validatorID)
+	if !ok {
+		t.Fatalf("Should have measured the latency of the response")
+	}
+	if latency < time.Millisecond {
+		t.Fatalf("Measured latency %s should be at least %s", latency, time.Millisecond)
+	}
+
+	// Cancelling an unknown request shouldn't change the measured latency
+	manager.Cancel(validatorID, ids.ID{}, 1)
+	if newLatency, _ := manager.Latency(validatorID); newLatency != latency {
+		t.Fatalf("Latency changed from %s to %s", latency, newLatency)
+	}
+}
+
+func TestManagerLatencyBounded(t *testing.T) {
+	manager := Manager{}
+	err := manager.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: 10 * time.Second,
+		MinimumTimeout: 10 * time.Second,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist.NewNoBenchlist())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validatorIDs := make([]ids.ShortID, latencyCacheSize+1)
+	for i := range validatorIDs {
+		validatorIDs[i] = ids.NewShortID([20]byte{byte(i >> 8), byte(i)})
+		manager.observeLatency(validatorIDs[i], time.Millisecond)
+	}
+
+	if _, ok := manager.Latency(validatorIDs[0]); ok {
+		t.Fatalf("Least recently observed validator should have been evicted")
+	}
+	if _, ok := manager.Latency(validatorIDs[latencyCacheSize]); !ok {
+		t.Fatalf("Most recently observed validator should still be tracked")
+	}
+}
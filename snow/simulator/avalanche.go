@@ -0,0 +1,193 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// txLen is the length of a transaction's bytes: the ID of its input and a
+	// nonce
+	txLen = 32 + wrappers.LongLen
+)
+
+var (
+	errUnknownTx  = errors.New("unknown transaction")
+	errSpentInput = errors.New("transaction's input was already spent")
+
+	_ vertex.DAGVM = &dagVM{}
+	_ snowstorm.Tx = &simTx{}
+	_ chain        = &dagVM{}
+)
+
+// dagVM is an avalanche VM whose transactions each spend a single input, so
+// two transactions conflict if they spend the same input
+type dagVM struct {
+	sim  *simulation
+	node *node
+
+	txs     map[ids.ID]*simTx
+	pending []snowstorm.Tx
+	// inputs spent by accepted transactions
+	spent ids.Set
+
+	// XOR of the IDs of the accepted transactions
+	acceptedTxs ids.ID
+}
+
+func newDAGVM(sim *simulation, n *node) *dagVM {
+	return &dagVM{
+		sim:  sim,
+		node: n,
+		txs:  make(map[ids.ID]*simTx),
+	}
+}
+
+// Initialize implements the common.VM interface
+func (vm *dagVM) Initialize(*snow.Context, database.Database, []byte, chan<- common.Message, []*common.Fx) error {
+	return nil
+}
+
+// Bootstrapping implements the common.VM interface
+func (vm *dagVM) Bootstrapping() error { return nil }
+
+// Bootstrapped implements the common.VM interface
+func (vm *dagVM) Bootstrapped() error { return nil }
+
+// Shutdown implements the common.VM interface
+func (vm *dagVM) Shutdown() error { return nil }
+
+// CreateHandlers implements the common.VM interface
+func (vm *dagVM) CreateHandlers() map[string]*common.HTTPHandler { return nil }
+
+// Health implements the common.VM interface
+func (vm *dagVM) Health() (interface{}, error) { return nil, nil }
+
+// PendingTxs implements the vertex.DAGVM interface
+func (vm *dagVM) PendingTxs() []snowstorm.Tx {
+	txs := vm.pending
+	vm.pending = nil
+	return txs
+}
+
+// ParseTx implements the vertex.DAGVM interface
+func (vm *dagVM) ParseTx(b []byte) (snowstorm.Tx, error) { return vm.parse(b) }
+
+// GetTx implements the vertex.DAGVM interface
+func (vm *dagVM) GetTx(txID ids.ID) (snowstorm.Tx, error) {
+	if tx, ok := vm.txs[txID]; ok {
+		return tx, nil
+	}
+	return nil, errUnknownTx
+}
+
+// propose implements the chain interface
+func (vm *dagVM) propose() error {
+	tx, err := vm.parse(vm.sim.newTx())
+	if err != nil {
+		return err
+	}
+	vm.pending = append(vm.pending, tx)
+	vm.sim.tracker.issue(tx.id, vm.sim.scheduler.now)
+	return vm.node.engine.Notify(common.PendingTxs)
+}
+
+// accepted implements the chain interface
+func (vm *dagVM) accepted() ids.ID { return vm.acceptedTxs }
+
+func (vm *dagVM) parse(b []byte) (*simTx, error) {
+	txID := hashing.ComputeHash256Array(b)
+	if tx, ok := vm.txs[txID]; ok {
+		return tx, nil
+	}
+
+	p := wrappers.Packer{Bytes: b}
+	inputID, err := ids.ToID(p.UnpackFixedBytes(32))
+	if err != nil {
+		return nil, err
+	}
+	_ = p.UnpackLong()
+	if p.Errored() {
+		return nil, p.Err
+	}
+	if p.Offset != len(b) {
+		return nil, fmt.Errorf("transaction has %d unexpected bytes", len(b)-p.Offset)
+	}
+
+	tx := &simTx{
+		vm:      vm,
+		id:      txID,
+		inputID: inputID,
+		bytes:   b,
+		status:  choices.Processing,
+	}
+	vm.txs[txID] = tx
+	return tx, nil
+}
+
+// simTx is a transaction of a dagVM
+type simTx struct {
+	vm      *dagVM
+	id      ids.ID
+	inputID ids.ID
+	bytes   []byte
+	status  choices.Status
+}
+
+// ID implements the snowstorm.Tx interface
+func (tx *simTx) ID() ids.ID { return tx.id }
+
+// Accept implements the snowstorm.Tx interface
+func (tx *simTx) Accept() error {
+	tx.status = choices.Accepted
+	tx.vm.spent.Add(tx.inputID)
+	for i := range tx.vm.acceptedTxs {
+		tx.vm.acceptedTxs[i] ^= tx.id[i]
+	}
+	if !tx.vm.node.byzantine {
+		tx.vm.sim.tracker.accept(tx.vm.node, tx.id, tx.inputID, tx.vm.sim.scheduler.now)
+	}
+	return nil
+}
+
+// Reject implements the snowstorm.Tx interface
+func (tx *simTx) Reject() error {
+	tx.status = choices.Rejected
+	if !tx.vm.node.byzantine {
+		tx.vm.sim.tracker.reject(tx.vm.node, tx.id)
+	}
+	return nil
+}
+
+// Status implements the snowstorm.Tx interface
+func (tx *simTx) Status() choices.Status { return tx.status }
+
+// Dependencies implements the snowstorm.Tx interface
+func (tx *simTx) Dependencies() []snowstorm.Tx { return nil }
+
+// InputIDs implements the snowstorm.Tx interface
+func (tx *simTx) InputIDs() []ids.ID { return []ids.ID{tx.inputID} }
+
+// Verify implements the snowstorm.Tx interface
+func (tx *simTx) Verify() error {
+	if tx.status != choices.Accepted && tx.vm.spent.Contains(tx.inputID) {
+		return errSpentInput
+	}
+	return nil
+}
+
+// Bytes implements the snowstorm.Tx interface
+func (tx *simTx) Bytes() []byte { return tx.bytes }
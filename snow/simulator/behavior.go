@@ -0,0 +1,91 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"math/rand"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+var (
+	_ Behavior = Mute{}
+	_ Behavior = RandomChits{}
+	_ Behavior = Withhold{}
+)
+
+// Behavior is how a Byzantine node deviates from the protocol. A Byzantine
+// node runs the same engine as an honest node, but the engine's messages are
+// sent through the sender returned by its Behavior.
+type Behavior interface {
+	// Sender returns the sender that the node's engine uses. [honest] sends
+	// messages as an honest node would. [rng] is the simulation's source of
+	// randomness.
+	Sender(honest common.Sender, rng *rand.Rand) common.Sender
+}
+
+// Mute is a node that never sends a message, as if it had crashed
+type Mute struct{}
+
+// Sender implements the Behavior interface
+func (Mute) Sender(common.Sender, *rand.Rand) common.Sender { return muteSender{} }
+
+// RandomChits is a node that answers every query with votes for containers
+// that don't exist
+type RandomChits struct{}
+
+// Sender implements the Behavior interface
+func (RandomChits) Sender(honest common.Sender, rng *rand.Rand) common.Sender {
+	return &randomChitsSender{
+		Sender: honest,
+		rng:    rng,
+	}
+}
+
+// Withhold is a node that never sends the containers that it is asked for or
+// that it would gossip
+type Withhold struct{}
+
+// Sender implements the Behavior interface
+func (Withhold) Sender(honest common.Sender, _ *rand.Rand) common.Sender {
+	return withholdSender{Sender: honest}
+}
+
+type muteSender struct{}
+
+func (muteSender) GetAcceptedFrontier(ids.ShortSet, uint32)       {}
+func (muteSender) AcceptedFrontier(ids.ShortID, uint32, []ids.ID) {}
+func (muteSender) GetAccepted(ids.ShortSet, uint32, []ids.ID)     {}
+func (muteSender) Accepted(ids.ShortID, uint32, []ids.ID)         {}
+func (muteSender) Get(ids.ShortID, uint32, ids.ID)                {}
+func (muteSender) GetAncestors(ids.ShortID, uint32, ids.ID)       {}
+func (muteSender) Put(ids.ShortID, uint32, ids.ID, []byte)        {}
+func (muteSender) MultiPut(ids.ShortID, uint32, [][]byte)         {}
+func (muteSender) PushQuery(ids.ShortSet, uint32, ids.ID, []byte) {}
+func (muteSender) PullQuery(ids.ShortSet, uint32, ids.ID)         {}
+func (muteSender) Chits(ids.ShortID, uint32, []ids.ID)            {}
+func (muteSender) Gossip(ids.ID, []byte)                          {}
+func (muteSender) AppRequest(ids.ShortSet, uint32, []byte)        {}
+func (muteSender) AppResponse(ids.ShortID, uint32, []byte)        {}
+func (muteSender) AppGossip([]byte)                               {}
+
+type randomChitsSender struct {
+	common.Sender
+	rng *rand.Rand
+}
+
+func (s *randomChitsSender) Chits(validatorID ids.ShortID, requestID uint32, votes []ids.ID) {
+	randomVotes := make([]ids.ID, len(votes))
+	for i := range randomVotes {
+		_, _ = s.rng.Read(randomVotes[i][:])
+	}
+	s.Sender.Chits(validatorID, requestID, randomVotes)
+}
+
+type withholdSender struct{ common.Sender }
+
+func (withholdSender) Put(ids.ShortID, uint32, ids.ID, []byte) {}
+func (withholdSender) MultiPut(ids.ShortID, uint32, [][]byte)  {}
+func (withholdSender) Gossip(ids.ID, []byte)                   {}
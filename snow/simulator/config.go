@@ -0,0 +1,180 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+)
+
+var (
+	errNoNodes            = errors.New("the network must have at least one node")
+	errWrongNumWeights    = errors.New("the number of weights must match the number of nodes")
+	errZeroWeight         = errors.New("every node must have a positive weight")
+	errInsufficientWeight = errors.New("the validators' total weight is less than the sample size")
+	errNoHonestNodes      = errors.New("the network must have at least one honest node")
+	errUnknownNode        = errors.New("unknown node index")
+	errInvalidDropRate    = errors.New("the drop rate must be in [0, 1)")
+	errInvalidLatency     = errors.New("the latency must satisfy 0 <= MinLatency <= MaxLatency")
+	errInvalidConflicts   = errors.New("the conflict rate must be in [0, 1]")
+	errNoRequestTimeout   = errors.New("the request timeout must be positive")
+	errNoMaxTime          = errors.New("the maximum time must be positive")
+)
+
+// Config describes a simulated network and the containers that are proposed
+// to it
+type Config struct {
+	// Seed of the simulation's source of randomness
+	Seed int64
+
+	// Nodes is the number of validators in the network
+	Nodes int
+	// Weights is the stake of each node. If empty, every node has a weight of
+	// 1.
+	Weights []uint64
+	// Byzantine maps the index of each Byzantine node to its behavior. Every
+	// other node is honest.
+	Byzantine map[int]Behavior
+
+	// DAG runs the avalanche engine rather than the snowman engine
+	DAG bool
+	// Params are the consensus parameters of every node. Parents and
+	// BatchSize are only used if [DAG] is true. Namespace and Metrics are
+	// ignored.
+	Params avalanche.Parameters
+
+	Network NetworkConfig
+
+	// Containers is the number of blocks, or of transactions if [DAG] is
+	// true, that are proposed. Each is proposed by a random honest node.
+	Containers int
+	// IssueInterval is the time between proposals
+	IssueInterval time.Duration
+	// ConflictRate is the probability that a transaction spends the input of
+	// an earlier transaction. Only used if [DAG] is true.
+	ConflictRate float64
+
+	// RequestTimeout is how long a node waits for a response before it fails
+	// the request
+	RequestTimeout time.Duration
+	// GossipFrequency is how often each node gossips its accepted frontier.
+	// If 0, nodes don't gossip.
+	GossipFrequency time.Duration
+	// MaxTime bounds the virtual time that the simulation runs for
+	MaxTime time.Duration
+}
+
+// NetworkConfig describes the conditions of the simulated network. Messages
+// that a node sends to itself are delivered immediately and never lost.
+type NetworkConfig struct {
+	// The latency of each message is drawn uniformly from
+	// [MinLatency, MaxLatency]
+	MinLatency, MaxLatency time.Duration
+	// DropRate is the probability that a message is lost
+	DropRate float64
+	// Partitions split the network for periods of time
+	Partitions []Partition
+}
+
+// Partition splits the network into groups of nodes that can't reach each
+// other from [Start] until [End]. Nodes that aren't in any group can reach
+// every node.
+type Partition struct {
+	Start, End time.Duration
+	// Groups of node indices
+	Groups [][]int
+}
+
+// DefaultConfig returns a network of 20 honest nodes that uses the node's
+// default consensus parameters
+func DefaultConfig() Config {
+	return Config{
+		Nodes: 20,
+		Params: avalanche.Parameters{
+			Parameters: snowball.Parameters{
+				K:                 20,
+				Alpha:             14,
+				BetaVirtuous:      15,
+				BetaRogue:         30,
+				ConcurrentRepolls: 4,
+			},
+			Parents:   5,
+			BatchSize: 30,
+		},
+		Network: NetworkConfig{
+			MinLatency: 10 * time.Millisecond,
+			MaxLatency: 100 * time.Millisecond,
+		},
+		Containers:      10,
+		IssueInterval:   100 * time.Millisecond,
+		ConflictRate:    0.1,
+		RequestTimeout:  2 * time.Second,
+		GossipFrequency: 10 * time.Second,
+		MaxTime:         10 * time.Minute,
+	}
+}
+
+// Valid returns nil if the config describes a network that can be simulated
+func (c *Config) Valid() error {
+	switch {
+	case c.Nodes <= 0:
+		return errNoNodes
+	case len(c.Weights) != 0 && len(c.Weights) != c.Nodes:
+		return errWrongNumWeights
+	case len(c.Byzantine) >= c.Nodes:
+		return errNoHonestNodes
+	case c.Network.DropRate < 0 || c.Network.DropRate >= 1:
+		return errInvalidDropRate
+	case c.Network.MinLatency < 0 || c.Network.MaxLatency < c.Network.MinLatency:
+		return errInvalidLatency
+	case c.ConflictRate < 0 || c.ConflictRate > 1:
+		return errInvalidConflicts
+	case c.RequestTimeout <= 0:
+		return errNoRequestTimeout
+	case c.MaxTime <= 0:
+		return errNoMaxTime
+	}
+
+	if c.DAG {
+		if err := c.Params.Valid(); err != nil {
+			return err
+		}
+	} else if err := c.Params.Parameters.Valid(); err != nil {
+		return err
+	}
+
+	totalWeight := uint64(c.Nodes)
+	if len(c.Weights) != 0 {
+		totalWeight = 0
+		for _, weight := range c.Weights {
+			if weight == 0 {
+				return errZeroWeight
+			}
+			totalWeight += weight
+		}
+	}
+	if totalWeight < uint64(c.Params.K) {
+		return errInsufficientWeight
+	}
+
+	for index := range c.Byzantine {
+		if index < 0 || index >= c.Nodes {
+			return fmt.Errorf("%w: Byzantine node %d", errUnknownNode, index)
+		}
+	}
+	for _, partition := range c.Network.Partitions {
+		for _, group := range partition.Groups {
+			for _, index := range group {
+				if index < 0 || index >= c.Nodes {
+					return fmt.Errorf("%w: partitioned node %d", errUnknownNode, index)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"math/rand"
+	"time"
+)
+
+// network delivers messages between nodes with the latency, loss and
+// partitions of its config
+type network struct {
+	config    NetworkConfig
+	rng       *rand.Rand
+	scheduler *scheduler
+
+	// groups[i] maps the index of each node in partition i to its group
+	groups []map[int]int
+
+	numSent, numDropped uint64
+}
+
+func newNetwork(config NetworkConfig, rng *rand.Rand, scheduler *scheduler) *network {
+	n := &network{
+		config:    config,
+		rng:       rng,
+		scheduler: scheduler,
+		groups:    make([]map[int]int, len(config.Partitions)),
+	}
+	for i, partition := range config.Partitions {
+		n.groups[i] = make(map[int]int)
+		for group, indices := range partition.Groups {
+			for _, index := range indices {
+				n.groups[i][index] = group
+			}
+		}
+	}
+	return n
+}
+
+// send a message from node [from] to node [to]. If the message isn't lost,
+// [deliver] runs when it arrives.
+func (n *network) send(from, to int, deliver func()) {
+	if from == to {
+		n.scheduler.schedule(0, deliver)
+		return
+	}
+
+	n.numSent++
+	if !n.connected(from, to) || n.rng.Float64() < n.config.DropRate {
+		n.numDropped++
+		return
+	}
+
+	latency := n.config.MinLatency
+	if jitter := n.config.MaxLatency - n.config.MinLatency; jitter > 0 {
+		latency += time.Duration(n.rng.Int63n(int64(jitter) + 1))
+	}
+	n.scheduler.schedule(latency, deliver)
+}
+
+// connected returns true if no partition separates nodes [a] and [b] at the
+// current time
+func (n *network) connected(a, b int) bool {
+	now := n.scheduler.now
+	for i, partition := range n.config.Partitions {
+		if now < partition.Start || now >= partition.End {
+			continue
+		}
+		groupA, okA := n.groups[i][a]
+		groupB, okB := n.groups[i][b]
+		if okA && okB && groupA != groupB {
+			return false
+		}
+	}
+	return true
+}
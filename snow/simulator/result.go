@@ -0,0 +1,145 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+)
+
+// Result of a simulation
+type Result struct {
+	// Time is the virtual time at which the simulation stopped
+	Time time.Duration
+	// Decided is true if every honest node decided every proposed container
+	// before the simulation stopped. Otherwise, the simulation reached the
+	// maximum time, or ran out of messages to deliver, first.
+	Decided bool
+
+	// Issued is the number of containers that were proposed
+	Issued int
+	// Finalized is the number of proposed containers that were accepted by
+	// every honest node
+	Finalized int
+	// Latencies are the times from the proposal of a container to its
+	// acceptance by each honest node, in increasing order
+	Latencies []time.Duration
+
+	// Safe is false if honest nodes made conflicting decisions
+	Safe bool
+	// Violations describes each conflicting decision
+	Violations []string
+
+	// Sent is the number of messages sent between different nodes, and
+	// Dropped is the number of those that were lost
+	Sent, Dropped uint64
+}
+
+// Finality returns the [percentile]th percentile, in (0, 100], of the
+// latencies. Returns 0 if no container was accepted.
+func (r *Result) Finality(percentile float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(percentile/100*float64(len(r.Latencies)))) - 1
+	switch {
+	case index < 0:
+		index = 0
+	case index >= len(r.Latencies):
+		index = len(r.Latencies) - 1
+	}
+	return r.Latencies[index]
+}
+
+// String returns a summary of the result
+func (r *Result) String() string {
+	return fmt.Sprintf(
+		"time: %s, decided: %t, issued: %d, finalized: %d, finality p50: %s, p99: %s, safe: %t, messages sent: %d, dropped: %d",
+		r.Time, r.Decided, r.Issued, r.Finalized, r.Finality(50), r.Finality(99), r.Safe, r.Sent, r.Dropped,
+	)
+}
+
+// tracker records the proposals and the decisions of the honest nodes
+type tracker struct {
+	numHonest int
+
+	// issued maps each proposed container to the time it was proposed
+	issued map[ids.ID]time.Duration
+	// decisions maps each decided container to the first decision made on it
+	decisions map[ids.ID]choices.Status
+	// accepted maps each conflict set to the first container accepted in it
+	accepted map[ids.ID]ids.ID
+	// acceptances is the number of honest nodes that accepted each container
+	acceptances map[ids.ID]int
+
+	latencies  []time.Duration
+	violations []string
+}
+
+func newTracker(numHonest int) *tracker {
+	return &tracker{
+		numHonest:   numHonest,
+		issued:      make(map[ids.ID]time.Duration),
+		decisions:   make(map[ids.ID]choices.Status),
+		accepted:    make(map[ids.ID]ids.ID),
+		acceptances: make(map[ids.ID]int),
+	}
+}
+
+// issue records that [containerID] was proposed at [now]
+func (t *tracker) issue(containerID ids.ID, now time.Duration) {
+	t.issued[containerID] = now
+}
+
+// accept records that an honest node accepted [containerID], which is in the
+// conflict set [conflictID], at [now]
+func (t *tracker) accept(n *node, containerID, conflictID ids.ID, now time.Duration) {
+	if issuedAt, ok := t.issued[containerID]; ok {
+		t.latencies = append(t.latencies, now-issuedAt)
+	}
+	t.acceptances[containerID]++
+
+	if status, ok := t.decisions[containerID]; ok && status != choices.Accepted {
+		t.violations = append(t.violations, fmt.Sprintf("node %d accepted %s, which was rejected by another node", n.index, containerID))
+	}
+	t.decisions[containerID] = choices.Accepted
+
+	if acceptedID, ok := t.accepted[conflictID]; !ok {
+		t.accepted[conflictID] = containerID
+	} else if acceptedID != containerID {
+		t.violations = append(t.violations, fmt.Sprintf("node %d accepted %s, which conflicts with %s accepted by another node", n.index, containerID, acceptedID))
+	}
+}
+
+// reject records that an honest node rejected [containerID]
+func (t *tracker) reject(n *node, containerID ids.ID) {
+	if status, ok := t.decisions[containerID]; ok && status != choices.Rejected {
+		t.violations = append(t.violations, fmt.Sprintf("node %d rejected %s, which was accepted by another node", n.index, containerID))
+		return
+	}
+	t.decisions[containerID] = choices.Rejected
+}
+
+// result returns the result of the simulation so far
+func (t *tracker) result() *Result {
+	r := &Result{
+		Issued:     len(t.issued),
+		Latencies:  make([]time.Duration, len(t.latencies)),
+		Safe:       len(t.violations) == 0,
+		Violations: t.violations,
+	}
+	for containerID := range t.issued {
+		if t.acceptances[containerID] == t.numHonest {
+			r.Finalized++
+		}
+	}
+	copy(r.Latencies, t.latencies)
+	sort.Slice(r.Latencies, func(i, j int) bool { return r.Latencies[i] < r.Latencies[j] })
+	return r
+}
@@ -0,0 +1,72 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"container/heap"
+	"time"
+)
+
+// event is a function that runs at a point in virtual time
+type event struct {
+	time time.Duration
+	// seq orders the events that run at the same time by when they were
+	// scheduled
+	seq uint64
+	run func()
+}
+
+// eventQueue is a min-heap of events ordered by time, and then by seq
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].time != q[j].time {
+		return q[i].time < q[j].time
+	}
+	return q[i].seq < q[j].seq
+}
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return e
+}
+
+// scheduler is a virtual clock that runs events in the order of their times.
+// As events run one at a time, the simulation doesn't depend on the wall
+// clock or on goroutine scheduling.
+type scheduler struct {
+	now    time.Duration
+	seq    uint64
+	events eventQueue
+}
+
+// schedule [run] to run [delay] after the current time
+func (s *scheduler) schedule(delay time.Duration, run func()) {
+	s.seq++
+	heap.Push(&s.events, &event{
+		time: s.now + delay,
+		seq:  s.seq,
+		run:  run,
+	})
+}
+
+// runNext advances the clock to the next event and runs it. Returns false if
+// there are no events or if the next event is after [maxTime].
+func (s *scheduler) runNext(maxTime time.Duration) bool {
+	if len(s.events) == 0 || s.events[0].time > maxTime {
+		return false
+	}
+	e := heap.Pop(&s.events).(*event)
+	s.now = e.time
+	e.run()
+	return true
+}
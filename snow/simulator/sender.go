@@ -0,0 +1,256 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+const (
+	// gossipSize is the number of nodes that a gossiped container is sent to
+	gossipSize = 50
+)
+
+// requestKey identifies a request by the node it was sent to and its ID
+type requestKey struct {
+	node      int
+	requestID uint32
+}
+
+// sender implements the common.Sender interface by sending messages over the
+// simulated network. Like the chain router, it fails requests that aren't
+// answered before the timeout and drops responses that weren't requested.
+type sender struct {
+	sim  *simulation
+	node *node
+
+	// requests that were sent but haven't been answered or failed yet
+	outstanding map[requestKey]struct{}
+}
+
+func newSender(sim *simulation, n *node) *sender {
+	return &sender{
+		sim:         sim,
+		node:        n,
+		outstanding: make(map[requestKey]struct{}),
+	}
+}
+
+// GetAcceptedFrontier implements the common.Sender interface
+func (s *sender) GetAcceptedFrontier(validatorIDs ids.ShortSet, requestID uint32) {
+	from := s.node.id
+	for _, to := range s.sim.indices(validatorIDs) {
+		to := to
+		s.request(to, requestID, func(e common.Engine) error {
+			return e.GetAcceptedFrontierFailed(s.sim.nodes[to].id, requestID)
+		}, func(e common.Engine) error {
+			return e.GetAcceptedFrontier(from, requestID)
+		})
+	}
+}
+
+// AcceptedFrontier implements the common.Sender interface
+func (s *sender) AcceptedFrontier(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) {
+	from := s.node.id
+	s.respond(validatorID, requestID, func(e common.Engine) error {
+		return e.AcceptedFrontier(from, requestID, containerIDs)
+	})
+}
+
+// GetAccepted implements the common.Sender interface
+func (s *sender) GetAccepted(validatorIDs ids.ShortSet, requestID uint32, containerIDs []ids.ID) {
+	from := s.node.id
+	for _, to := range s.sim.indices(validatorIDs) {
+		to := to
+		s.request(to, requestID, func(e common.Engine) error {
+			return e.GetAcceptedFailed(s.sim.nodes[to].id, requestID)
+		}, func(e common.Engine) error {
+			return e.GetAccepted(from, requestID, containerIDs)
+		})
+	}
+}
+
+// Accepted implements the common.Sender interface
+func (s *sender) Accepted(validatorID ids.ShortID, requestID uint32, containerIDs []ids.ID) {
+	from := s.node.id
+	s.respond(validatorID, requestID, func(e common.Engine) error {
+		return e.Accepted(from, requestID, containerIDs)
+	})
+}
+
+// Get implements the common.Sender interface
+func (s *sender) Get(validatorID ids.ShortID, requestID uint32, containerID ids.ID) {
+	to, ok := s.sim.index(validatorID)
+	if !ok {
+		return
+	}
+	from := s.node.id
+	s.request(to, requestID, func(e common.Engine) error {
+		return e.GetFailed(validatorID, requestID)
+	}, func(e common.Engine) error {
+		return e.Get(from, requestID, containerID)
+	})
+}
+
+// GetAncestors implements the common.Sender interface
+func (s *sender) GetAncestors(validatorID ids.ShortID, requestID uint32, containerID ids.ID) {
+	to, ok := s.sim.index(validatorID)
+	if !ok {
+		return
+	}
+	from := s.node.id
+	s.request(to, requestID, func(e common.Engine) error {
+		return e.GetAncestorsFailed(validatorID, requestID)
+	}, func(e common.Engine) error {
+		return e.GetAncestors(from, requestID, containerID)
+	})
+}
+
+// Put implements the common.Sender interface
+func (s *sender) Put(validatorID ids.ShortID, requestID uint32, containerID ids.ID, container []byte) {
+	from := s.node.id
+	s.respond(validatorID, requestID, func(e common.Engine) error {
+		return e.Put(from, requestID, containerID, container)
+	})
+}
+
+// MultiPut implements the common.Sender interface
+func (s *sender) MultiPut(validatorID ids.ShortID, requestID uint32, containers [][]byte) {
+	from := s.node.id
+	s.respond(validatorID, requestID, func(e common.Engine) error {
+		return e.MultiPut(from, requestID, containers)
+	})
+}
+
+// PushQuery implements the common.Sender interface
+func (s *sender) PushQuery(validatorIDs ids.ShortSet, requestID uint32, containerID ids.ID, container []byte) {
+	from := s.node.id
+	for _, to := range s.sim.indices(validatorIDs) {
+		to := to
+		s.request(to, requestID, func(e common.Engine) error {
+			return e.QueryFailed(s.sim.nodes[to].id, requestID)
+		}, func(e common.Engine) error {
+			return e.PushQuery(from, requestID, containerID, container)
+		})
+	}
+}
+
+// PullQuery implements the common.Sender interface
+func (s *sender) PullQuery(validatorIDs ids.ShortSet, requestID uint32, containerID ids.ID) {
+	from := s.node.id
+	for _, to := range s.sim.indices(validatorIDs) {
+		to := to
+		s.request(to, requestID, func(e common.Engine) error {
+			return e.QueryFailed(s.sim.nodes[to].id, requestID)
+		}, func(e common.Engine) error {
+			return e.PullQuery(from, requestID, containerID)
+		})
+	}
+}
+
+// Chits implements the common.Sender interface
+func (s *sender) Chits(validatorID ids.ShortID, requestID uint32, votes []ids.ID) {
+	from := s.node.id
+	s.respond(validatorID, requestID, func(e common.Engine) error {
+		return e.Chits(from, requestID, votes)
+	})
+}
+
+// Gossip implements the common.Sender interface
+func (s *sender) Gossip(containerID ids.ID, container []byte) {
+	from := s.node.id
+	for _, to := range s.sim.peers(s.node.index, gossipSize) {
+		to := to
+		s.sim.network.send(s.node.index, to, func() {
+			s.sim.deliver(to, func(e common.Engine) error {
+				return e.Put(from, constants.GossipMsgRequestID, containerID, container)
+			})
+		})
+	}
+}
+
+// AppRequest implements the common.Sender interface
+func (s *sender) AppRequest(nodeIDs ids.ShortSet, requestID uint32, appRequestBytes []byte) {
+	from := s.node.id
+	for _, to := range s.sim.indices(nodeIDs) {
+		to := to
+		s.request(to, requestID, func(e common.Engine) error {
+			return e.AppRequestFailed(s.sim.nodes[to].id, requestID)
+		}, func(e common.Engine) error {
+			return e.AppRequest(from, requestID, appRequestBytes)
+		})
+	}
+}
+
+// AppResponse implements the common.Sender interface
+func (s *sender) AppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte) {
+	from := s.node.id
+	s.respond(nodeID, requestID, func(e common.Engine) error {
+		return e.AppResponse(from, requestID, appResponseBytes)
+	})
+}
+
+// AppGossip implements the common.Sender interface
+func (s *sender) AppGossip(appGossipBytes []byte) {
+	from := s.node.id
+	for _, to := range s.sim.peers(s.node.index, gossipSize) {
+		to := to
+		s.sim.network.send(s.node.index, to, func() {
+			s.sim.deliver(to, func(e common.Engine) error {
+				return e.AppGossip(from, appGossipBytes)
+			})
+		})
+	}
+}
+
+// request sends a request to node [to], and calls [failed] on this node's
+// engine if it isn't answered before the request timeout
+func (s *sender) request(to int, requestID uint32, failed, handle func(common.Engine) error) {
+	key := requestKey{
+		node:      to,
+		requestID: requestID,
+	}
+	s.outstanding[key] = struct{}{}
+	s.sim.scheduler.schedule(s.sim.config.RequestTimeout, func() {
+		if _, ok := s.outstanding[key]; !ok {
+			return
+		}
+		delete(s.outstanding, key)
+		s.sim.deliver(s.node.index, failed)
+	})
+
+	s.sim.network.send(s.node.index, to, func() {
+		s.sim.deliver(to, handle)
+	})
+}
+
+// respond sends a response to node [validatorID]. The response is dropped on
+// arrival if it wasn't requested, or if the request already failed.
+func (s *sender) respond(validatorID ids.ShortID, requestID uint32, handle func(common.Engine) error) {
+	to, ok := s.sim.index(validatorID)
+	if !ok {
+		return
+	}
+	if requestID == constants.GossipMsgRequestID {
+		s.sim.network.send(s.node.index, to, func() {
+			s.sim.deliver(to, handle)
+		})
+		return
+	}
+
+	key := requestKey{
+		node:      s.node.index,
+		requestID: requestID,
+	}
+	s.sim.network.send(s.node.index, to, func() {
+		requester := s.sim.nodes[to].sender
+		if _, ok := requester.outstanding[key]; !ok {
+			return
+		}
+		delete(requester.outstanding, key)
+		s.sim.deliver(to, handle)
+	})
+}
@@ -0,0 +1,333 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs networks of in-process nodes that each run the full
+// snowman or avalanche consensus engine. Messages are delivered in virtual
+// time with configurable latency, loss and partitions, and Byzantine nodes can
+// deviate from the protocol. The result reports the time to finality and
+// whether the honest nodes' decisions were consistent, which can be used to
+// evaluate consensus parameters before they're deployed.
+//
+// Time is virtual and every random choice the simulation makes, such as
+// message latencies, lost messages, the proposers of containers and the
+// validators sampled for polls, comes from its seed. However, the consensus
+// implementations iterate over maps when they count votes, and the avalanche
+// engine selects the parents of vertices with the global source of randomness,
+// so runs with the same seed may decide at slightly different times.
+package simulator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	avaeng "github.com/ava-labs/avalanchego/snow/engine/avalanche"
+	avabootstrap "github.com/ava-labs/avalanchego/snow/engine/avalanche/bootstrap"
+	avastate "github.com/ava-labs/avalanchego/snow/engine/avalanche/state"
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+	smbootstrap "github.com/ava-labs/avalanchego/snow/engine/snowman/bootstrap"
+)
+
+// chain is the part of a node that depends on its consensus engine
+type chain interface {
+	// propose a new container to the node
+	propose() error
+
+	// accepted returns an ID that is the same for nodes that accepted the
+	// same containers
+	accepted() ids.ID
+}
+
+// node is a simulated validator
+type node struct {
+	index     int
+	id        ids.ShortID
+	byzantine bool
+
+	engine common.Engine
+	sender *sender
+	chain  chain
+
+	// finalized returns true if the node's consensus has no processing
+	// containers
+	finalized func() bool
+}
+
+// simulation is the state of a simulated network
+type simulation struct {
+	config    Config
+	rng       *rand.Rand
+	scheduler *scheduler
+	network   *network
+	tracker   *tracker
+
+	nodes   []*node
+	indexOf map[[20]byte]int
+	honest  []int
+
+	numProposed int
+	// inputs that were spent by proposed transactions
+	inputs []ids.ID
+
+	// err is the first error returned by an engine
+	err error
+}
+
+// Run simulates the network described by [config] until every honest node
+// has decided every proposed container, or until the maximum time.
+func Run(config Config) (*Result, error) {
+	if err := config.Valid(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed)) // #nosec G404
+	sim := &simulation{
+		config:    config,
+		rng:       rng,
+		scheduler: &scheduler{},
+		tracker:   newTracker(config.Nodes - len(config.Byzantine)),
+		indexOf:   make(map[[20]byte]int, config.Nodes),
+	}
+	sim.network = newNetwork(config.Network, rng, sim.scheduler)
+
+	vdrs := &seededSet{
+		validatorSet: validators.NewSet(),
+		rng:          rng,
+	}
+	for i := 0; i < config.Nodes; i++ {
+		nodeID := [20]byte{}
+		binary.BigEndian.PutUint32(nodeID[:], uint32(i))
+		n := &node{
+			index: i,
+			id:    ids.NewShortID(nodeID),
+		}
+		_, n.byzantine = config.Byzantine[i]
+		if !n.byzantine {
+			sim.honest = append(sim.honest, i)
+		}
+		sim.nodes = append(sim.nodes, n)
+		sim.indexOf[nodeID] = i
+
+		weight := uint64(1)
+		if len(config.Weights) != 0 {
+			weight = config.Weights[i]
+		}
+		if err := vdrs.AddWeight(n.id, weight); err != nil {
+			return nil, err
+		}
+	}
+	for _, n := range sim.nodes {
+		if err := sim.initialize(n, vdrs); err != nil {
+			return nil, fmt.Errorf("couldn't initialize node %d: %w", n.index, err)
+		}
+	}
+
+	for i := 0; i < config.Containers; i++ {
+		sim.scheduler.schedule(time.Duration(i)*config.IssueInterval, sim.propose)
+	}
+	if config.GossipFrequency > 0 {
+		for _, n := range sim.nodes {
+			sim.scheduleGossip(n, time.Duration(rng.Int63n(int64(config.GossipFrequency))))
+		}
+	}
+
+	decided := sim.decided()
+	for sim.err == nil && !decided && sim.scheduler.runNext(config.MaxTime) {
+		decided = sim.decided()
+	}
+
+	result := sim.tracker.result()
+	result.Time = sim.scheduler.now
+	result.Decided = decided
+	result.Sent = sim.network.numSent
+	result.Dropped = sim.network.numDropped
+	return result, sim.err
+}
+
+// initialize the engine of node [n], which bootstraps immediately as there
+// are no beacons
+func (sim *simulation) initialize(n *node, vdrs validators.Set) error {
+	ctx := snow.DefaultContextTest()
+	ctx.NodeID = n.id
+
+	n.sender = newSender(sim, n)
+	var engineSender common.Sender = n.sender
+	if behavior, ok := sim.config.Byzantine[n.index]; ok {
+		engineSender = behavior.Sender(n.sender, sim.rng)
+	}
+
+	commonConfig := common.Config{
+		Ctx:        ctx,
+		Validators: vdrs,
+		Beacons:    validators.NewSet(),
+		Sender:     engineSender,
+	}
+
+	// Each node registers its metrics separately
+	params := sim.config.Params
+	params.Namespace = ""
+	params.Metrics = prometheus.NewRegistry()
+
+	if sim.config.DAG {
+		vtxBlocked, err := queue.New(memdb.New())
+		if err != nil {
+			return err
+		}
+		txBlocked, err := queue.New(memdb.New())
+		if err != nil {
+			return err
+		}
+		vm := newDAGVM(sim, n)
+		manager := &avastate.Serializer{}
+		manager.Initialize(ctx, vm, memdb.New())
+
+		engine := &avaeng.Transitive{}
+		n.engine = engine
+		n.chain = vm
+		n.finalized = func() bool { return engine.Consensus.Finalized() }
+		return engine.Initialize(avaeng.Config{
+			Config: avabootstrap.Config{
+				Config:     commonConfig,
+				VtxBlocked: vtxBlocked,
+				TxBlocked:  txBlocked,
+				Manager:    manager,
+				VM:         vm,
+			},
+			Params:    params,
+			Consensus: &avalanche.Topological{},
+		})
+	}
+
+	blocked, err := queue.New(memdb.New())
+	if err != nil {
+		return err
+	}
+	vm, err := newBlockVM(sim, n)
+	if err != nil {
+		return err
+	}
+
+	engine := &smeng.Transitive{}
+	n.engine = engine
+	n.chain = vm
+	n.finalized = func() bool { return engine.Consensus.Finalized() }
+	return engine.Initialize(smeng.Config{
+		Config: smbootstrap.Config{
+			Config:  commonConfig,
+			Blocked: blocked,
+			VM:      vm,
+		},
+		Params:    params.Parameters,
+		Consensus: &snowman.Topological{},
+	})
+}
+
+// propose a new container to a random honest node
+func (sim *simulation) propose() {
+	sim.numProposed++
+	index := sim.honest[sim.rng.Intn(len(sim.honest))]
+	sim.deliver(index, func(common.Engine) error {
+		return sim.nodes[index].chain.propose()
+	})
+}
+
+// scheduleGossip schedules node [n] to gossip after [delay], and then every
+// gossip period
+func (sim *simulation) scheduleGossip(n *node, delay time.Duration) {
+	sim.scheduler.schedule(delay, func() {
+		sim.deliver(n.index, common.Engine.Gossip)
+		sim.scheduleGossip(n, sim.config.GossipFrequency)
+	})
+}
+
+// newTx returns the bytes of a new transaction. With probability
+// ConflictRate, it spends the input of an earlier transaction.
+func (sim *simulation) newTx() []byte {
+	var inputID ids.ID
+	if len(sim.inputs) > 0 && sim.rng.Float64() < sim.config.ConflictRate {
+		inputID = sim.inputs[sim.rng.Intn(len(sim.inputs))]
+	} else {
+		inputID = ids.Empty.Prefix(uint64(len(sim.inputs)))
+		sim.inputs = append(sim.inputs, inputID)
+	}
+
+	p := wrappers.Packer{Bytes: make([]byte, txLen)}
+	p.PackFixedBytes(inputID[:])
+	p.PackLong(uint64(sim.numProposed))
+	return p.Bytes
+}
+
+// deliver calls [handle] on the engine of node [index]. After an engine
+// returns an error, nothing else is delivered.
+func (sim *simulation) deliver(index int, handle func(common.Engine) error) {
+	if sim.err != nil {
+		return
+	}
+	if err := handle(sim.nodes[index].engine); err != nil {
+		sim.err = fmt.Errorf("node %d failed at %s: %w", index, sim.scheduler.now, err)
+	}
+}
+
+// decided returns true if every container was proposed, and every honest node
+// has no processing containers and accepted the same containers
+func (sim *simulation) decided() bool {
+	if sim.numProposed < sim.config.Containers {
+		return false
+	}
+	accepted := sim.nodes[sim.honest[0]].chain.accepted()
+	for _, index := range sim.honest {
+		n := sim.nodes[index]
+		if !n.finalized() || n.chain.accepted() != accepted {
+			return false
+		}
+	}
+	return true
+}
+
+// index returns the index of the node with ID [nodeID]
+func (sim *simulation) index(nodeID ids.ShortID) (int, bool) {
+	index, ok := sim.indexOf[nodeID.Key()]
+	return index, ok
+}
+
+// indices returns the indices of the nodes in [nodeIDs] in increasing order,
+// so that the order messages are sent in doesn't depend on the order of
+// iteration over the set
+func (sim *simulation) indices(nodeIDs ids.ShortSet) []int {
+	indices := make([]int, 0, nodeIDs.Len())
+	for nodeID := range nodeIDs {
+		if index, ok := sim.indexOf[nodeID]; ok {
+			indices = append(indices, index)
+		}
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// peers returns the indices of up to [size] random nodes other than [index]
+func (sim *simulation) peers(index, size int) []int {
+	peers := make([]int, 0, size)
+	for _, peer := range sim.rng.Perm(len(sim.nodes)) {
+		if len(peers) == size {
+			break
+		}
+		if peer != index {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
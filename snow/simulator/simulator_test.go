@@ -0,0 +1,316 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// testConfig returns a small network that finalizes quickly
+func testConfig() Config {
+	config := DefaultConfig()
+	config.Nodes = 10
+	config.Params.K = 5
+	config.Params.Alpha = 4
+	config.Params.BetaVirtuous = 5
+	config.Params.BetaRogue = 10
+	config.Params.ConcurrentRepolls = 2
+	config.Params.Parents = 2
+	config.Params.BatchSize = 5
+	config.Containers = 5
+	config.MaxTime = time.Minute
+	return config
+}
+
+func TestRunSnowman(t *testing.T) {
+	config := testConfig()
+
+	result, err := Run(config)
+	assert.NoError(t, err)
+	assert.True(t, result.Decided)
+	assert.True(t, result.Safe)
+	assert.Empty(t, result.Violations)
+	assert.Equal(t, config.Containers, result.Issued)
+	// Blocks that were built on the same parent conflict, so only one of
+	// them is accepted
+	assert.NotZero(t, result.Finalized)
+	assert.True(t, result.Finalized <= result.Issued)
+	assert.Len(t, result.Latencies, result.Finalized*config.Nodes)
+	assert.True(t, result.Finality(50) > 0)
+	assert.True(t, result.Finality(50) <= result.Finality(99))
+	assert.Zero(t, result.Dropped)
+}
+
+func TestNetworkDeterministic(t *testing.T) {
+	config := NetworkConfig{
+		MinLatency: time.Millisecond,
+		MaxLatency: 10 * time.Millisecond,
+		DropRate:   0.2,
+		Partitions: []Partition{{
+			Start:  5 * time.Millisecond,
+			End:    20 * time.Millisecond,
+			Groups: [][]int{{0}, {1}},
+		}},
+	}
+	deliveries := func(seed int64) []time.Duration {
+		s := &scheduler{}
+		n := newNetwork(config, rand.New(rand.NewSource(seed)), s) // #nosec G404
+		delivered := []time.Duration(nil)
+		for i := 0; i < 100; i++ {
+			s.schedule(time.Duration(i)*time.Millisecond, func() {
+				n.send(0, 1, func() { delivered = append(delivered, s.now) })
+				n.send(1, 1, func() { delivered = append(delivered, s.now) })
+			})
+		}
+		for s.runNext(time.Second) {
+		}
+		assert.Equal(t, uint64(100), n.numSent)
+		assert.True(t, n.numDropped >= 15)
+		assert.Len(t, delivered, 200-int(n.numDropped))
+		return delivered
+	}
+
+	first := deliveries(5)
+	assert.Equal(t, first, deliveries(5))
+	assert.NotEqual(t, first, deliveries(6))
+}
+
+func TestRunSnowmanPartition(t *testing.T) {
+	config := testConfig()
+	config.Containers = 10
+	config.GossipFrequency = time.Second
+	config.Network.Partitions = []Partition{{
+		Start:  0,
+		End:    5 * time.Second,
+		Groups: [][]int{{0, 1, 2, 3, 4}, {5, 6, 7, 8, 9}},
+	}}
+
+	result, err := Run(config)
+	assert.NoError(t, err)
+	assert.True(t, result.Decided)
+	assert.True(t, result.Safe)
+	assert.NotZero(t, result.Dropped)
+	// Neither side has alpha nodes, so nothing can be decided until the
+	// partition heals
+	assert.True(t, result.Finality(0) >= 5*time.Second-time.Duration(config.Containers)*config.IssueInterval)
+}
+
+func TestRunSnowmanByzantine(t *testing.T) {
+	behaviors := map[string]Behavior{
+		"mute":         Mute{},
+		"random chits": RandomChits{},
+		"withhold":     Withhold{},
+	}
+	for name, behavior := range behaviors {
+		behavior := behavior
+		t.Run(name, func(t *testing.T) {
+			config := testConfig()
+			config.Byzantine = map[int]Behavior{
+				0: behavior,
+				1: behavior,
+			}
+
+			result, err := Run(config)
+			assert.NoError(t, err)
+			assert.True(t, result.Decided)
+			assert.True(t, result.Safe)
+			assert.NotZero(t, result.Finalized)
+		})
+	}
+}
+
+func TestRunAvalanche(t *testing.T) {
+	config := testConfig()
+	config.DAG = true
+	config.Containers = 20
+	config.ConflictRate = 0.3
+
+	result, err := Run(config)
+	assert.NoError(t, err)
+	assert.True(t, result.Decided)
+	assert.True(t, result.Safe)
+	assert.Equal(t, config.Containers, result.Issued)
+	// Conflicting transactions may be rejected, but the first transaction is
+	// always virtuous
+	assert.NotZero(t, result.Finalized)
+}
+
+func TestRunMaxTime(t *testing.T) {
+	config := testConfig()
+	config.Byzantine = map[int]Behavior{}
+	for i := 0; i < 7; i++ {
+		config.Byzantine[i] = Mute{}
+	}
+	config.MaxTime = 10 * time.Second
+
+	// Only 3 nodes respond to queries, which is less than alpha
+	result, err := Run(config)
+	assert.NoError(t, err)
+	assert.False(t, result.Decided)
+	assert.True(t, result.Safe)
+	assert.Zero(t, result.Finalized)
+	assert.True(t, result.Time <= config.MaxTime)
+}
+
+func TestConfigValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    error
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "no nodes",
+			modify: func(c *Config) { c.Nodes = 0 },
+			err:    errNoNodes,
+		},
+		{
+			name:   "wrong number of weights",
+			modify: func(c *Config) { c.Weights = []uint64{1} },
+			err:    errWrongNumWeights,
+		},
+		{
+			name: "zero weight",
+			modify: func(c *Config) {
+				c.Weights = make([]uint64, c.Nodes)
+			},
+			err: errZeroWeight,
+		},
+		{
+			name:   "insufficient weight",
+			modify: func(c *Config) { c.Nodes = c.Params.K - 1 },
+			err:    errInsufficientWeight,
+		},
+		{
+			name: "no honest nodes",
+			modify: func(c *Config) {
+				c.Nodes = 1
+				c.Byzantine = map[int]Behavior{0: Mute{}}
+			},
+			err: errNoHonestNodes,
+		},
+		{
+			name: "unknown Byzantine node",
+			modify: func(c *Config) {
+				c.Byzantine = map[int]Behavior{c.Nodes: Mute{}}
+			},
+			err: errUnknownNode,
+		},
+		{
+			name: "unknown partitioned node",
+			modify: func(c *Config) {
+				c.Network.Partitions = []Partition{{Groups: [][]int{{-1}}}}
+			},
+			err: errUnknownNode,
+		},
+		{
+			name:   "drop rate",
+			modify: func(c *Config) { c.Network.DropRate = 1 },
+			err:    errInvalidDropRate,
+		},
+		{
+			name:   "latency",
+			modify: func(c *Config) { c.Network.MinLatency = c.Network.MaxLatency + 1 },
+			err:    errInvalidLatency,
+		},
+		{
+			name:   "conflict rate",
+			modify: func(c *Config) { c.ConflictRate = -1 },
+			err:    errInvalidConflicts,
+		},
+		{
+			name:   "request timeout",
+			modify: func(c *Config) { c.RequestTimeout = 0 },
+			err:    errNoRequestTimeout,
+		},
+		{
+			name:   "max time",
+			modify: func(c *Config) { c.MaxTime = 0 },
+			err:    errNoMaxTime,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig()
+			test.modify(&config)
+
+			err := config.Valid()
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, test.err), "expected %s but got %v", test.err, err)
+			}
+		})
+	}
+
+	config := DefaultConfig()
+	config.Params.Alpha = config.Params.K + 1
+	assert.Error(t, config.Valid())
+
+	// Only the avalanche engine uses Parents
+	config = DefaultConfig()
+	config.Params.Parents = 0
+	assert.NoError(t, config.Valid())
+	config.DAG = true
+	assert.Error(t, config.Valid())
+}
+
+func TestRunWeighted(t *testing.T) {
+	config := testConfig()
+	config.Weights = []uint64{1, 1, 1, 1, 1, 1, 1, 1, 1, 100}
+
+	result, err := Run(config)
+	assert.NoError(t, err)
+	assert.True(t, result.Decided)
+	assert.True(t, result.Safe)
+}
+
+func TestSeededSetSample(t *testing.T) {
+	sample := func(seed int64) []int {
+		vdrs := &seededSet{
+			validatorSet: validators.NewSet(),
+			rng:          rand.New(rand.NewSource(seed)), // #nosec G404
+		}
+		for i := 0; i < 4; i++ {
+			nodeID := [20]byte{byte(i)}
+			weight := uint64(1)
+			if i == 3 {
+				weight = 100
+			}
+			assert.NoError(t, vdrs.AddWeight(ids.NewShortID(nodeID), weight))
+		}
+
+		_, err := vdrs.Sample(104)
+		assert.Equal(t, errInsufficientWeight, err)
+
+		counts := make([]int, 4)
+		for i := 0; i < 100; i++ {
+			sampled, err := vdrs.Sample(2)
+			assert.NoError(t, err)
+			assert.Len(t, sampled, 2)
+			for _, vdr := range sampled {
+				nodeID := vdr.ID().Key()
+				counts[nodeID[0]]++
+			}
+		}
+		return counts
+	}
+
+	counts := sample(5)
+	assert.Equal(t, counts, sample(5))
+	// The heavy validator is sampled at least once in almost every sample
+	assert.True(t, counts[3] > 95, "heavy validator was sampled %d times", counts[3])
+}
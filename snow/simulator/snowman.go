@@ -0,0 +1,238 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// blockLen is the length of a block's bytes: its parent's ID, its height,
+	// the index of the node that built it and the nonce of that node
+	blockLen = 32 + wrappers.LongLen + wrappers.IntLen + wrappers.LongLen
+)
+
+var (
+	errNoPendingBlocks = errors.New("no blocks are pending")
+	errUnknownBlock    = errors.New("unknown block")
+	errWrongHeight     = errors.New("block height isn't one more than its parent's")
+	errUnverifiedBlock = errors.New("block's parent hasn't been verified")
+
+	_ block.ChainVM = &blockVM{}
+	_ snowman.Block = &simBlock{}
+	_ chain         = &blockVM{}
+)
+
+// blockVM is a snowman VM whose blocks have no contents, so any two blocks at
+// the same height conflict
+type blockVM struct {
+	sim  *simulation
+	node *node
+
+	blocks       map[ids.ID]*simBlock
+	preferred    ids.ID
+	lastAccepted ids.ID
+
+	// number of blocks that the node was asked to propose but hasn't built
+	pending int
+	nonce   uint64
+}
+
+func newBlockVM(sim *simulation, n *node) (*blockVM, error) {
+	vm := &blockVM{
+		sim:    sim,
+		node:   n,
+		blocks: make(map[ids.ID]*simBlock),
+	}
+	genesis, err := vm.parse(make([]byte, blockLen))
+	if err != nil {
+		return nil, err
+	}
+	genesis.status = choices.Accepted
+	genesis.verified = true
+	vm.preferred = genesis.id
+	vm.lastAccepted = genesis.id
+	return vm, nil
+}
+
+// Initialize implements the common.VM interface
+func (vm *blockVM) Initialize(*snow.Context, database.Database, []byte, chan<- common.Message, []*common.Fx) error {
+	return nil
+}
+
+// Bootstrapping implements the common.VM interface
+func (vm *blockVM) Bootstrapping() error { return nil }
+
+// Bootstrapped implements the common.VM interface
+func (vm *blockVM) Bootstrapped() error { return nil }
+
+// Shutdown implements the common.VM interface
+func (vm *blockVM) Shutdown() error { return nil }
+
+// CreateHandlers implements the common.VM interface
+func (vm *blockVM) CreateHandlers() map[string]*common.HTTPHandler { return nil }
+
+// Health implements the common.VM interface
+func (vm *blockVM) Health() (interface{}, error) { return nil, nil }
+
+// BuildBlock implements the block.ChainVM interface
+func (vm *blockVM) BuildBlock() (snowman.Block, error) {
+	if vm.pending == 0 {
+		return nil, errNoPendingBlocks
+	}
+	vm.pending--
+
+	parent := vm.blocks[vm.preferred]
+	p := wrappers.Packer{Bytes: make([]byte, blockLen)}
+	p.PackFixedBytes(parent.id[:])
+	p.PackLong(parent.height + 1)
+	p.PackInt(uint32(vm.node.index))
+	p.PackLong(vm.nonce)
+	if p.Errored() {
+		return nil, p.Err
+	}
+	vm.nonce++
+
+	blk, err := vm.parse(p.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	vm.sim.tracker.issue(blk.id, vm.sim.scheduler.now)
+	return blk, nil
+}
+
+// ParseBlock implements the block.ChainVM interface
+func (vm *blockVM) ParseBlock(b []byte) (snowman.Block, error) { return vm.parse(b) }
+
+// GetBlock implements the block.ChainVM interface
+func (vm *blockVM) GetBlock(blkID ids.ID) (snowman.Block, error) {
+	if blk, ok := vm.blocks[blkID]; ok {
+		return blk, nil
+	}
+	return nil, errUnknownBlock
+}
+
+// SetPreference implements the block.ChainVM interface
+func (vm *blockVM) SetPreference(blkID ids.ID) { vm.preferred = blkID }
+
+// LastAccepted implements the block.ChainVM interface
+func (vm *blockVM) LastAccepted() ids.ID { return vm.lastAccepted }
+
+// propose implements the chain interface
+func (vm *blockVM) propose() error {
+	vm.pending++
+	return vm.node.engine.Notify(common.PendingTxs)
+}
+
+// accepted implements the chain interface
+func (vm *blockVM) accepted() ids.ID { return vm.lastAccepted }
+
+func (vm *blockVM) parse(b []byte) (*simBlock, error) {
+	blkID := hashing.ComputeHash256Array(b)
+	if blk, ok := vm.blocks[blkID]; ok {
+		return blk, nil
+	}
+
+	p := wrappers.Packer{Bytes: b}
+	parentID, err := ids.ToID(p.UnpackFixedBytes(32))
+	if err != nil {
+		return nil, err
+	}
+	height := p.UnpackLong()
+	_ = p.UnpackInt()
+	_ = p.UnpackLong()
+	if p.Errored() {
+		return nil, p.Err
+	}
+	if p.Offset != len(b) {
+		return nil, fmt.Errorf("block has %d unexpected bytes", len(b)-p.Offset)
+	}
+
+	blk := &simBlock{
+		vm:       vm,
+		id:       blkID,
+		parentID: parentID,
+		height:   height,
+		bytes:    b,
+		status:   choices.Processing,
+	}
+	vm.blocks[blkID] = blk
+	return blk, nil
+}
+
+// simBlock is a block of a blockVM
+type simBlock struct {
+	vm       *blockVM
+	id       ids.ID
+	parentID ids.ID
+	height   uint64
+	bytes    []byte
+	status   choices.Status
+	verified bool
+}
+
+// ID implements the snowman.Block interface
+func (b *simBlock) ID() ids.ID { return b.id }
+
+// Accept implements the snowman.Block interface
+func (b *simBlock) Accept() error {
+	b.status = choices.Accepted
+	b.vm.lastAccepted = b.id
+	if !b.vm.node.byzantine {
+		// Every block at the same height conflicts
+		b.vm.sim.tracker.accept(b.vm.node, b.id, ids.Empty.Prefix(b.height), b.vm.sim.scheduler.now)
+	}
+	return nil
+}
+
+// Reject implements the snowman.Block interface
+func (b *simBlock) Reject() error {
+	b.status = choices.Rejected
+	if !b.vm.node.byzantine {
+		b.vm.sim.tracker.reject(b.vm.node, b.id)
+	}
+	return nil
+}
+
+// Status implements the snowman.Block interface
+func (b *simBlock) Status() choices.Status { return b.status }
+
+// Parent implements the snowman.Block interface
+func (b *simBlock) Parent() snowman.Block {
+	if parent, ok := b.vm.blocks[b.parentID]; ok {
+		return parent
+	}
+	return &simBlock{
+		vm:     b.vm,
+		id:     b.parentID,
+		status: choices.Unknown,
+	}
+}
+
+// Verify implements the snowman.Block interface
+func (b *simBlock) Verify() error {
+	parent, ok := b.vm.blocks[b.parentID]
+	switch {
+	case !ok || !parent.verified:
+		return errUnverifiedBlock
+	case b.height != parent.height+1:
+		return errWrongHeight
+	}
+	b.verified = true
+	return nil
+}
+
+// Bytes implements the snowman.Block interface
+func (b *simBlock) Bytes() []byte { return b.bytes }
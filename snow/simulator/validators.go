@@ -0,0 +1,63 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// validatorSet is embedded under another name so that the Set method of
+// validators.Set isn't shadowed by the embedded field
+type validatorSet = validators.Set
+
+// seededSet is a validator set that samples with the simulation's source of
+// randomness, rather than the global one, so that the validators that are
+// polled are determined by the seed
+type seededSet struct {
+	validatorSet
+	rng *rand.Rand
+}
+
+// Sample implements the validators.Set interface. Like the validators.Set
+// sampler, it samples weight without replacement, so a validator may be
+// sampled more than once.
+func (s *seededSet) Sample(size int) ([]validators.Validator, error) {
+	vdrs := s.List()
+	// cumulativeWeights[i] is the total weight of the first i+1 validators
+	cumulativeWeights := make([]uint64, len(vdrs))
+	totalWeight := uint64(0)
+	for i, vdr := range vdrs {
+		totalWeight += vdr.Weight()
+		cumulativeWeights[i] = totalWeight
+	}
+	if uint64(size) > totalWeight {
+		return nil, errInsufficientWeight
+	}
+
+	// Partial Fisher-Yates shuffle of [0, totalWeight), where [swapped] holds
+	// the units of weight that were moved
+	swapped := make(map[uint64]uint64, size)
+	sampled := make([]validators.Validator, size)
+	for i := 0; i < size; i++ {
+		draw := uint64(i) + uint64(s.rng.Int63n(int64(totalWeight-uint64(i))))
+		unit, ok := swapped[draw]
+		if !ok {
+			unit = draw
+		}
+		if current, ok := swapped[uint64(i)]; ok {
+			swapped[draw] = current
+		} else {
+			swapped[draw] = uint64(i)
+		}
+
+		index := sort.Search(len(cumulativeWeights), func(j int) bool {
+			return cumulativeWeights[j] > unit
+		})
+		sampled[i] = vdrs[index]
+	}
+	return sampled, nil
+}
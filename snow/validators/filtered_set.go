@@ -0,0 +1,116 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+)
+
+// SampleFilter reports which validators are currently worth sampling
+type SampleFilter interface {
+	// ShouldSample returns true if [validatorID] may be sampled
+	ShouldSample(validatorID ids.ShortID) bool
+}
+
+// filteredSet is a set of validators that, when sampled, skips the validators
+// rejected by its filter.
+type filteredSet struct {
+	vdrs   Set
+	filter SampleFilter
+}
+
+// NewFilteredSet returns a set that behaves like [vdrs], except that Sample
+// only returns validators accepted by [filter]. The sample is stake weighted
+// over the accepted validators. If the accepted validators don't have enough
+// weight to fill the sample, [vdrs] is sampled without the filter.
+func NewFilteredSet(vdrs Set, filter SampleFilter) Set {
+	return &filteredSet{
+		vdrs:   vdrs,
+		filter: filter,
+	}
+}
+
+// String implements the Set interface.
+func (s *filteredSet) String() string {
+	return s.vdrs.String()
+}
+
+// Set implements the Set interface.
+func (s *filteredSet) Set(vdrs []Validator) error {
+	return s.vdrs.Set(vdrs)
+}
+
+// AddWeight implements the Set interface.
+func (s *filteredSet) AddWeight(validatorID ids.ShortID, weight uint64) error {
+	return s.vdrs.AddWeight(validatorID, weight)
+}
+
+// GetWeight implements the Set interface.
+func (s *filteredSet) GetWeight(validatorID ids.ShortID) (uint64, bool) {
+	return s.vdrs.GetWeight(validatorID)
+}
+
+// SubsetWeight implements the Set interface.
+func (s *filteredSet) SubsetWeight(subset ids.ShortSet) (uint64, error) {
+	return s.vdrs.SubsetWeight(subset)
+}
+
+// RemoveWeight implements the Set interface.
+func (s *filteredSet) RemoveWeight(validatorID ids.ShortID, weight uint64) error {
+	return s.vdrs.RemoveWeight(validatorID, weight)
+}
+
+// Contains implements the Set interface.
+func (s *filteredSet) Contains(validatorID ids.ShortID) bool {
+	return s.vdrs.Contains(validatorID)
+}
+
+// Len implements the Set interface.
+func (s *filteredSet) Len() int {
+	return s.vdrs.Len()
+}
+
+// List implements the Set interface.
+func (s *filteredSet) List() []Validator {
+	return s.vdrs.List()
+}
+
+// Weight implements the Set interface.
+func (s *filteredSet) Weight() uint64 {
+	return s.vdrs.Weight()
+}
+
+// Sample implements the Set interface.
+func (s *filteredSet) Sample(size int) ([]Validator, error) {
+	vdrList := s.vdrs.List()
+	accepted := make([]Validator, 0, len(vdrList))
+	weights := make([]uint64, 0, len(vdrList))
+	for _, vdr := range vdrList {
+		if s.filter.ShouldSample(vdr.ID()) {
+			accepted = append(accepted, vdr)
+			weights = append(weights, vdr.Weight())
+		}
+	}
+
+	if len(accepted) == len(vdrList) {
+		return s.vdrs.Sample(size)
+	}
+
+	vdrSampler := sampler.NewWeightedWithoutReplacement()
+	if err := vdrSampler.Initialize(weights); err != nil {
+		return s.vdrs.Sample(size)
+	}
+	indices, err := vdrSampler.Sample(size)
+	if err != nil {
+		// There isn't enough accepted weight to fill the sample
+		return s.vdrs.Sample(size)
+	}
+
+	list := make([]Validator, size)
+	for i, index := range indices {
+		list[i] = accepted[index]
+	}
+	return list, nil
+}
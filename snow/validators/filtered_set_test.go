@@ -0,0 +1,78 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type testSampleFilter struct{ skipped ids.ShortSet }
+
+func (f *testSampleFilter) ShouldSample(validatorID ids.ShortID) bool {
+	return !f.skipped.Contains(validatorID)
+}
+
+func TestFilteredSetSample(t *testing.T) {
+	vdr0 := ids.GenerateTestShortID()
+	vdr1 := ids.GenerateTestShortID()
+	vdr2 := ids.GenerateTestShortID()
+
+	s := NewSet()
+	err := s.AddWeight(vdr0, math.MaxInt64-2)
+	assert.NoError(t, err)
+	err = s.AddWeight(vdr1, 1)
+	assert.NoError(t, err)
+	err = s.AddWeight(vdr2, 1)
+	assert.NoError(t, err)
+
+	filter := &testSampleFilter{}
+	filtered := NewFilteredSet(s, filter)
+	assert.Equal(t, s.Weight(), filtered.Weight())
+
+	sampled, err := filtered.Sample(1)
+	assert.NoError(t, err)
+	assert.Len(t, sampled, 1, "should have only sampled one validator")
+	assert.Equal(t, vdr0, sampled[0].ID(), "should have sampled vdr0")
+
+	filter.skipped.Add(vdr0)
+
+	sampled, err = filtered.Sample(2)
+	assert.NoError(t, err)
+	assert.Len(t, sampled, 2, "should have sampled two validators")
+	sampledIDs := ids.ShortSet{}
+	for _, vdr := range sampled {
+		sampledIDs.Add(vdr.ID())
+	}
+	assert.False(t, sampledIDs.Contains(vdr0), "shouldn't have sampled vdr0")
+	assert.True(t, sampledIDs.Contains(vdr1), "should have sampled vdr1")
+	assert.True(t, sampledIDs.Contains(vdr2), "should have sampled vdr2")
+}
+
+func TestFilteredSetSampleFallback(t *testing.T) {
+	vdr0 := ids.GenerateTestShortID()
+	vdr1 := ids.GenerateTestShortID()
+
+	s := NewSet()
+	err := s.AddWeight(vdr0, math.MaxInt64-1)
+	assert.NoError(t, err)
+	err = s.AddWeight(vdr1, 1)
+	assert.NoError(t, err)
+
+	filter := &testSampleFilter{}
+	filter.skipped.Add(vdr0)
+	filtered := NewFilteredSet(s, filter)
+
+	// The accepted validators don't have enough weight to fill the sample, so
+	// all the validators should be sampled
+	sampled, err := filtered.Sample(2)
+	assert.NoError(t, err)
+	assert.Len(t, sampled, 2, "should have sampled two validators")
+	assert.Equal(t, vdr0, sampled[0].ID(), "should have sampled vdr0")
+	assert.Equal(t, vdr0, sampled[1].ID(), "should have sampled vdr0")
+}
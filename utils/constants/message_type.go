@@ -30,6 +30,10 @@ const (
 	GetAncestorsMsg
 	MultiPutMsg
 	GetAncestorsFailedMsg
+	AppRequestMsg
+	AppResponseMsg
+	AppRequestFailedMsg
+	AppGossipMsg
 )
 
 func (t MsgType) String() string {
@@ -76,6 +80,14 @@ func (t MsgType) String() string {
 		return "Notify Message"
 	case GossipMsg:
 		return "Gossip Message"
+	case AppRequestMsg:
+		return "App Request Message"
+	case AppResponseMsg:
+		return "App Response Message"
+	case AppRequestFailedMsg:
+		return "App Request Failed Message"
+	case AppGossipMsg:
+		return "App Gossip Message"
 	default:
 		return fmt.Sprintf("Unknown Message Type: %d", t)
 	}
@@ -4,6 +4,7 @@
 package utils
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -84,6 +85,15 @@ func (ipDesc IPDesc) IsZero() bool {
 		ip.Equal(net.IPv6zero)
 }
 
+// IPCertDesc is an IP claim that was signed, along with the time the claim was
+// made, by the staking key certified by [Cert].
+type IPCertDesc struct {
+	Cert      *x509.Certificate
+	IPDesc    IPDesc
+	Time      uint64
+	Signature []byte
+}
+
 // ToIPDesc ...
 func ToIPDesc(str string) (IPDesc, error) {
 	host, portStr, err := net.SplitHostPort(str)
@@ -118,6 +118,24 @@ func (tm *AdaptiveTimeoutManager) Remove(id ids.ID) {
 	tm.remove(id, currentTime)
 }
 
+// RemoveMeasured removes the item that no longer needs to be there and returns
+// how long ago it was put. Returns false if the item didn't exist or had
+// already expired.
+func (tm *AdaptiveTimeoutManager) RemoveMeasured(id ids.ID) (time.Duration, bool) {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	currentTime := time.Now()
+
+	timeout, exists := tm.timeoutMap[id]
+	tm.remove(id, currentTime)
+	if !exists || timeout.deadline.Before(currentTime) {
+		return 0, false
+	}
+	start := timeout.deadline.Add(-timeout.duration)
+	return currentTime.Sub(start), true
+}
+
 // Timeout registers a timeout
 func (tm *AdaptiveTimeoutManager) Timeout() {
 	tm.lock.Lock()
@@ -4,6 +4,7 @@
 package wrappers
 
 import (
+	"crypto/x509"
 	"encoding/binary"
 	"errors"
 	"math"
@@ -321,6 +322,57 @@ func (p *Packer) UnpackIPs() []utils.IPDesc {
 	return ips
 }
 
+// PackIPCert packs a signed ip port pair, along with the certificate that
+// signed it, to the byte array
+func (p *Packer) PackIPCert(ipCert utils.IPCertDesc) {
+	p.PackBytes(ipCert.Cert.Raw)
+	p.PackIP(ipCert.IPDesc)
+	p.PackLong(ipCert.Time)
+	p.PackBytes(ipCert.Signature)
+}
+
+// UnpackIPCert unpacks a signed ip port pair, along with the certificate that
+// signed it, from the byte array
+func (p *Packer) UnpackIPCert() utils.IPCertDesc {
+	certBytes := p.UnpackBytes()
+	ip := p.UnpackIP()
+	time := p.UnpackLong()
+	signature := p.UnpackBytes()
+	if p.Errored() {
+		return utils.IPCertDesc{}
+	}
+
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		p.Add(err)
+		return utils.IPCertDesc{}
+	}
+	return utils.IPCertDesc{
+		Cert:      cert,
+		IPDesc:    ip,
+		Time:      time,
+		Signature: signature,
+	}
+}
+
+// PackIPCerts packs a signed ip port pair slice to the byte array
+func (p *Packer) PackIPCerts(ipCerts []utils.IPCertDesc) {
+	p.PackInt(uint32(len(ipCerts)))
+	for i := 0; i < len(ipCerts) && !p.Errored(); i++ {
+		p.PackIPCert(ipCerts[i])
+	}
+}
+
+// UnpackIPCerts unpacks a signed ip port pair slice from the byte array
+func (p *Packer) UnpackIPCerts() []utils.IPCertDesc {
+	sliceSize := p.UnpackInt()
+	ipCerts := []utils.IPCertDesc(nil)
+	for i := uint32(0); i < sliceSize && !p.Errored(); i++ {
+		ipCerts = append(ipCerts, p.UnpackIPCert())
+	}
+	return ipCerts
+}
+
 // TryPackByte attempts to pack the value as a byte
 func TryPackByte(packer *Packer, valIntf interface{}) {
 	if val, ok := valIntf.(uint8); ok {
@@ -502,3 +554,18 @@ func TryPackIPList(packer *Packer, valIntf interface{}) {
 func TryUnpackIPList(packer *Packer) interface{} {
 	return packer.UnpackIPs()
 }
+
+// TryPackIPCertList attempts to pack the value as a signed ip port pair list
+func TryPackIPCertList(packer *Packer, valIntf interface{}) {
+	if val, ok := valIntf.([]utils.IPCertDesc); ok {
+		packer.PackIPCerts(val)
+	} else {
+		packer.Add(errBadType)
+	}
+}
+
+// TryUnpackIPCertList attempts to unpack the value as a signed ip port pair
+// list
+func TryUnpackIPCertList(packer *Packer) interface{} {
+	return packer.UnpackIPCerts()
+}
@@ -90,7 +90,9 @@ func (t *ExportTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiab
 	return t.BaseTx.SemanticVerify(vm, tx, creds)
 }
 
-// ExecuteWithSideEffects writes the batch with any additional side effects
+// ExecuteWithSideEffects writes the batch with any additional side effects.
+// The batch is written atomically with the addition of the exported UTXOs to
+// shared memory.
 func (t *ExportTx) ExecuteWithSideEffects(vm *VM, batch database.Batch) error {
 	txID := t.ID()
 
@@ -132,7 +132,9 @@ func (t *ImportTx) SemanticVerify(vm *VM, tx UnsignedTx, creds []verify.Verifiab
 	return nil
 }
 
-// ExecuteWithSideEffects writes the batch with any additional side effects
+// ExecuteWithSideEffects writes the batch with any additional side effects.
+// The batch is written atomically with the removal of the imported UTXOs from
+// shared memory.
 func (t *ImportTx) ExecuteWithSideEffects(vm *VM, batch database.Batch) error {
 	utxoIDs := make([][]byte, len(t.ImportedIns))
 	for i, in := range t.ImportedIns {
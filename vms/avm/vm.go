@@ -665,6 +665,15 @@ func (vm *VM) initState(genesisBytes []byte) error {
 	return vm.state.SetDBInitialized(choices.Processing)
 }
 
+// StoragePrefixes implements the chains.StoragePrefixer interface. Txs, UTXOs,
+// statuses and the funds indices are all stored under hashed keys, so the
+// state is reported as the VM's database as a whole.
+func (vm *VM) StoragePrefixes() map[string][]byte {
+	return map[string][]byte{
+		"state": nil,
+	}
+}
+
 func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
 	rawTx, err := vm.parsePrivateTx(bytes)
 	if err != nil {
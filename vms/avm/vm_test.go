@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/mockdb"
@@ -1548,3 +1549,24 @@ func TestTxVerifyAfterVerifyAncestorTx(t *testing.T) {
 		t.Fatalf("Should have errored due to a missing UTXO")
 	}
 }
+
+func TestStoragePrefixes(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	var prefixer chains.StoragePrefixer = vm
+	prefixes := prefixer.StoragePrefixes()
+	if len(prefixes) != 1 {
+		t.Fatalf("expected 1 prefix but got %d", len(prefixes))
+	}
+	// The state is reported as the VM's entire database
+	if prefix, ok := prefixes["state"]; !ok || len(prefix) != 0 {
+		t.Fatalf("expected the state to be reported as the entire database but got %v", prefixes)
+	}
+}
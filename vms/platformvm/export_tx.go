@@ -127,7 +127,8 @@ func (tx *UnsignedExportTx) SemanticVerify(
 	return nil
 }
 
-// Accept this transaction.
+// Accept this transaction. [batch] is written atomically with the addition of
+// the exported UTXOs to shared memory.
 func (tx *UnsignedExportTx) Accept(ctx *snow.Context, batch database.Batch) error {
 	txID := tx.ID()
 
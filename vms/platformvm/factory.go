@@ -32,6 +32,7 @@ type Factory struct {
 	MinStakeDuration   time.Duration // Min time allowed for validating
 	MaxStakeDuration   time.Duration // Max time allowed for validating
 	StakeMintingPeriod time.Duration // Staking consumption period
+	Uptimes            *Uptimes      // If non-nil, reports the uptimes observed by the created chain
 }
 
 // New returns a new instance of the Platform Chain
@@ -50,5 +51,6 @@ func (f *Factory) New(*snow.Context) (interface{}, error) {
 		minStakeDuration:   f.MinStakeDuration,
 		maxStakeDuration:   f.MaxStakeDuration,
 		stakeMintingPeriod: f.StakeMintingPeriod,
+		uptimes:            f.Uptimes,
 	}, nil
 }
@@ -914,6 +914,17 @@ func (vm *VM) deleteUptime(db database.Database, nodeID ids.ShortID) error {
 	return errs.Err
 }
 
+// StoragePrefixes implements the chains.StoragePrefixer interface. Subnets are
+// created while the chain runs, so only the primary network's validator sets
+// are reported.
+func (vm *VM) StoragePrefixes() map[string][]byte {
+	return map[string][]byte{
+		"primary network " + startDBPrefix: []byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, startDBPrefix)),
+		"primary network " + stopDBPrefix:  []byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, stopDBPrefix)),
+		uptimeDBPrefix:                     []byte(uptimeDBPrefix),
+	}
+}
+
 // Unmarshal a Block from bytes and initialize it
 // The Block being unmarshaled must have had static type Block when it was marshaled
 // i.e. don't do:
@@ -0,0 +1,106 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	errNoPlatformChain = errors.New("the platform chain hasn't been created")
+	errUnknownUptime   = errors.New("the uptime hasn't been calculated yet")
+	errNotBootstrapped = errors.New("the platform chain hasn't finished bootstrapping")
+	errNotValidator    = errors.New("node isn't a primary network validator")
+)
+
+// Uptimes reports the uptimes of primary network validators as observed by
+// the platform chain.
+//
+// Calculating an uptime requires the platform chain's context lock, which may
+// be held for long periods of time. So, uptimes are calculated in the
+// background and the most recently calculated uptime is reported.
+type Uptimes struct {
+	lock sync.Mutex
+	vm   *VM
+
+	// Node ID --> most recently calculated uptime
+	uptimes map[[20]byte]float64
+	// Node IDs whose uptimes are currently being calculated
+	calculating ids.ShortSet
+}
+
+// CalculateUptimePercent returns the most recently calculated uptime of
+// [nodeID] and starts calculating its current uptime in the background.
+// Returns an error if the uptime of [nodeID] hasn't been calculated yet.
+func (u *Uptimes) CalculateUptimePercent(nodeID ids.ShortID) (float64, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if u.vm == nil {
+		return 0, errNoPlatformChain
+	}
+
+	if !u.calculating.Contains(nodeID) {
+		u.calculating.Add(nodeID)
+		go u.calculate(u.vm, nodeID)
+	}
+
+	uptime, ok := u.uptimes[nodeID.Key()]
+	if !ok {
+		return 0, errUnknownUptime
+	}
+	return uptime, nil
+}
+
+func (u *Uptimes) calculate(vm *VM, nodeID ids.ShortID) {
+	uptime, err := vm.calculateCurrentUptime(nodeID)
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.calculating.Remove(nodeID)
+	if err != nil {
+		delete(u.uptimes, nodeID.Key())
+		return
+	}
+	if u.uptimes == nil {
+		u.uptimes = make(map[[20]byte]float64)
+	}
+	u.uptimes[nodeID.Key()] = uptime
+}
+
+func (u *Uptimes) setVM(vm *VM) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.vm = vm
+}
+
+// calculateCurrentUptime returns the uptime of [nodeID] during its current
+// staking period on the primary network. Grabs the context lock.
+func (vm *VM) calculateCurrentUptime(nodeID ids.ShortID) (float64, error) {
+	vm.Ctx.Lock.Lock()
+	defer vm.Ctx.Lock.Unlock()
+
+	if !vm.bootstrapped {
+		return 0, errNotBootstrapped
+	}
+
+	txIntf, isValidator, err := vm.isValidator(vm.DB, constants.PrimaryNetworkID, nodeID)
+	if err != nil {
+		return 0, err
+	}
+	if !isValidator {
+		return 0, errNotValidator
+	}
+	tx, ok := txIntf.(*UnsignedAddValidatorTx)
+	if !ok {
+		return 0, errNotValidator
+	}
+	return vm.calculateUptime(vm.DB, nodeID, tx.StartTime())
+}
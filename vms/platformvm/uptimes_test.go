@@ -0,0 +1,23 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Test that uptimes aren't calculated by a chain that hasn't been initialized
+func TestUptimesBeforeInitialize(t *testing.T) {
+	uptimes := &Uptimes{}
+	factory := &Factory{Uptimes: uptimes}
+	if _, err := factory.New(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uptimes.CalculateUptimePercent(ids.GenerateTestShortID()); err != errNoPlatformChain {
+		t.Fatalf("expected %s but got %v", errNoPlatformChain, err)
+	}
+}
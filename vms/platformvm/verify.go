@@ -0,0 +1,75 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var errWrongStakerKey = errors.New("staker isn't stored under its tx ID")
+
+// VerifyStakers parses every staker in subnet [subnetID]'s pending and current
+// validator queues in [db], the database of a platform chain, and checks that
+// each is stored under the ID of the transaction that added it. [onInvalid] is
+// called with the name of the queue, the key and the problem of each staker
+// that isn't valid. Returns an error if the queues can't be read.
+//
+// Only the validator queues are parsed. The rest of the chain's state, such as
+// its blocks, UTXOs and subnets, isn't checked.
+func VerifyStakers(db database.Database, subnetID ids.ID, onInvalid func(queue string, key []byte, err error)) error {
+	queues := []struct {
+		name  string
+		parse func([]byte) (*Tx, error)
+	}{
+		{
+			name: startDBPrefix,
+			parse: func(b []byte) (*Tx, error) {
+				tx := Tx{}
+				_, err := Codec.Unmarshal(b, &tx)
+				return &tx, err
+			},
+		},
+		{
+			name: stopDBPrefix,
+			parse: func(b []byte) (*Tx, error) {
+				tx := rewardTx{}
+				_, err := Codec.Unmarshal(b, &tx)
+				return &tx.Tx, err
+			},
+		},
+	}
+	for _, queue := range queues {
+		queueDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, queue.name)), db)
+		it := queueDB.NewIterator()
+		for it.Next() {
+			key := it.Key()
+			tx, err := queue.parse(it.Value())
+			if err == nil {
+				err = tx.Sign(Codec, nil)
+			}
+			if err == nil {
+				// Key: [Staker start or stop time] | [Priority] | [Tx ID]
+				txID := tx.ID()
+				if !bytes.HasSuffix(key, txID[:]) {
+					err = errWrongStakerKey
+				}
+			}
+			if err != nil {
+				onInvalid(queue.name, key, err)
+			}
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return fmt.Errorf("couldn't read %s queue: %w", queue.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestVerifyStakers(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	onInvalid := func(queue string, key []byte, err error) {
+		t.Fatalf("unexpected invalid staker in %s queue at key %x: %s", queue, key, err)
+	}
+	if err := VerifyStakers(vm.DB, constants.PrimaryNetworkID, onInvalid); err != nil {
+		t.Fatal(err)
+	}
+
+	// The genesis validators are all current validators
+	stopDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, stopDBPrefix)), vm.DB)
+	it := stopDB.NewIterator()
+	if !it.Next() {
+		t.Fatal("expected a current validator")
+	}
+	key := it.Key()
+	value := it.Value()
+	it.Release()
+
+	// Move the validator to the wrong key and add an unparsable validator
+	movedKey := append([]byte{}, key...)
+	movedKey[len(movedKey)-1]++
+	if err := stopDB.Put(movedKey, value); err != nil {
+		t.Fatal(err)
+	}
+	if err := stopDB.Put([]byte{0}, []byte{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := map[string]error{}
+	onInvalid = func(queue string, key []byte, err error) {
+		if queue != stopDBPrefix {
+			t.Fatalf("unexpected invalid staker in %s queue", queue)
+		}
+		invalid[string(key)] = err
+	}
+	if err := VerifyStakers(vm.DB, constants.PrimaryNetworkID, onInvalid); err != nil {
+		t.Fatal(err)
+	}
+	// Shutting down the VM reads the current validators
+	if err := stopDB.Delete(movedKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := stopDB.Delete([]byte{0}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(invalid) != 2 {
+		t.Fatalf("expected 2 invalid stakers but found %d", len(invalid))
+	}
+	if err := invalid[string(movedKey)]; err != errWrongStakerKey {
+		t.Fatalf("expected %s but got %v", errWrongStakerKey, err)
+	}
+	if err := invalid[string([]byte{0})]; err == nil {
+		t.Fatal("expected the unparsable validator to be invalid")
+	}
+}
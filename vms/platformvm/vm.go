@@ -163,6 +163,10 @@ type VM struct {
 	bootstrappedTime time.Time
 
 	connections map[[20]byte]time.Time
+
+	// If non-nil, reports the uptimes observed by this chain once it's
+	// initialized
+	uptimes *Uptimes
 }
 
 // Initialize this blockchain.
@@ -331,6 +335,10 @@ func (vm *VM) Initialize(
 		return errInvalidLastAcceptedBlock
 	}
 
+	// Uptimes may only be calculated once the VM is initialized
+	if vm.uptimes != nil {
+		vm.uptimes.setVM(vm)
+	}
 	return nil
 }
 
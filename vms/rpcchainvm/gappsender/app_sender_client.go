@@ -0,0 +1,61 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gappsender
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
+)
+
+var (
+	_ common.AppSender = &Client{}
+)
+
+// Client is an implementation of an application level message sender that
+// talks over RPC.
+type Client struct {
+	client gappsenderproto.AppSenderClient
+}
+
+// NewClient returns an app sender instance connected to a remote app sender
+// instance
+func NewClient(client gappsenderproto.AppSenderClient) *Client {
+	return &Client{client: client}
+}
+
+// AppRequest ...
+func (c *Client) AppRequest(nodeIDs ids.ShortSet, requestID uint32, appRequestBytes []byte) {
+	nodeIDsList := nodeIDs.List()
+	nodeIDsBytes := make([][]byte, len(nodeIDsList))
+	for i, nodeID := range nodeIDsList {
+		nodeIDsBytes[i] = nodeID.Bytes()
+	}
+	// Sending is best effort, so there is nothing to do with the error
+	_, _ = c.client.SendAppRequest(context.Background(), &gappsenderproto.SendAppRequestMsg{
+		NodeIDs:   nodeIDsBytes,
+		RequestID: requestID,
+		Request:   appRequestBytes,
+	})
+}
+
+// AppResponse ...
+func (c *Client) AppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte) {
+	// Sending is best effort, so there is nothing to do with the error
+	_, _ = c.client.SendAppResponse(context.Background(), &gappsenderproto.SendAppResponseMsg{
+		NodeID:    nodeID.Bytes(),
+		RequestID: requestID,
+		Response:  appResponseBytes,
+	})
+}
+
+// AppGossip ...
+func (c *Client) AppGossip(appGossipBytes []byte) {
+	// Sending is best effort, so there is nothing to do with the error
+	_, _ = c.client.SendAppGossip(context.Background(), &gappsenderproto.SendAppGossipMsg{
+		Msg: appGossipBytes,
+	})
+}
@@ -0,0 +1,53 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gappsender
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
+)
+
+// Server is an application level message sender that is managed over RPC.
+type Server struct {
+	appSender common.AppSender
+}
+
+// NewServer returns an app sender instance connected to a remote app sender
+// instance
+func NewServer(appSender common.AppSender) *Server {
+	return &Server{appSender: appSender}
+}
+
+// SendAppRequest ...
+func (s *Server) SendAppRequest(_ context.Context, req *gappsenderproto.SendAppRequestMsg) (*gappsenderproto.EmptyMsg, error) {
+	nodeIDs := ids.ShortSet{}
+	for _, nodeIDBytes := range req.NodeIDs {
+		nodeID, err := ids.ToShortID(nodeIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		nodeIDs.Add(nodeID)
+	}
+	s.appSender.AppRequest(nodeIDs, req.RequestID, req.Request)
+	return &gappsenderproto.EmptyMsg{}, nil
+}
+
+// SendAppResponse ...
+func (s *Server) SendAppResponse(_ context.Context, req *gappsenderproto.SendAppResponseMsg) (*gappsenderproto.EmptyMsg, error) {
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	s.appSender.AppResponse(nodeID, req.RequestID, req.Response)
+	return &gappsenderproto.EmptyMsg{}, nil
+}
+
+// SendAppGossip ...
+func (s *Server) SendAppGossip(_ context.Context, req *gappsenderproto.SendAppGossipMsg) (*gappsenderproto.EmptyMsg, error) {
+	s.appSender.AppGossip(req.Msg)
+	return &gappsenderproto.EmptyMsg{}, nil
+}
@@ -0,0 +1,387 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: gappsender.proto
+
+package gappsenderproto
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SendAppRequestMsg struct {
+	NodeIDs              [][]byte `protobuf:"bytes,1,rep,name=nodeIDs,proto3" json:"nodeIDs,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Request              []byte   `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendAppRequestMsg) Reset()         { *m = SendAppRequestMsg{} }
+func (m *SendAppRequestMsg) String() string { return proto.CompactTextString(m) }
+func (*SendAppRequestMsg) ProtoMessage()    {}
+func (*SendAppRequestMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67135bc9eb95e390, []int{0}
+}
+
+func (m *SendAppRequestMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendAppRequestMsg.Unmarshal(m, b)
+}
+func (m *SendAppRequestMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendAppRequestMsg.Marshal(b, m, deterministic)
+}
+func (m *SendAppRequestMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendAppRequestMsg.Merge(m, src)
+}
+func (m *SendAppRequestMsg) XXX_Size() int {
+	return xxx_messageInfo_SendAppRequestMsg.Size(m)
+}
+func (m *SendAppRequestMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendAppRequestMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendAppRequestMsg proto.InternalMessageInfo
+
+func (m *SendAppRequestMsg) GetNodeIDs() [][]byte {
+	if m != nil {
+		return m.NodeIDs
+	}
+	return nil
+}
+
+func (m *SendAppRequestMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+func (m *SendAppRequestMsg) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+type SendAppResponseMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendAppResponseMsg) Reset()         { *m = SendAppResponseMsg{} }
+func (m *SendAppResponseMsg) String() string { return proto.CompactTextString(m) }
+func (*SendAppResponseMsg) ProtoMessage()    {}
+func (*SendAppResponseMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67135bc9eb95e390, []int{1}
+}
+
+func (m *SendAppResponseMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendAppResponseMsg.Unmarshal(m, b)
+}
+func (m *SendAppResponseMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendAppResponseMsg.Marshal(b, m, deterministic)
+}
+func (m *SendAppResponseMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendAppResponseMsg.Merge(m, src)
+}
+func (m *SendAppResponseMsg) XXX_Size() int {
+	return xxx_messageInfo_SendAppResponseMsg.Size(m)
+}
+func (m *SendAppResponseMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendAppResponseMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendAppResponseMsg proto.InternalMessageInfo
+
+func (m *SendAppResponseMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *SendAppResponseMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+func (m *SendAppResponseMsg) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type SendAppGossipMsg struct {
+	Msg                  []byte   `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendAppGossipMsg) Reset()         { *m = SendAppGossipMsg{} }
+func (m *SendAppGossipMsg) String() string { return proto.CompactTextString(m) }
+func (*SendAppGossipMsg) ProtoMessage()    {}
+func (*SendAppGossipMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67135bc9eb95e390, []int{2}
+}
+
+func (m *SendAppGossipMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendAppGossipMsg.Unmarshal(m, b)
+}
+func (m *SendAppGossipMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendAppGossipMsg.Marshal(b, m, deterministic)
+}
+func (m *SendAppGossipMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendAppGossipMsg.Merge(m, src)
+}
+func (m *SendAppGossipMsg) XXX_Size() int {
+	return xxx_messageInfo_SendAppGossipMsg.Size(m)
+}
+func (m *SendAppGossipMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendAppGossipMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendAppGossipMsg proto.InternalMessageInfo
+
+func (m *SendAppGossipMsg) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+type EmptyMsg struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmptyMsg) Reset()         { *m = EmptyMsg{} }
+func (m *EmptyMsg) String() string { return proto.CompactTextString(m) }
+func (*EmptyMsg) ProtoMessage()    {}
+func (*EmptyMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67135bc9eb95e390, []int{3}
+}
+
+func (m *EmptyMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmptyMsg.Unmarshal(m, b)
+}
+func (m *EmptyMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmptyMsg.Marshal(b, m, deterministic)
+}
+func (m *EmptyMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmptyMsg.Merge(m, src)
+}
+func (m *EmptyMsg) XXX_Size() int {
+	return xxx_messageInfo_EmptyMsg.Size(m)
+}
+func (m *EmptyMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmptyMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmptyMsg proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*SendAppRequestMsg)(nil), "gappsenderproto.SendAppRequestMsg")
+	proto.RegisterType((*SendAppResponseMsg)(nil), "gappsenderproto.SendAppResponseMsg")
+	proto.RegisterType((*SendAppGossipMsg)(nil), "gappsenderproto.SendAppGossipMsg")
+	proto.RegisterType((*EmptyMsg)(nil), "gappsenderproto.EmptyMsg")
+}
+
+func init() { proto.RegisterFile("gappsender.proto", fileDescriptor_67135bc9eb95e390) }
+
+var fileDescriptor_67135bc9eb95e390 = []byte{
+	// 252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0xc1, 0x4e, 0xc2, 0x40,
+	0x10, 0x86, 0xb3, 0x36, 0x41, 0x98, 0x88, 0xd4, 0x39, 0x98, 0x95, 0x78, 0xa8, 0xab, 0x07, 0x4e,
+	0x3d, 0xe8, 0x13, 0x90, 0x60, 0x0c, 0x87, 0xc6, 0x58, 0x9e, 0x40, 0xd3, 0xb1, 0xf1, 0x40, 0x77,
+	0xec, 0xd4, 0x83, 0x2f, 0x6f, 0xcc, 0x2e, 0xcb, 0x12, 0x20, 0xc0, 0x6d, 0xff, 0xc9, 0xec, 0xf7,
+	0x65, 0x7e, 0x48, 0xeb, 0x77, 0x66, 0xa1, 0xa6, 0xa2, 0x36, 0xe7, 0xd6, 0x76, 0x16, 0x47, 0x9b,
+	0x89, 0x1f, 0x18, 0x82, 0xab, 0x05, 0x35, 0xd5, 0x94, 0xb9, 0xa4, 0xef, 0x1f, 0x92, 0xae, 0x90,
+	0x1a, 0x35, 0x9c, 0x37, 0xb6, 0xa2, 0xf9, 0x4c, 0xb4, 0xca, 0x92, 0xc9, 0x45, 0xb9, 0x8e, 0x78,
+	0x0b, 0x83, 0x76, 0xb5, 0x37, 0x9f, 0xe9, 0xb3, 0x4c, 0x4d, 0x86, 0xe5, 0x66, 0xe0, 0xfe, 0x85,
+	0xa0, 0x93, 0x4c, 0xb9, 0x7f, 0x21, 0x9a, 0x4f, 0xc0, 0xa8, 0x11, 0xb6, 0x8d, 0x90, 0xf3, 0x5c,
+	0x43, 0x6f, 0x05, 0xd6, 0xca, 0xaf, 0x87, 0x74, 0xc2, 0x32, 0x86, 0x7e, 0x1b, 0x20, 0x41, 0x13,
+	0xb3, 0x79, 0x80, 0x34, 0x78, 0x5e, 0xac, 0xc8, 0x17, 0x3b, 0x4b, 0x0a, 0xc9, 0x52, 0xea, 0xa0,
+	0x70, 0x4f, 0x03, 0xd0, 0x7f, 0x5e, 0x72, 0xf7, 0x5b, 0x48, 0xfd, 0xf8, 0xa7, 0x60, 0x30, 0x65,
+	0x5e, 0xf8, 0x4e, 0xf0, 0x15, 0x2e, 0xb7, 0xeb, 0x40, 0x93, 0xef, 0x54, 0x96, 0xef, 0xf5, 0x35,
+	0xbe, 0xd9, 0xdb, 0x59, 0xe3, 0xf1, 0x0d, 0x46, 0x3b, 0x87, 0xe3, 0xfd, 0x61, 0x62, 0xac, 0xe6,
+	0x18, 0xb2, 0x80, 0xe1, 0xd6, 0x8d, 0x78, 0x77, 0x08, 0x18, 0x3b, 0x38, 0x82, 0xfb, 0xe8, 0xf9,
+	0xfc, 0xf4, 0x3f, 0x00, 0xed, 0x2c, 0x13, 0xab, 0x2d, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AppSenderClient is the client API for AppSender service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AppSenderClient interface {
+	SendAppRequest(ctx context.Context, in *SendAppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	SendAppResponse(ctx context.Context, in *SendAppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	SendAppGossip(ctx context.Context, in *SendAppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+}
+
+type appSenderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAppSenderClient(cc grpc.ClientConnInterface) AppSenderClient {
+	return &appSenderClient{cc}
+}
+
+func (c *appSenderClient) SendAppRequest(ctx context.Context, in *SendAppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/gappsenderproto.AppSender/SendAppRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appSenderClient) SendAppResponse(ctx context.Context, in *SendAppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/gappsenderproto.AppSender/SendAppResponse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appSenderClient) SendAppGossip(ctx context.Context, in *SendAppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/gappsenderproto.AppSender/SendAppGossip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AppSenderServer is the server API for AppSender service.
+type AppSenderServer interface {
+	SendAppRequest(context.Context, *SendAppRequestMsg) (*EmptyMsg, error)
+	SendAppResponse(context.Context, *SendAppResponseMsg) (*EmptyMsg, error)
+	SendAppGossip(context.Context, *SendAppGossipMsg) (*EmptyMsg, error)
+}
+
+// UnimplementedAppSenderServer can be embedded to have forward compatible implementations.
+type UnimplementedAppSenderServer struct {
+}
+
+func (*UnimplementedAppSenderServer) SendAppRequest(ctx context.Context, req *SendAppRequestMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAppRequest not implemented")
+}
+func (*UnimplementedAppSenderServer) SendAppResponse(ctx context.Context, req *SendAppResponseMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAppResponse not implemented")
+}
+func (*UnimplementedAppSenderServer) SendAppGossip(ctx context.Context, req *SendAppGossipMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAppGossip not implemented")
+}
+
+func RegisterAppSenderServer(s *grpc.Server, srv AppSenderServer) {
+	s.RegisterService(&_AppSender_serviceDesc, srv)
+}
+
+func _AppSender_SendAppRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendAppRequestMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppSenderServer).SendAppRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gappsenderproto.AppSender/SendAppRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppSenderServer).SendAppRequest(ctx, req.(*SendAppRequestMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppSender_SendAppResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendAppResponseMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppSenderServer).SendAppResponse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gappsenderproto.AppSender/SendAppResponse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppSenderServer).SendAppResponse(ctx, req.(*SendAppResponseMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppSender_SendAppGossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendAppGossipMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppSenderServer).SendAppGossip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gappsenderproto.AppSender/SendAppGossip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppSenderServer).SendAppGossip(ctx, req.(*SendAppGossipMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AppSender_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gappsenderproto.AppSender",
+	HandlerType: (*AppSenderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendAppRequest",
+			Handler:    _AppSender_SendAppRequest_Handler,
+		},
+		{
+			MethodName: "SendAppResponse",
+			Handler:    _AppSender_SendAppResponse_Handler,
+		},
+		{
+			MethodName: "SendAppGossip",
+			Handler:    _AppSender_SendAppGossip_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gappsender.proto",
+}
@@ -48,6 +48,6 @@ func (c *Client) GetDatabase(username, password string) (database.Database, erro
 		return nil, err
 	}
 
-	dbClient := rpcdb.NewClient(rpcdbproto.NewDatabaseClient(dbConn))
+	dbClient := rpcdb.NewClient(rpcdbproto.NewDatabaseClient(dbConn), rpcdb.DefaultIteratorPrefetch)
 	return dbClient, err
 }
@@ -14,9 +14,11 @@ import (
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/vmproto"
 )
 
-// Handshake is a common handshake that is shared by plugin and host.
+// Handshake is a common handshake that is shared by plugin and host. The
+// protocol version was bumped to 2 when app messages were added to the VM
+// protocol.
 var Handshake = plugin.HandshakeConfig{
-	ProtocolVersion:  1,
+	ProtocolVersion:  2,
 	MagicCookieKey:   "VM_PLUGIN",
 	MagicCookieValue: "dynamic",
 }
@@ -8,6 +8,8 @@ import (
 	"errors"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/go-plugin"
 
@@ -23,6 +25,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/missing"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gappsender"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp/ghttpproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gkeystore"
@@ -53,6 +57,7 @@ type VMClient struct {
 	sharedMemory *gsharedmemory.Server
 	bcLookup     *galiaslookup.Server
 	snLookup     *gsubnetlookup.Server
+	appSender    *gappsender.Server
 
 	serverCloser grpcutils.ServerCloser
 	conns        []*grpc.ClientConn
@@ -77,6 +82,11 @@ func (vm *VMClient) SetProcess(proc *plugin.Client) {
 	vm.proc = proc
 }
 
+// SetAppSender ...
+func (vm *VMClient) SetAppSender(appSender common.AppSender) {
+	vm.appSender = gappsender.NewServer(appSender)
+}
+
 // Initialize ...
 func (vm *VMClient) Initialize(
 	ctx *snow.Context,
@@ -122,6 +132,13 @@ func (vm *VMClient) Initialize(
 	snLookupBrokerID := vm.broker.NextId()
 	go vm.broker.AcceptAndServe(snLookupBrokerID, vm.startSNLookupServer)
 
+	// start the app sender server, if the chain is able to send app messages
+	appSenderBrokerID := uint32(0)
+	if vm.appSender != nil {
+		appSenderBrokerID = vm.broker.NextId()
+		go vm.broker.AcceptAndServe(appSenderBrokerID, vm.startAppSenderServer)
+	}
+
 	resp, err := vm.client.Initialize(context.Background(), &vmproto.InitializeRequest{
 		NetworkID:          ctx.NetworkID,
 		SubnetID:           ctx.SubnetID[:],
@@ -136,6 +153,7 @@ func (vm *VMClient) Initialize(
 		SharedMemoryServer: sharedMemoryBrokerID,
 		BcLookupServer:     bcLookupBrokerID,
 		SnLookupServer:     snLookupBrokerID,
+		AppSenderServer:    appSenderBrokerID,
 	})
 	if err != nil {
 		return err
@@ -192,6 +210,13 @@ func (vm *VMClient) startSNLookupServer(opts []grpc.ServerOption) *grpc.Server {
 	return server
 }
 
+func (vm *VMClient) startAppSenderServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	gappsenderproto.RegisterAppSenderServer(server, vm.appSender)
+	return server
+}
+
 // Bootstrapping ...
 func (vm *VMClient) Bootstrapping() error {
 	_, err := vm.client.Bootstrapping(context.Background(), &vmproto.BootstrappingRequest{})
@@ -335,6 +360,53 @@ func (vm *VMClient) Health() (interface{}, error) {
 	)
 }
 
+// AppRequest ...
+func (vm *VMClient) AppRequest(nodeID ids.ShortID, requestID uint32, request []byte) error {
+	_, err := vm.client.AppRequest(context.Background(), &vmproto.AppRequestMsg{
+		NodeID:    nodeID.Bytes(),
+		RequestID: requestID,
+		Request:   request,
+	})
+	return appMsgErr(err)
+}
+
+// AppRequestFailed ...
+func (vm *VMClient) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
+	_, err := vm.client.AppRequestFailed(context.Background(), &vmproto.AppRequestFailedMsg{
+		NodeID:    nodeID.Bytes(),
+		RequestID: requestID,
+	})
+	return appMsgErr(err)
+}
+
+// AppResponse ...
+func (vm *VMClient) AppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error {
+	_, err := vm.client.AppResponse(context.Background(), &vmproto.AppResponseMsg{
+		NodeID:    nodeID.Bytes(),
+		RequestID: requestID,
+		Response:  response,
+	})
+	return appMsgErr(err)
+}
+
+// AppGossip ...
+func (vm *VMClient) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	_, err := vm.client.AppGossip(context.Background(), &vmproto.AppGossipMsg{
+		NodeID: nodeID.Bytes(),
+		Msg:    msg,
+	})
+	return appMsgErr(err)
+}
+
+// appMsgErr ignores the error returned by a plugin that was built before app
+// messages were added to the VM protocol, and so doesn't implement them
+func appMsgErr(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	return err
+}
+
 // BlockClient is an implementation of Block that talks over RPC.
 type BlockClient struct {
 	vm *VMClient
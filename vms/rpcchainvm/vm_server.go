@@ -21,6 +21,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gappsender"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp/ghttpproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gkeystore"
@@ -123,13 +125,29 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 		return nil, err
 	}
 
-	dbClient := rpcdb.NewClient(rpcdbproto.NewDatabaseClient(dbConn))
+	dbClient := rpcdb.NewClient(rpcdbproto.NewDatabaseClient(dbConn), rpcdb.DefaultIteratorPrefetch)
 	msgClient := messenger.NewClient(messengerproto.NewMessengerClient(msgConn))
 	keystoreClient := gkeystore.NewClient(gkeystoreproto.NewKeystoreClient(keystoreConn), vm.broker)
 	sharedMemoryClient := gsharedmemory.NewClient(gsharedmemoryproto.NewSharedMemoryClient(sharedMemoryConn))
 	bcLookupClient := galiaslookup.NewClient(galiaslookupproto.NewAliasLookupClient(bcLookupConn))
 	snLookupClient := gsubnetlookup.NewClient(gsubnetlookupproto.NewSubnetLookupClient(snLookupConn))
 
+	var appSenderConn *grpc.ClientConn
+	if appVM, ok := vm.vm.(common.AppVM); ok && req.AppSenderServer != 0 {
+		appSenderConn, err = vm.broker.Dial(req.AppSenderServer)
+		if err != nil {
+			// Ignore closing error to return the original error
+			_ = dbConn.Close()
+			_ = msgConn.Close()
+			_ = keystoreConn.Close()
+			_ = sharedMemoryConn.Close()
+			_ = bcLookupConn.Close()
+			_ = snLookupConn.Close()
+			return nil, err
+		}
+		appVM.SetAppSender(gappsender.NewClient(gappsenderproto.NewAppSenderClient(appSenderConn)))
+	}
+
 	toEngine := make(chan common.Message, 1)
 	go func() {
 		for msg := range toEngine {
@@ -162,12 +180,18 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 		_ = sharedMemoryConn.Close()
 		_ = bcLookupConn.Close()
 		_ = snLookupConn.Close()
+		if appSenderConn != nil {
+			_ = appSenderConn.Close()
+		}
 		close(toEngine)
 		return nil, err
 	}
 
 	vm.conns = append(vm.conns, dbConn)
 	vm.conns = append(vm.conns, msgConn)
+	if appSenderConn != nil {
+		vm.conns = append(vm.conns, appSenderConn)
+	}
 	vm.toEngine = toEngine
 	lastAccepted := vm.vm.LastAccepted()
 	return &vmproto.InitializeResponse{
@@ -358,3 +382,55 @@ func (vm *VMServer) BlockReject(_ context.Context, req *vmproto.BlockRejectReque
 	}
 	return &vmproto.BlockRejectResponse{}, nil
 }
+
+// AppRequest ...
+func (vm *VMServer) AppRequest(_ context.Context, req *vmproto.AppRequestMsg) (*vmproto.AppMsgResponse, error) {
+	appVM, ok := vm.vm.(common.AppVM)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appVM.AppRequest(nodeID, req.RequestID, req.Request)
+}
+
+// AppRequestFailed ...
+func (vm *VMServer) AppRequestFailed(_ context.Context, req *vmproto.AppRequestFailedMsg) (*vmproto.AppMsgResponse, error) {
+	appVM, ok := vm.vm.(common.AppVM)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appVM.AppRequestFailed(nodeID, req.RequestID)
+}
+
+// AppResponse ...
+func (vm *VMServer) AppResponse(_ context.Context, req *vmproto.AppResponseMsg) (*vmproto.AppMsgResponse, error) {
+	appVM, ok := vm.vm.(common.AppVM)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appVM.AppResponse(nodeID, req.RequestID, req.Response)
+}
+
+// AppGossip ...
+func (vm *VMServer) AppGossip(_ context.Context, req *vmproto.AppGossipMsg) (*vmproto.AppMsgResponse, error) {
+	appVM, ok := vm.vm.(common.AppVM)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appVM.AppGossip(nodeID, req.Msg)
+}
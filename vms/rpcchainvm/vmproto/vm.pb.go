@@ -39,6 +39,7 @@ type InitializeRequest struct {
 	SharedMemoryServer   uint32   `protobuf:"varint,11,opt,name=sharedMemoryServer,proto3" json:"sharedMemoryServer,omitempty"`
 	BcLookupServer       uint32   `protobuf:"varint,12,opt,name=bcLookupServer,proto3" json:"bcLookupServer,omitempty"`
 	SnLookupServer       uint32   `protobuf:"varint,13,opt,name=snLookupServer,proto3" json:"snLookupServer,omitempty"`
+	AppSenderServer      uint32   `protobuf:"varint,14,opt,name=appSenderServer,proto3" json:"appSenderServer,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -160,6 +161,13 @@ func (m *InitializeRequest) GetSnLookupServer() uint32 {
 	return 0
 }
 
+func (m *InitializeRequest) GetAppSenderServer() uint32 {
+	if m != nil {
+		return m.AppSenderServer
+	}
+	return 0
+}
+
 type InitializeResponse struct {
 	LastAcceptedID       []byte   `protobuf:"bytes,1,opt,name=lastAcceptedID,proto3" json:"lastAcceptedID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1134,6 +1142,241 @@ func (m *HealthResponse) GetDetails() string {
 	return ""
 }
 
+type AppRequestMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Request              []byte   `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppRequestMsg) Reset()         { *m = AppRequestMsg{} }
+func (m *AppRequestMsg) String() string { return proto.CompactTextString(m) }
+func (*AppRequestMsg) ProtoMessage()    {}
+func (*AppRequestMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{27}
+}
+
+func (m *AppRequestMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppRequestMsg.Unmarshal(m, b)
+}
+func (m *AppRequestMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppRequestMsg.Marshal(b, m, deterministic)
+}
+func (m *AppRequestMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppRequestMsg.Merge(m, src)
+}
+func (m *AppRequestMsg) XXX_Size() int {
+	return xxx_messageInfo_AppRequestMsg.Size(m)
+}
+func (m *AppRequestMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppRequestMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppRequestMsg proto.InternalMessageInfo
+
+func (m *AppRequestMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppRequestMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+func (m *AppRequestMsg) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+type AppRequestFailedMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppRequestFailedMsg) Reset()         { *m = AppRequestFailedMsg{} }
+func (m *AppRequestFailedMsg) String() string { return proto.CompactTextString(m) }
+func (*AppRequestFailedMsg) ProtoMessage()    {}
+func (*AppRequestFailedMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{28}
+}
+
+func (m *AppRequestFailedMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppRequestFailedMsg.Unmarshal(m, b)
+}
+func (m *AppRequestFailedMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppRequestFailedMsg.Marshal(b, m, deterministic)
+}
+func (m *AppRequestFailedMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppRequestFailedMsg.Merge(m, src)
+}
+func (m *AppRequestFailedMsg) XXX_Size() int {
+	return xxx_messageInfo_AppRequestFailedMsg.Size(m)
+}
+func (m *AppRequestFailedMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppRequestFailedMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppRequestFailedMsg proto.InternalMessageInfo
+
+func (m *AppRequestFailedMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppRequestFailedMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+type AppResponseMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppResponseMsg) Reset()         { *m = AppResponseMsg{} }
+func (m *AppResponseMsg) String() string { return proto.CompactTextString(m) }
+func (*AppResponseMsg) ProtoMessage()    {}
+func (*AppResponseMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{29}
+}
+
+func (m *AppResponseMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppResponseMsg.Unmarshal(m, b)
+}
+func (m *AppResponseMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppResponseMsg.Marshal(b, m, deterministic)
+}
+func (m *AppResponseMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppResponseMsg.Merge(m, src)
+}
+func (m *AppResponseMsg) XXX_Size() int {
+	return xxx_messageInfo_AppResponseMsg.Size(m)
+}
+func (m *AppResponseMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppResponseMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppResponseMsg proto.InternalMessageInfo
+
+func (m *AppResponseMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppResponseMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+func (m *AppResponseMsg) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type AppGossipMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Msg                  []byte   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppGossipMsg) Reset()         { *m = AppGossipMsg{} }
+func (m *AppGossipMsg) String() string { return proto.CompactTextString(m) }
+func (*AppGossipMsg) ProtoMessage()    {}
+func (*AppGossipMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{30}
+}
+
+func (m *AppGossipMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppGossipMsg.Unmarshal(m, b)
+}
+func (m *AppGossipMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppGossipMsg.Marshal(b, m, deterministic)
+}
+func (m *AppGossipMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppGossipMsg.Merge(m, src)
+}
+func (m *AppGossipMsg) XXX_Size() int {
+	return xxx_messageInfo_AppGossipMsg.Size(m)
+}
+func (m *AppGossipMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppGossipMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppGossipMsg proto.InternalMessageInfo
+
+func (m *AppGossipMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppGossipMsg) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+type AppMsgResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppMsgResponse) Reset()         { *m = AppMsgResponse{} }
+func (m *AppMsgResponse) String() string { return proto.CompactTextString(m) }
+func (*AppMsgResponse) ProtoMessage()    {}
+func (*AppMsgResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{31}
+}
+
+func (m *AppMsgResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppMsgResponse.Unmarshal(m, b)
+}
+func (m *AppMsgResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppMsgResponse.Marshal(b, m, deterministic)
+}
+func (m *AppMsgResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppMsgResponse.Merge(m, src)
+}
+func (m *AppMsgResponse) XXX_Size() int {
+	return xxx_messageInfo_AppMsgResponse.Size(m)
+}
+func (m *AppMsgResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppMsgResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppMsgResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*InitializeRequest)(nil), "vmproto.InitializeRequest")
 	proto.RegisterType((*InitializeResponse)(nil), "vmproto.InitializeResponse")
@@ -1162,67 +1405,80 @@ func init() {
 	proto.RegisterType((*BlockRejectResponse)(nil), "vmproto.BlockRejectResponse")
 	proto.RegisterType((*HealthRequest)(nil), "vmproto.HealthRequest")
 	proto.RegisterType((*HealthResponse)(nil), "vmproto.HealthResponse")
+	proto.RegisterType((*AppRequestMsg)(nil), "vmproto.AppRequestMsg")
+	proto.RegisterType((*AppRequestFailedMsg)(nil), "vmproto.AppRequestFailedMsg")
+	proto.RegisterType((*AppResponseMsg)(nil), "vmproto.AppResponseMsg")
+	proto.RegisterType((*AppGossipMsg)(nil), "vmproto.AppGossipMsg")
+	proto.RegisterType((*AppMsgResponse)(nil), "vmproto.AppMsgResponse")
 }
 
-func init() {
-	proto.RegisterFile("vm.proto", fileDescriptor_cab246c8c7c5372d)
-}
+func init() { proto.RegisterFile("vm.proto", fileDescriptor_cab246c8c7c5372d) }
 
 var fileDescriptor_cab246c8c7c5372d = []byte{
-	// 841 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x6e, 0xf3, 0x44,
-	0x10, 0x55, 0x12, 0xbe, 0x24, 0x9d, 0xfc, 0xb4, 0xd9, 0x36, 0x89, 0x31, 0x2d, 0x04, 0x0b, 0x55,
-	0x05, 0xa1, 0x5c, 0x94, 0x2b, 0x24, 0xa4, 0xaa, 0x69, 0x0a, 0x8d, 0xa0, 0xa5, 0xb8, 0x52, 0x85,
-	0x04, 0x37, 0x4e, 0x3c, 0x6d, 0x4c, 0xd2, 0xb5, 0xd9, 0xdd, 0xa4, 0x0d, 0xcf, 0xc5, 0x4b, 0xf0,
-	0x56, 0xc8, 0xeb, 0xb5, 0xbd, 0x76, 0x1c, 0x55, 0xfa, 0xee, 0x32, 0x67, 0xce, 0x1c, 0xcf, 0xee,
-	0xce, 0x99, 0x40, 0x7d, 0xfd, 0x32, 0x0c, 0x98, 0x2f, 0x7c, 0x52, 0x5b, 0xbf, 0xc8, 0x1f, 0xd6,
-	0x7f, 0x15, 0xe8, 0x4c, 0xa8, 0x27, 0x3c, 0x67, 0xe9, 0xfd, 0x83, 0x36, 0xfe, 0xbd, 0x42, 0x2e,
-	0xc8, 0x31, 0xec, 0x51, 0x14, 0xaf, 0x3e, 0x5b, 0x4c, 0xc6, 0x46, 0x69, 0x50, 0x3a, 0x6b, 0xd9,
-	0x29, 0x40, 0x4c, 0xa8, 0xf3, 0xd5, 0x94, 0xa2, 0x98, 0x8c, 0x8d, 0xf2, 0xa0, 0x74, 0xd6, 0xb4,
-	0x93, 0x98, 0x18, 0x50, 0x9b, 0xcd, 0x1d, 0x8f, 0x4e, 0xc6, 0x46, 0x45, 0xa6, 0xe2, 0x90, 0xf4,
-	0xa0, 0x4a, 0x7d, 0x17, 0x27, 0x63, 0xe3, 0x13, 0x99, 0x50, 0x51, 0xa8, 0xf6, 0x76, 0xa5, 0x4a,
-	0x3e, 0x44, 0x6a, 0x71, 0x4c, 0x06, 0xd0, 0x70, 0xd6, 0xce, 0xdb, 0x25, 0xe7, 0xf2, 0x63, 0x55,
-	0x99, 0xd6, 0x21, 0x62, 0x41, 0xf3, 0x19, 0x29, 0x72, 0x8f, 0x8f, 0x36, 0x02, 0xb9, 0x51, 0x93,
-	0x94, 0x0c, 0x16, 0x7e, 0xc1, 0x9d, 0x3e, 0x20, 0x5b, 0x23, 0x33, 0xea, 0xf2, 0x30, 0x49, 0x1c,
-	0xd6, 0x23, 0x7d, 0xf6, 0x28, 0xaa, 0xfc, 0x9e, 0xcc, 0x67, 0x30, 0x72, 0x0a, 0xed, 0x05, 0x6e,
-	0xb8, 0xf0, 0x59, 0xcc, 0x02, 0xc9, 0xca, 0xa1, 0x64, 0x08, 0x84, 0xcf, 0x1d, 0x86, 0xee, 0x2d,
-	0xbe, 0xf8, 0x6c, 0xa3, 0xb8, 0x0d, 0xc9, 0x2d, 0xc8, 0x84, 0xba, 0xd3, 0xd9, 0x2f, 0xbe, 0xbf,
-	0x58, 0x05, 0x8a, 0xdb, 0x8c, 0x74, 0xb3, 0x68, 0xc8, 0xe3, 0x34, 0xc3, 0x6b, 0x45, 0xbc, 0x2c,
-	0x6a, 0xfd, 0x00, 0x44, 0x7f, 0x4a, 0x1e, 0xf8, 0x94, 0x63, 0x58, 0xbd, 0x74, 0xb8, 0xb8, 0x9c,
-	0xcd, 0x30, 0x10, 0xe8, 0xaa, 0x07, 0x6d, 0xda, 0x39, 0xd4, 0xea, 0xc1, 0xd1, 0xc8, 0xf7, 0x05,
-	0x17, 0xcc, 0x09, 0x02, 0x8f, 0x3e, 0xab, 0x59, 0xb0, 0xfa, 0xd0, 0xcd, 0xe1, 0x91, 0xb0, 0xd5,
-	0x85, 0xc3, 0x34, 0x81, 0x6e, 0xcc, 0xcf, 0xe8, 0x84, 0xb0, 0xa2, 0x77, 0x60, 0xff, 0x61, 0xbe,
-	0x12, 0xae, 0xff, 0x4a, 0x63, 0x2a, 0x81, 0x83, 0x14, 0x52, 0xb4, 0x3e, 0x74, 0xaf, 0x18, 0x3a,
-	0x02, 0x6f, 0x1c, 0xea, 0x2e, 0x91, 0xf1, 0x98, 0xfc, 0x23, 0xf4, 0xf2, 0x09, 0x75, 0xc2, 0x6f,
-	0xa1, 0x3e, 0x57, 0x98, 0x51, 0x1a, 0x54, 0xce, 0x1a, 0xe7, 0x07, 0x43, 0x35, 0xdf, 0x43, 0x45,
-	0xb6, 0x13, 0x86, 0xf5, 0x07, 0xd4, 0x14, 0x18, 0x8e, 0x64, 0xc0, 0xf0, 0xc9, 0x7b, 0x93, 0x57,
-	0xb2, 0x67, 0xab, 0x28, 0x1c, 0xbb, 0xa5, 0x3f, 0x5b, 0xfc, 0x1a, 0x08, 0xcf, 0xa7, 0x5c, 0xce,
-	0x78, 0xcb, 0xd6, 0xa1, 0xb0, 0x92, 0x47, 0x4f, 0x51, 0x91, 0x49, 0x15, 0x59, 0x87, 0xd0, 0x19,
-	0xad, 0xbc, 0xa5, 0x3b, 0x0a, 0xc9, 0x71, 0xe7, 0x8f, 0x40, 0x74, 0x50, 0x75, 0xdd, 0x86, 0xb2,
-	0xe7, 0xaa, 0xb7, 0x28, 0x7b, 0x6e, 0x38, 0xa5, 0x81, 0xc3, 0x90, 0x6a, 0xae, 0x8a, 0x63, 0x72,
-	0x04, 0x1f, 0xa6, 0x72, 0xbc, 0x23, 0x4f, 0x45, 0x81, 0xf5, 0x35, 0x74, 0xee, 0x1d, 0xc6, 0x51,
-	0xff, 0x58, 0x4a, 0x2d, 0xe9, 0xd4, 0xdf, 0x81, 0xe8, 0xd4, 0x8f, 0x68, 0x21, 0x3c, 0xb1, 0x70,
-	0xc4, 0x8a, 0x27, 0x27, 0x96, 0x91, 0xf5, 0x25, 0xec, 0xff, 0x84, 0x22, 0xd3, 0x42, 0x4e, 0xd6,
-	0xfa, 0x13, 0x0e, 0x52, 0x8a, 0xfa, 0xb4, 0xfe, 0xa9, 0xd2, 0xae, 0xd3, 0x96, 0xb5, 0x23, 0xec,
-	0x6c, 0xe0, 0x14, 0x8e, 0x1e, 0x50, 0xdc, 0x33, 0x7c, 0x42, 0x86, 0x74, 0x86, 0xbb, 0xba, 0xe8,
-	0x43, 0x37, 0xc7, 0x53, 0x13, 0xf7, 0x15, 0x10, 0xd9, 0xdb, 0x23, 0x32, 0xef, 0x69, 0xb3, 0xab,
-	0x3c, 0x9c, 0x76, 0x9d, 0x95, 0x2b, 0x8e, 0x8c, 0xf4, 0x5e, 0x71, 0xcc, 0xca, 0x15, 0xdb, 0xf8,
-	0x17, 0xce, 0xde, 0x2d, 0x8e, 0x59, 0xaa, 0x78, 0x1f, 0x5a, 0x37, 0xe8, 0x2c, 0xc5, 0x3c, 0x1e,
-	0xb3, 0x6f, 0xa0, 0x1d, 0x03, 0xea, 0x92, 0x0d, 0xa8, 0xb9, 0x28, 0x1c, 0x6f, 0xc9, 0xd5, 0x80,
-	0xc7, 0xe1, 0xf9, 0xbf, 0x35, 0x28, 0x3f, 0xde, 0x92, 0x6b, 0x80, 0x74, 0x63, 0x10, 0x33, 0x71,
-	0xcd, 0xd6, 0x3f, 0x82, 0xf9, 0x59, 0x61, 0x4e, 0x7d, 0xe7, 0x0e, 0x5a, 0x99, 0x15, 0x41, 0x4e,
-	0x12, 0x76, 0xd1, 0x4a, 0x31, 0x3f, 0xdf, 0x95, 0x56, 0x7a, 0x3f, 0x43, 0x53, 0x5f, 0x21, 0xe4,
-	0xb8, 0x80, 0x9f, 0x2c, 0x1c, 0xf3, 0x64, 0x47, 0x56, 0x89, 0x5d, 0x40, 0x3d, 0x5e, 0x32, 0xc4,
-	0x48, 0xa8, 0xb9, 0x55, 0x64, 0x7e, 0x5a, 0x90, 0x51, 0x02, 0xbf, 0x41, 0x3b, 0xbb, 0x78, 0x48,
-	0xda, 0x7f, 0xe1, 0xaa, 0x32, 0xbf, 0xd8, 0x99, 0x57, 0x92, 0xd7, 0x00, 0xe9, 0x46, 0xd0, 0xee,
-	0x7d, 0x6b, 0x77, 0x68, 0xf7, 0x5e, 0xb0, 0x42, 0xae, 0x01, 0x52, 0x57, 0x6b, 0x32, 0x5b, 0x5b,
-	0x41, 0x93, 0x29, 0x58, 0x03, 0x17, 0x50, 0x8f, 0xfd, 0xa9, 0xdd, 0x50, 0xce, 0xd5, 0xda, 0x0d,
-	0x6d, 0x99, 0xf9, 0x0e, 0x5a, 0x19, 0x6b, 0x69, 0xef, 0x5f, 0x64, 0x4d, 0xed, 0xfd, 0x0b, 0x1d,
-	0x49, 0xbe, 0x87, 0x6a, 0x34, 0xc9, 0xa4, 0x97, 0x2e, 0x72, 0x7d, 0xd6, 0xcd, 0xfe, 0x16, 0xae,
-	0x4a, 0x6f, 0xa0, 0xa1, 0xd9, 0x94, 0x68, 0xd7, 0xb7, 0x65, 0x71, 0xf3, 0xb8, 0x38, 0x99, 0x53,
-	0x8a, 0x3c, 0x9b, 0x57, 0xca, 0xf8, 0x3d, 0xaf, 0x94, 0xb5, 0x79, 0xa2, 0x14, 0x19, 0x38, 0xaf,
-	0x94, 0x31, 0x7f, 0x5e, 0x29, 0xeb, 0xf9, 0x69, 0x55, 0xa6, 0xbe, 0xfb, 0x3f, 0x00, 0x00, 0xff,
-	0xff, 0x4d, 0xf2, 0x90, 0xfe, 0xc9, 0x09, 0x00, 0x00,
+	// 998 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x7f, 0x6f, 0xe3, 0x44,
+	0x10, 0x55, 0x12, 0xae, 0x49, 0x26, 0x3f, 0x9a, 0x6e, 0x9b, 0xc6, 0xe7, 0xe6, 0x8e, 0x62, 0xa1,
+	0x53, 0x41, 0xa8, 0x7f, 0x1c, 0xff, 0x80, 0x00, 0x9d, 0xda, 0xeb, 0xdd, 0x35, 0x3a, 0x0a, 0x87,
+	0x2b, 0x55, 0x48, 0x20, 0x21, 0x27, 0x9e, 0x26, 0xa6, 0xa9, 0x6d, 0x76, 0x37, 0xbd, 0x86, 0xcf,
+	0xc6, 0xb7, 0xe2, 0x0b, 0x20, 0xaf, 0xc7, 0xf6, 0xda, 0xb1, 0xa9, 0xd4, 0xff, 0x3c, 0xf3, 0xde,
+	0xbc, 0x9d, 0xf5, 0xce, 0xbe, 0x85, 0xd6, 0xdd, 0xed, 0x71, 0xc8, 0x03, 0x19, 0xb0, 0xe6, 0xdd,
+	0xad, 0xfa, 0xb0, 0xfe, 0x6d, 0xc0, 0xce, 0xc4, 0xf7, 0xa4, 0xe7, 0x2c, 0xbd, 0xbf, 0xd1, 0xc6,
+	0xbf, 0x56, 0x28, 0x24, 0x1b, 0x43, 0xdb, 0x47, 0xf9, 0x31, 0xe0, 0x37, 0x93, 0x33, 0xa3, 0x76,
+	0x58, 0x3b, 0xea, 0xd9, 0x59, 0x82, 0x99, 0xd0, 0x12, 0xab, 0xa9, 0x8f, 0x72, 0x72, 0x66, 0xd4,
+	0x0f, 0x6b, 0x47, 0x5d, 0x3b, 0x8d, 0x99, 0x01, 0xcd, 0xd9, 0xc2, 0xf1, 0xfc, 0xc9, 0x99, 0xd1,
+	0x50, 0x50, 0x12, 0xb2, 0x7d, 0xd8, 0xf2, 0x03, 0x17, 0x27, 0x67, 0xc6, 0x27, 0x0a, 0xa0, 0x28,
+	0x52, 0xbb, 0x7f, 0x4d, 0x25, 0x4f, 0x62, 0xb5, 0x24, 0x66, 0x87, 0xd0, 0x71, 0xee, 0x9c, 0xfb,
+	0x13, 0x21, 0xd4, 0x62, 0x5b, 0x0a, 0xd6, 0x53, 0xcc, 0x82, 0xee, 0x1c, 0x7d, 0x14, 0x9e, 0x38,
+	0x5d, 0x4b, 0x14, 0x46, 0x53, 0x51, 0x72, 0xb9, 0x68, 0x05, 0x77, 0x7a, 0x89, 0xfc, 0x0e, 0xb9,
+	0xd1, 0x52, 0x9b, 0x49, 0xe3, 0xa8, 0x1e, 0xfd, 0xb9, 0xe7, 0x23, 0xe1, 0x6d, 0x85, 0xe7, 0x72,
+	0xec, 0x05, 0xf4, 0x6f, 0x70, 0x2d, 0x64, 0xc0, 0x13, 0x16, 0x28, 0x56, 0x21, 0xcb, 0x8e, 0x81,
+	0x89, 0x85, 0xc3, 0xd1, 0xbd, 0xc0, 0xdb, 0x80, 0xaf, 0x89, 0xdb, 0x51, 0xdc, 0x12, 0x24, 0xd2,
+	0x9d, 0xce, 0x7e, 0x0c, 0x82, 0x9b, 0x55, 0x48, 0xdc, 0x6e, 0xac, 0x9b, 0xcf, 0x46, 0x3c, 0xe1,
+	0xe7, 0x78, 0xbd, 0x98, 0x97, 0xcf, 0xb2, 0x23, 0xd8, 0x76, 0xc2, 0xf0, 0x12, 0x7d, 0x17, 0x39,
+	0x11, 0xfb, 0x8a, 0x58, 0x4c, 0x5b, 0xdf, 0x03, 0xd3, 0x0f, 0x5d, 0x84, 0x81, 0x2f, 0x30, 0x5a,
+	0x67, 0xe9, 0x08, 0x79, 0x32, 0x9b, 0x61, 0x28, 0xd1, 0xa5, 0xa3, 0xef, 0xda, 0x85, 0xac, 0xb5,
+	0x0f, 0x7b, 0xa7, 0x41, 0x20, 0x85, 0xe4, 0x4e, 0x18, 0x7a, 0xfe, 0x9c, 0xa6, 0xc6, 0x1a, 0xc1,
+	0xb0, 0x90, 0x8f, 0x85, 0xad, 0x21, 0xec, 0x66, 0x00, 0xba, 0x09, 0x3f, 0xa7, 0x83, 0x6e, 0x4a,
+	0xdf, 0x81, 0xed, 0xcb, 0xc5, 0x4a, 0xba, 0xc1, 0x47, 0x3f, 0xa1, 0x32, 0x18, 0x64, 0x29, 0xa2,
+	0x8d, 0x60, 0xf8, 0x9a, 0xa3, 0x23, 0xf1, 0xdc, 0xf1, 0xdd, 0x25, 0x72, 0x91, 0x90, 0xdf, 0xc2,
+	0x7e, 0x11, 0xa0, 0x1d, 0x7e, 0x05, 0xad, 0x05, 0xe5, 0x8c, 0xda, 0x61, 0xe3, 0xa8, 0xf3, 0x72,
+	0x70, 0x4c, 0x37, 0xe1, 0x98, 0xc8, 0x76, 0xca, 0xb0, 0x7e, 0x83, 0x26, 0x25, 0xa3, 0xe1, 0x0d,
+	0x39, 0x5e, 0x7b, 0xf7, 0xea, 0x97, 0xb4, 0x6d, 0x8a, 0xa2, 0x01, 0x5d, 0x06, 0xb3, 0x9b, 0x9f,
+	0x43, 0xe9, 0x05, 0xbe, 0x50, 0xb7, 0xa1, 0x67, 0xeb, 0xa9, 0xa8, 0x52, 0xc4, 0x67, 0xd1, 0x50,
+	0x20, 0x45, 0xd6, 0x2e, 0xec, 0x9c, 0xae, 0xbc, 0xa5, 0x7b, 0x1a, 0x91, 0x93, 0xce, 0xaf, 0x80,
+	0xe9, 0x49, 0xea, 0xba, 0x0f, 0x75, 0xcf, 0xa5, 0xb3, 0xa8, 0x7b, 0x6e, 0x34, 0xcf, 0xa1, 0xc3,
+	0xd1, 0xd7, 0xee, 0x5f, 0x12, 0xb3, 0x3d, 0x78, 0x32, 0x55, 0x17, 0x21, 0xbe, 0x7d, 0x71, 0x60,
+	0x7d, 0x01, 0x3b, 0x1f, 0x1c, 0x2e, 0x50, 0x5f, 0x2c, 0xa3, 0xd6, 0x74, 0xea, 0xaf, 0xc0, 0x74,
+	0xea, 0x23, 0x5a, 0x88, 0x76, 0x2c, 0x1d, 0xb9, 0x12, 0xe9, 0x8e, 0x55, 0x64, 0x7d, 0x06, 0xdb,
+	0xef, 0x50, 0xe6, 0x5a, 0x28, 0xc8, 0x5a, 0xbf, 0xc3, 0x20, 0xa3, 0xd0, 0xd2, 0xfa, 0x52, 0xb5,
+	0xaa, 0xdd, 0xd6, 0xb5, 0x2d, 0x54, 0x36, 0xf0, 0x02, 0xf6, 0x2e, 0x51, 0x7e, 0xe0, 0x78, 0x8d,
+	0x1c, 0xfd, 0x19, 0x56, 0x75, 0x31, 0x82, 0x61, 0x81, 0x47, 0x13, 0xf7, 0x39, 0x30, 0xd5, 0xdb,
+	0x15, 0x72, 0xef, 0x7a, 0x5d, 0x55, 0x1e, 0x4d, 0xbb, 0xce, 0x2a, 0x14, 0xc7, 0x17, 0xe9, 0xa1,
+	0xe2, 0x84, 0x55, 0x28, 0xb6, 0xf1, 0x4f, 0x9c, 0x3d, 0x58, 0x9c, 0xb0, 0xa8, 0x78, 0x1b, 0x7a,
+	0xe7, 0xe8, 0x2c, 0xe5, 0x22, 0x19, 0xb3, 0x2f, 0xa1, 0x9f, 0x24, 0xe8, 0x27, 0x1b, 0xd0, 0x74,
+	0x51, 0x3a, 0xde, 0x52, 0xd0, 0x80, 0x27, 0xa1, 0xf5, 0x07, 0xf4, 0x4e, 0xc2, 0x90, 0x2a, 0x2f,
+	0xc4, 0x5c, 0xf3, 0xf1, 0x5a, 0xce, 0xc7, 0xc7, 0xd0, 0xe6, 0x31, 0x8b, 0x66, 0xa2, 0x67, 0x67,
+	0x89, 0x68, 0x01, 0x0a, 0x92, 0x77, 0x81, 0x42, 0xeb, 0x3d, 0xec, 0x66, 0x0b, 0xbc, 0x75, 0xbc,
+	0x25, 0xba, 0x8f, 0x5e, 0xc6, 0x9a, 0x42, 0x5f, 0x89, 0xc5, 0xdb, 0x7a, 0x7c, 0xbb, 0x26, 0xb4,
+	0x38, 0x89, 0x50, 0xbf, 0x69, 0x6c, 0x7d, 0x03, 0xdd, 0x93, 0x30, 0x7c, 0x17, 0x08, 0xe1, 0x85,
+	0xff, 0xb7, 0xc2, 0x00, 0x1a, 0xb7, 0x62, 0x4e, 0xa3, 0x19, 0x7d, 0x5a, 0x03, 0xd5, 0xdd, 0x85,
+	0x48, 0x9d, 0xf1, 0xe5, 0x3f, 0x6d, 0xa8, 0x5f, 0x5d, 0xb0, 0x37, 0x00, 0x99, 0x1f, 0x33, 0x33,
+	0xf5, 0xa4, 0x8d, 0x97, 0xd9, 0x3c, 0x28, 0xc5, 0xe8, 0x14, 0x7f, 0x82, 0x5e, 0xce, 0x80, 0xd9,
+	0xb3, 0x94, 0x5d, 0x66, 0xd8, 0xe6, 0xf3, 0x2a, 0x98, 0xf4, 0xde, 0x43, 0x57, 0x37, 0x68, 0x36,
+	0x2e, 0xe1, 0xa7, 0x76, 0x6e, 0x3e, 0xab, 0x40, 0x49, 0xec, 0x15, 0xb4, 0x12, 0x0b, 0x67, 0x46,
+	0x4a, 0x2d, 0x18, 0xbd, 0xf9, 0xb4, 0x04, 0x21, 0x81, 0x5f, 0xa0, 0x9f, 0xb7, 0x75, 0x96, 0xf5,
+	0x5f, 0xfa, 0x10, 0x98, 0x9f, 0x56, 0xe2, 0x24, 0xf9, 0x06, 0x20, 0xf3, 0x5b, 0xed, 0xbf, 0x6f,
+	0x38, 0xb3, 0x79, 0x50, 0x8a, 0x65, 0x32, 0x99, 0x67, 0x6a, 0x32, 0x1b, 0x9e, 0x6b, 0x1e, 0x94,
+	0x62, 0xd9, 0x1f, 0x4a, 0xdc, 0x4f, 0xfb, 0x43, 0x05, 0xcf, 0x34, 0x9f, 0x96, 0x20, 0xd9, 0xf9,
+	0xe7, 0x8c, 0x4b, 0x3b, 0xff, 0x32, 0xe3, 0x33, 0x9f, 0x57, 0xc1, 0xa4, 0xf7, 0x2d, 0x6c, 0xc5,
+	0x3e, 0xc1, 0xf6, 0xb3, 0x67, 0x52, 0x77, 0x12, 0x73, 0xb4, 0x91, 0xa7, 0xd2, 0x1f, 0x00, 0xb2,
+	0x5b, 0xad, 0x95, 0xe7, 0xbc, 0x44, 0x2b, 0xcf, 0xdf, 0x0b, 0x36, 0x81, 0x41, 0xd1, 0x14, 0xb4,
+	0xe9, 0x2b, 0xf1, 0x8b, 0x6a, 0xa9, 0x57, 0xd0, 0xd1, 0x2c, 0x81, 0x8d, 0xf2, 0x2a, 0xa9, 0x51,
+	0x54, 0x0b, 0x7c, 0x07, 0xed, 0xf4, 0xbe, 0xb3, 0xa1, 0xce, 0x4a, 0x3d, 0xa0, 0xba, 0xf8, 0x1c,
+	0x3a, 0xda, 0x63, 0xc0, 0xb4, 0x31, 0xda, 0x78, 0x48, 0xcc, 0x71, 0x39, 0x58, 0x50, 0x8a, 0x5f,
+	0x86, 0xa2, 0x52, 0xee, 0x55, 0x31, 0xc7, 0xe5, 0x60, 0x41, 0x29, 0x7e, 0x26, 0x8a, 0x4a, 0xb9,
+	0x27, 0xc6, 0x1c, 0x97, 0x83, 0xb1, 0xd2, 0x74, 0x4b, 0x41, 0x5f, 0xff, 0x37, 0x00, 0x8f, 0x80,
+	0x1e, 0x4b, 0x59, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1247,6 +1503,10 @@ type VMClient interface {
 	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
 	SetPreference(ctx context.Context, in *SetPreferenceRequest, opts ...grpc.CallOption) (*SetPreferenceResponse, error)
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	AppRequest(ctx context.Context, in *AppRequestMsg, opts ...grpc.CallOption) (*AppMsgResponse, error)
+	AppRequestFailed(ctx context.Context, in *AppRequestFailedMsg, opts ...grpc.CallOption) (*AppMsgResponse, error)
+	AppResponse(ctx context.Context, in *AppResponseMsg, opts ...grpc.CallOption) (*AppMsgResponse, error)
+	AppGossip(ctx context.Context, in *AppGossipMsg, opts ...grpc.CallOption) (*AppMsgResponse, error)
 	BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error)
 	BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*BlockAcceptResponse, error)
 	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*BlockRejectResponse, error)
@@ -1350,6 +1610,42 @@ func (c *vMClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *vMClient) AppRequest(ctx context.Context, in *AppRequestMsg, opts ...grpc.CallOption) (*AppMsgResponse, error) {
+	out := new(AppMsgResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) AppRequestFailed(ctx context.Context, in *AppRequestFailedMsg, opts ...grpc.CallOption) (*AppMsgResponse, error) {
+	out := new(AppMsgResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppRequestFailed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) AppResponse(ctx context.Context, in *AppResponseMsg, opts ...grpc.CallOption) (*AppMsgResponse, error) {
+	out := new(AppMsgResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppResponse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) AppGossip(ctx context.Context, in *AppGossipMsg, opts ...grpc.CallOption) (*AppMsgResponse, error) {
+	out := new(AppMsgResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppGossip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vMClient) BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error) {
 	out := new(BlockVerifyResponse)
 	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockVerify", in, out, opts...)
@@ -1389,6 +1685,10 @@ type VMServer interface {
 	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
 	SetPreference(context.Context, *SetPreferenceRequest) (*SetPreferenceResponse, error)
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	AppRequest(context.Context, *AppRequestMsg) (*AppMsgResponse, error)
+	AppRequestFailed(context.Context, *AppRequestFailedMsg) (*AppMsgResponse, error)
+	AppResponse(context.Context, *AppResponseMsg) (*AppMsgResponse, error)
+	AppGossip(context.Context, *AppGossipMsg) (*AppMsgResponse, error)
 	BlockVerify(context.Context, *BlockVerifyRequest) (*BlockVerifyResponse, error)
 	BlockAccept(context.Context, *BlockAcceptRequest) (*BlockAcceptResponse, error)
 	BlockReject(context.Context, *BlockRejectRequest) (*BlockRejectResponse, error)
@@ -1428,6 +1728,18 @@ func (*UnimplementedVMServer) SetPreference(ctx context.Context, req *SetPrefere
 func (*UnimplementedVMServer) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
 }
+func (*UnimplementedVMServer) AppRequest(ctx context.Context, req *AppRequestMsg) (*AppMsgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppRequest not implemented")
+}
+func (*UnimplementedVMServer) AppRequestFailed(ctx context.Context, req *AppRequestFailedMsg) (*AppMsgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppRequestFailed not implemented")
+}
+func (*UnimplementedVMServer) AppResponse(ctx context.Context, req *AppResponseMsg) (*AppMsgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppResponse not implemented")
+}
+func (*UnimplementedVMServer) AppGossip(ctx context.Context, req *AppGossipMsg) (*AppMsgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppGossip not implemented")
+}
 func (*UnimplementedVMServer) BlockVerify(ctx context.Context, req *BlockVerifyRequest) (*BlockVerifyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BlockVerify not implemented")
 }
@@ -1622,6 +1934,78 @@ func _VM_Health_Handler(srv interface{}, ctx context.Context, dec func(interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VM_AppRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppRequestMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppRequest(ctx, req.(*AppRequestMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_AppRequestFailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppRequestFailedMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppRequestFailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppRequestFailed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppRequestFailed(ctx, req.(*AppRequestFailedMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_AppResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppResponseMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppResponse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppResponse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppResponse(ctx, req.(*AppResponseMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_AppGossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppGossipMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppGossip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppGossip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppGossip(ctx, req.(*AppGossipMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VM_BlockVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlockVerifyRequest)
 	if err := dec(in); err != nil {
@@ -1720,6 +2104,22 @@ var _VM_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Health",
 			Handler:    _VM_Health_Handler,
 		},
+		{
+			MethodName: "AppRequest",
+			Handler:    _VM_AppRequest_Handler,
+		},
+		{
+			MethodName: "AppRequestFailed",
+			Handler:    _VM_AppRequestFailed_Handler,
+		},
+		{
+			MethodName: "AppResponse",
+			Handler:    _VM_AppResponse_Handler,
+		},
+		{
+			MethodName: "AppGossip",
+			Handler:    _VM_AppGossip_Handler,
+		},
 		{
 			MethodName: "BlockVerify",
 			Handler:    _VM_BlockVerify_Handler,